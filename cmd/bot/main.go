@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	_ "time/tzdata"
 
 	"max-moderation-bot/internal/app"
 	"max-moderation-bot/internal/config"
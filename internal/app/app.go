@@ -71,11 +71,29 @@ func (a *App) Run(ctx context.Context) error {
 	userStateRepo := repository.NewUserStateRepository(db)
 	tempMessageRepo := repository.NewTemporaryMessageRepository(db)
 	violationRepo := repository.NewViolationRepository(db)
-
-	svc := service.NewModerationService(a.logger, settingsRepo, chatAdminRepo, linkTokenRepo, muteRepo, tempMessageRepo, violationRepo, a.bot)
+	scheduleRepo := repository.NewScheduleRepository(db, a.cfg.EnableCache)
+	strikeLadderRepo := repository.NewStrikeLadderRepository(db)
+	banRepo := repository.NewBanRepository(db)
+	appealRepo := repository.NewAppealRepository(db)
+	federationRepo := repository.NewFederationRepository(db)
+	globalBanRepo := repository.NewGlobalBanRepository(db)
+	historyRepo := repository.NewSanctionHistoryRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	archiveRepo := repository.NewArchiveRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	presetRepo := repository.NewPresetRepository(db)
+
+	svc := service.NewModerationService(a.logger, settingsRepo, chatAdminRepo, linkTokenRepo, muteRepo, tempMessageRepo, violationRepo, scheduleRepo, strikeLadderRepo, banRepo, appealRepo, federationRepo, globalBanRepo, historyRepo, auditRepo, archiveRepo, reportRepo, notificationRepo, presetRepo, a.bot)
 	svc.StartMetricsUpdater(ctx)
 	svc.StartCleanupTask(ctx, a.bot)
+	svc.StartSanctionExpiryTask(ctx)
+	svc.StartStrikeDecayTask(ctx)
+	svc.StartScheduleNotifier(ctx)
+	svc.StartArchiveCleanupTask(ctx)
+	svc.StartNotificationTasks(ctx)
 	h := handler.NewHandler(a.logger, svc, a.bot, userStateRepo, a.cfg)
+	h.StartAdminSync(ctx)
 
 	metricsSrv := metrics.NewServer(a.logger, a.cfg.MetricsAddr)
 	go func() {
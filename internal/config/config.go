@@ -25,6 +25,10 @@ type Config struct {
 	EnableTelemetry        bool   `env:"ENABLE_TELEMETRY" envDefault:"true"`
 	GroupLinkedSuccessText string `env:"GROUP_LINKED_SUCCESS_TEXT" envDefault:""`
 	AdminUserIDs           []int64 `env:"ADMIN_USER_IDS" envSeparator:","`
+
+	AppealCooldown       string `env:"APPEAL_COOLDOWN" envDefault:"1h"`
+	AdminSyncInterval    string `env:"ADMIN_SYNC_INTERVAL" envDefault:"1h"`
+	AdminSyncGracePeriod string `env:"ADMIN_SYNC_GRACE_PERIOD" envDefault:"24h"`
 }
 
 func (c *Config) GetDSN() string {
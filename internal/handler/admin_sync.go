@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const (
+	defaultAdminSyncInterval = time.Hour
+	defaultAdminSyncGrace    = 24 * time.Hour
+)
+
+func parseAdminSyncDuration(value string, fallback time.Duration) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return fallback
+	}
+	return duration
+}
+
+func (h *Handler) StartAdminSync(ctx context.Context) {
+	interval := parseAdminSyncDuration(h.config.AdminSyncInterval, defaultAdminSyncInterval)
+	grace := parseAdminSyncDuration(h.config.AdminSyncGracePeriod, defaultAdminSyncGrace)
+	h.svc.StartAdminSyncTask(ctx, interval, grace)
+}
+
+func (h *Handler) syncMemberAccess(ctx context.Context, chatID, userID int64) {
+	grace := parseAdminSyncDuration(h.config.AdminSyncGracePeriod, defaultAdminSyncGrace)
+	if err := h.svc.SyncChatAdminsOnChange(ctx, chatID, grace); err != nil {
+		h.logger.Warn("Failed to sync chat admins on member change", "chat_id", chatID, "user_id", userID, "error", err)
+	}
+}
+
+func (h *Handler) handleUserRemoved(ctx context.Context, upd *schemes.UserRemovedFromChatUpdate) {
+	h.syncMemberAccess(ctx, upd.ChatId, upd.User.UserId)
+}
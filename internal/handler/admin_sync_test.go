@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAdminSyncDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "Empty uses fallback", value: "", want: time.Hour},
+		{name: "Zero disables", value: "0", want: 0},
+		{name: "Hours", value: "12h", want: 12 * time.Hour},
+		{name: "Seconds", value: "90s", want: 90 * time.Second},
+		{name: "Bare number uses fallback", value: "3600", want: time.Hour},
+		{name: "Negative uses fallback", value: "-1h", want: time.Hour},
+		{name: "Invalid uses fallback", value: "soon", want: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAdminSyncDuration(tt.value, time.Hour); got != tt.want {
+				t.Errorf("parseAdminSyncDuration(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
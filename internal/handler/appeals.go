@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"max-moderation-bot/internal/handler/callbacks"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const defaultAppealCooldown = time.Hour
+
+func parseAppealCooldown(value string) time.Duration {
+	if value == "" {
+		return defaultAppealCooldown
+	}
+	cooldown, err := utils.ParseDuration(value)
+	if err != nil || cooldown < 0 {
+		return defaultAppealCooldown
+	}
+	return cooldown
+}
+
+func (h *Handler) handleSanctionedUserMessage(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	userID := upd.Message.Sender.UserId
+	state, _ := h.userStateRepo.GetState(userID)
+	if state != nil && strings.HasPrefix(state.Action, callbacks.AppealActionPrefix) {
+		text := strings.TrimSpace(upd.Message.Body.Text)
+		if text == "" {
+			h.sendText(ctx, userID, messages.MsgOnlyTextSupported)
+			return
+		}
+		if err := h.userStateRepo.ClearState(userID); err != nil {
+			h.logger.Error("Failed to delete user state", "error", err)
+		}
+		h.handleAppealInput(ctx, text, upd.Message.Sender, state.ChatID, strings.TrimPrefix(state.Action, callbacks.AppealActionPrefix))
+		return
+	}
+
+	if !h.callbackHandler.SendAppealMenu(ctx, userID) {
+		h.logger.Warn("Non-admin user access denied", "user_id", userID)
+	}
+}
+
+func (h *Handler) handleAppealInput(ctx context.Context, text string, user schemes.User, chatID int64, sanctionType string) {
+	appeal, admins, err := h.svc.FileAppeal(ctx, chatID, user.UserId, user.Name, sanctionType, text, parseAppealCooldown(h.config.AppealCooldown))
+	if err != nil && appeal == nil {
+		h.logger.Warn("Failed to file appeal", "user_id", user.UserId, "chat_id", chatID, "error", err)
+		h.sendText(ctx, user.UserId, callbacks.AppealErrorText(err))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get chat admins for appeal", "appeal_id", appeal.ID, "error", err)
+	}
+	if len(admins) == 0 {
+		h.sendText(ctx, user.UserId, messages.MsgAppealNoAdmins)
+		return
+	}
+
+	var expiresAt time.Time
+	if sanctionType == service.SanctionBan {
+		if ban, err := h.svc.GetBan(ctx, chatID, user.UserId); err == nil && ban != nil {
+			expiresAt = ban.ExpiresAt
+		}
+	} else if mute, err := h.svc.GetMute(ctx, chatID, user.UserId); err == nil && mute != nil {
+		expiresAt = mute.ExpiresAt
+	}
+
+	h.callbackHandler.NotifyAppeal(ctx, appeal, expiresAt, admins)
+	h.sendText(ctx, user.UserId, messages.MsgAppealSent)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/json"
+)
+
+func attachmentTokens(rawAttachments []json.RawMessage) []string {
+	var tokens []string
+	for _, raw := range rawAttachments {
+		var att struct {
+			Type    string `json:"type"`
+			Payload struct {
+				Token string `json:"token"`
+				Code  string `json:"code"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(raw, &att); err != nil {
+			continue
+		}
+		token := att.Payload.Token
+		if att.Type == "sticker" {
+			token = att.Payload.Code
+		}
+		if att.Type == "" || token == "" {
+			continue
+		}
+		tokens = append(tokens, att.Type+":"+token)
+	}
+	return tokens
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAttachmentTokens(t *testing.T) {
+	raw := []json.RawMessage{
+		json.RawMessage(`{"type":"image","payload":{"photo_id":1,"token":"img-token","url":"https://example.com/a.jpg"}}`),
+		json.RawMessage(`{"type":"file","payload":{"token":"file-token","url":"https://example.com/a.txt"}}`),
+		json.RawMessage(`{"type":"sticker","payload":{"code":"sticker-code","url":"https://example.com/s.webp"}}`),
+		json.RawMessage(`{"type":"inline_keyboard","payload":{"buttons":[]}}`),
+		json.RawMessage(`not json`),
+	}
+	got := attachmentTokens(raw)
+	want := []string{"image:img-token", "file:file-token", "sticker:sticker-code"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("attachmentTokens() = %v, want %v", got, want)
+	}
+	if got := attachmentTokens(nil); got != nil {
+		t.Errorf("attachmentTokens(nil) = %v, want nil", got)
+	}
+}
@@ -7,5 +7,9 @@ import (
 )
 
 func (h *Handler) handleCallback(ctx context.Context, upd *schemes.MessageCallbackUpdate) {
+	if status, reportID, ok := parseReportCallback(upd.Callback.Payload); ok {
+		h.handleReportDecision(ctx, upd.Callback.User.UserId, reportID, status)
+		return
+	}
 	h.callbackHandler.Handle(ctx, upd)
 }
@@ -0,0 +1,157 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const AppealActionPrefix = "appeal_"
+
+func AppealErrorText(err error) string {
+	var cooldownErr *service.AppealCooldownError
+	switch {
+	case errors.As(err, &cooldownErr):
+		return fmt.Sprintf(messages.MsgAppealCooldown, utils.FormatDuration(cooldownErr.Remaining.Round(time.Minute)))
+	case errors.Is(err, service.ErrAppealNoSanction):
+		return messages.MsgAppealNoSanction
+	case errors.Is(err, service.ErrAppealAlreadyOpen):
+		return messages.MsgAppealAlreadyOpen
+	}
+	return messages.MsgAppealFailed
+}
+
+func sanctionExpiryLabel(expiresAt time.Time) string {
+	if expiresAt.Equal(repository.PermanentExpiry) {
+		return messages.MsgBanPermanent
+	}
+	return fmt.Sprintf(messages.MsgAppealUntil, expiresAt.Format("02.01.2006 15:04"))
+}
+
+func (h *CallbackHandler) chatLabel(ctx context.Context, chatID int64) string {
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		return chat.Title
+	}
+	return fmt.Sprintf("%d", chatID)
+}
+
+func (h *CallbackHandler) SendAppealMenu(ctx context.Context, userID int64) bool {
+	sanctions, err := h.svc.GetUserSanctions(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get user sanctions", "user_id", userID, "error", err)
+		return false
+	}
+	if len(sanctions) == 0 {
+		return false
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, s := range sanctions {
+		format := messages.MsgAppealMuteLabel
+		if s.Type == service.SanctionBan {
+			format = messages.MsgAppealBanLabel
+		}
+		label := fmt.Sprintf(format, truncateLabel(h.chatLabel(ctx, s.ChatID), 24), sanctionExpiryLabel(s.ExpiresAt))
+		kb.AddRow().AddCallback(label, schemes.DEFAULT, fmt.Sprintf("%s%s_%d", AppealActionPrefix, s.Type, s.ChatID))
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(messages.MsgAppealMenu)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send appeal menu", "error", err)
+	}
+	return true
+}
+
+func (h *CallbackHandler) handleAppealSelect(ctx context.Context, userID int64, sanctionType string, chatID int64) {
+	if err := h.svc.CheckAppeal(ctx, chatID, userID, sanctionType, h.appealCooldown); err != nil {
+		if !errors.Is(err, service.ErrAppealNoSanction) && !errors.Is(err, service.ErrAppealAlreadyOpen) {
+			h.logger.Warn("Appeal rejected", "user_id", userID, "chat_id", chatID, "error", err)
+		}
+		h.sendText(ctx, userID, AppealErrorText(err))
+		return
+	}
+	if err := h.userStateRepo.SetState(userID, chatID, AppealActionPrefix+sanctionType); err != nil {
+		h.logger.Error("Failed to set user state", "error", err)
+		return
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgPromptAppeal, h.chatLabel(ctx, chatID)))
+}
+
+func (h *CallbackHandler) NotifyAppeal(ctx context.Context, appeal *repository.Appeal, expiresAt time.Time, adminIDs []int64) {
+	sanction := messages.MsgAppealSanctionMute
+	if appeal.SanctionType == service.SanctionBan {
+		sanction = messages.MsgAppealSanctionBan
+	}
+	userName := appeal.UserName
+	if userName == "" {
+		userName = fmt.Sprintf("User %d", appeal.UserID)
+	}
+	text := fmt.Sprintf(messages.MsgAppealAdminNotice,
+		appeal.ID,
+		h.chatLabel(ctx, appeal.ChatID),
+		userName,
+		appeal.UserID,
+		sanction,
+		sanctionExpiryLabel(expiresAt),
+		appeal.Text,
+	)
+
+	for _, adminID := range adminIDs {
+		kb := h.bot.Messages.NewKeyboardBuilder()
+		kb.AddRow().
+			AddCallback(messages.BtnAppealApprove, schemes.POSITIVE, fmt.Sprintf("apok_%d", appeal.ID)).
+			AddCallback(messages.BtnAppealReduce, schemes.DEFAULT, fmt.Sprintf("aprd_%d", appeal.ID))
+		kb.AddRow().AddCallback(messages.BtnAppealReject, schemes.NEGATIVE, fmt.Sprintf("aprj_%d", appeal.ID))
+
+		msg := maxbot.NewMessage()
+		msg.SetUser(adminID)
+		msg.SetText(text)
+		msg.SetFormat("markdown")
+		msg.AddKeyboard(kb)
+		if err := h.bot.Messages.Send(ctx, msg); err != nil {
+			h.logger.Error("Failed to send appeal to admin", "admin_id", adminID, "appeal_id", appeal.ID, "error", err)
+		}
+	}
+}
+
+func (h *CallbackHandler) handleAppealDecision(ctx context.Context, adminID int64, appealID uint, decision string) {
+	appeal, newExpiry, err := h.svc.ResolveAppeal(ctx, appealID, adminID, decision)
+	if errors.Is(err, service.ErrAppealResolved) {
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgAppealAlreadyResolved, appealID))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to resolve appeal", "appeal_id", appealID, "admin_id", adminID, "error", err)
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgAppealDecisionFailed, appealID))
+		return
+	}
+
+	label := h.chatLabel(ctx, appeal.ChatID)
+	switch decision {
+	case repository.AppealStatusApproved:
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgAppealApprovedAdmin, appealID))
+		h.sendText(ctx, appeal.UserID, fmt.Sprintf(messages.MsgAppealApprovedUser, label))
+	case repository.AppealStatusReduced:
+		until := sanctionExpiryLabel(newExpiry)
+		if newExpiry.IsZero() {
+			until = messages.MsgAppealSanctionExpired
+		}
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgAppealReducedAdmin, appealID, until))
+		h.sendText(ctx, appeal.UserID, fmt.Sprintf(messages.MsgAppealReducedUser, label, until))
+	case repository.AppealStatusRejected:
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgAppealRejectedAdmin, appealID))
+		h.sendText(ctx, appeal.UserID, fmt.Sprintf(messages.MsgAppealRejectedUser, label))
+	}
+}
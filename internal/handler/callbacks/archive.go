@@ -0,0 +1,250 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func archiveStatusLabel(status string) string {
+	switch status {
+	case repository.ArchiveStatusRestored:
+		return messages.MsgArchiveStatusRestored
+	case repository.ArchiveStatusFalsePositive:
+		return messages.MsgArchiveStatusFalsePositive
+	}
+	return messages.MsgArchiveStatusPending
+}
+
+func archiveStatusIcon(status string) string {
+	switch status {
+	case repository.ArchiveStatusRestored:
+		return "♻️"
+	case repository.ArchiveStatusFalsePositive:
+		return "⚠️"
+	}
+	return "🗑"
+}
+
+func archiveUserName(message repository.ArchivedMessage) string {
+	if message.UserName != "" {
+		return message.UserName
+	}
+	return fmt.Sprintf("User %d", message.UserID)
+}
+
+func archiveRetentionDays(settings *repository.ChatSettings) int {
+	if settings == nil || settings.ArchiveDays <= 0 {
+		return 7
+	}
+	return settings.ArchiveDays
+}
+
+func (h *CallbackHandler) HandleArchive(ctx context.Context, chatID, userID int64, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	archived, total, err := h.svc.GetArchivedMessages(ctx, chatID, page)
+	if err != nil {
+		h.logger.Error("Failed to get archived messages", "chat_id", chatID, "error", err)
+		return
+	}
+	settings, err := h.svc.GetChatSettings(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get settings for archive", "chat_id", chatID, "error", err)
+	}
+	days := archiveRetentionDays(settings)
+
+	label := h.chatLabel(ctx, chatID)
+	kb := h.bot.Messages.NewKeyboardBuilder()
+
+	var text string
+	if len(archived) == 0 {
+		text = fmt.Sprintf(messages.MsgArchiveEmpty, label, days)
+	} else {
+		totalPages := (int(total) + 9) / 10
+		text = fmt.Sprintf(messages.MsgArchiveTitle, label, page, totalPages, days)
+		for _, m := range archived {
+			snippet := m.Text
+			if snippet == "" {
+				snippet = fmt.Sprintf("[%d]", len(m.Attachments))
+			}
+			btn := fmt.Sprintf(messages.BtnArchiveEntry, archiveStatusIcon(m.Status), m.CreatedAt.Format("02.01 15:04"), truncateLabel(archiveUserName(m), 16), truncateLabel(snippet, 24))
+			kb.AddRow().AddCallback(btn, schemes.DEFAULT, fmt.Sprintf("arv_%d", m.ID))
+		}
+		if totalPages > 1 {
+			navRow := kb.AddRow()
+			if page > 1 {
+				navRow.AddCallback(messages.BtnPrevPage, schemes.DEFAULT, fmt.Sprintf("arl_%d_%d", chatID, page-1))
+			}
+			if page < totalPages {
+				navRow.AddCallback(messages.BtnNextPage, schemes.DEFAULT, fmt.Sprintf("arl_%d_%d", chatID, page+1))
+			}
+		}
+	}
+	if settings != nil && !settings.EnableArchive {
+		text += messages.MsgArchiveDisabled
+	}
+
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnArchiveRetention, days), schemes.DEFAULT, fmt.Sprintf("prompt_archdays_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send archive list", "error", err)
+	}
+}
+
+func (h *CallbackHandler) loadArchivedMessage(ctx context.Context, userID int64, id uint) *repository.ArchivedMessage {
+	archived, err := h.svc.GetArchivedMessage(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get archived message", "id", id, "error", err)
+		return nil
+	}
+	if archived == nil {
+		h.sendText(ctx, userID, messages.MsgArchiveNotFound)
+		return nil
+	}
+	if !h.verifyAccess(ctx, userID, archived.ChatID, repository.RoleModerator) {
+		return nil
+	}
+	return archived
+}
+
+func (h *CallbackHandler) handleViewArchived(ctx context.Context, userID int64, id uint) {
+	archived := h.loadArchivedMessage(ctx, userID, id)
+	if archived == nil {
+		return
+	}
+	trusted, err := h.svc.IsTrustedUser(ctx, archived.ChatID, archived.UserID)
+	if err != nil {
+		h.logger.Error("Failed to check trusted user", "chat_id", archived.ChatID, "user_id", archived.UserID, "error", err)
+	}
+
+	body := archived.Text
+	if body == "" {
+		body = messages.MsgArchiveNoText
+	}
+	reason := archived.Reason
+	if reason == "" {
+		reason = messages.MsgWarnNoReason
+	}
+	trustedMark := ""
+	if trusted {
+		trustedMark = messages.MsgArchiveTrustedMark
+	}
+	filter := archived.Filter
+	if archived.Rule != "" {
+		filter += fmt.Sprintf(messages.MsgArchiveRule, archived.Rule)
+	}
+	text := fmt.Sprintf(messages.MsgArchiveDetail,
+		archiveUserName(*archived),
+		archived.UserID,
+		trustedMark,
+		archived.CreatedAt.Format("02.01.2006 15:04"),
+		filter,
+		reason,
+		archiveStatusLabel(archived.Status),
+		len(archived.Attachments),
+		strings.TrimSpace(body),
+	)
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	if archived.Status != repository.ArchiveStatusRestored {
+		kb.AddRow().AddCallback(messages.BtnArchiveRestore, schemes.POSITIVE, fmt.Sprintf("arr_%d", archived.ID))
+	}
+	if archived.Status == repository.ArchiveStatusPending {
+		kb.AddRow().AddCallback(messages.BtnArchiveFalsePositive, schemes.DEFAULT, fmt.Sprintf("arf_%d", archived.ID))
+	}
+	if archived.AuditID != 0 {
+		for _, action := range service.CorrectionActions(archived.AuditID, archived.Rule) {
+			if action.Label == messages.BtnCorrectionExempt && trusted {
+				continue
+			}
+			kb.AddRow().AddCallback(action.Label, schemes.DEFAULT, action.Payload)
+		}
+	} else if !trusted {
+		kb.AddRow().AddCallback(messages.BtnArchiveTrust, schemes.DEFAULT, fmt.Sprintf("art_%d", archived.ID))
+	}
+	if trusted {
+		kb.AddRow().AddCallback(messages.BtnArchiveUntrust, schemes.NEGATIVE, fmt.Sprintf("aru_%d", archived.ID))
+	}
+	kb.AddRow().AddCallback(messages.BtnSanctionHistory, schemes.DEFAULT, fmt.Sprintf("sh_%d_%d_1", archived.ChatID, archived.UserID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("arl_%d_1", archived.ChatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send archived message", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleRestoreArchived(ctx context.Context, userID int64, id uint) {
+	archived := h.loadArchivedMessage(ctx, userID, id)
+	if archived == nil {
+		return
+	}
+	err := h.svc.RestoreArchivedMessage(ctx, id, userID)
+	switch {
+	case errors.Is(err, service.ErrAlreadyRestored):
+		h.sendText(ctx, userID, messages.MsgArchiveAlreadyRestored)
+	case err != nil:
+		h.logger.Error("Failed to restore archived message", "id", id, "error", err)
+		h.sendText(ctx, userID, messages.MsgArchiveRestoreFailed)
+	default:
+		h.sendText(ctx, userID, messages.MsgArchiveRestored)
+	}
+	h.handleViewArchived(ctx, userID, id)
+}
+
+func (h *CallbackHandler) handleFalsePositive(ctx context.Context, userID int64, id uint) {
+	archived := h.loadArchivedMessage(ctx, userID, id)
+	if archived == nil {
+		return
+	}
+	if err := h.svc.MarkFalsePositive(ctx, id, userID); err != nil {
+		h.logger.Error("Failed to mark false positive", "id", id, "error", err)
+		h.sendText(ctx, userID, messages.MsgArchiveActionFailed)
+	} else {
+		h.sendText(ctx, userID, messages.MsgArchiveMarkedFalsePositive)
+	}
+	h.handleViewArchived(ctx, userID, id)
+}
+
+func (h *CallbackHandler) handleTrustSender(ctx context.Context, userID int64, id uint, trust bool) {
+	archived := h.loadArchivedMessage(ctx, userID, id)
+	if archived == nil {
+		return
+	}
+	var err error
+	text := fmt.Sprintf(messages.MsgArchiveTrusted, archived.UserID)
+	if trust {
+		err = h.svc.TrustUser(ctx, archived.ChatID, userID, archived.UserID)
+	} else {
+		err = h.svc.UntrustUser(ctx, archived.ChatID, userID, archived.UserID)
+		text = fmt.Sprintf(messages.MsgArchiveUntrusted, archived.UserID)
+	}
+	if err != nil {
+		h.logger.Error("Failed to update trusted user", "chat_id", archived.ChatID, "user_id", archived.UserID, "error", err)
+		text = messages.MsgArchiveActionFailed
+	}
+	h.sendText(ctx, userID, text)
+	h.handleViewArchived(ctx, userID, id)
+}
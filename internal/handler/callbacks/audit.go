@@ -0,0 +1,196 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func auditActionLabel(action string) string {
+	switch action {
+	case repository.AuditActionDelete:
+		return messages.MsgAuditActionDelete
+	case repository.AuditActionWarn:
+		return messages.MsgAuditActionWarn
+	case repository.AuditActionUnwarn:
+		return messages.MsgAuditActionUnwarn
+	case repository.AuditActionForgive:
+		return messages.MsgAuditActionForgive
+	case repository.AuditActionMute:
+		return messages.MsgAuditActionMute
+	case repository.AuditActionUnmute:
+		return messages.MsgAuditActionUnmute
+	case repository.AuditActionBan:
+		return messages.MsgAuditActionBan
+	case repository.AuditActionUnban:
+		return messages.MsgAuditActionUnban
+	case repository.AuditActionKick:
+		return messages.MsgAuditActionKick
+	case repository.AuditActionSetting:
+		return messages.MsgAuditActionSetting
+	case repository.AuditActionBlocklist:
+		return messages.MsgAuditActionBlocklist
+	case repository.AuditActionLink:
+		return messages.MsgAuditActionLink
+	case repository.AuditActionRestore:
+		return messages.MsgAuditActionRestore
+	case repository.AuditActionFalsePos:
+		return messages.MsgAuditActionFalsePos
+	case repository.AuditActionTrust:
+		return messages.MsgAuditActionTrust
+	case repository.AuditActionUntrust:
+		return messages.MsgAuditActionUntrust
+	case repository.AuditActionReport:
+		return messages.MsgAuditActionReport
+	case "":
+		return messages.MsgAuditAll
+	}
+	return action
+}
+
+func auditEntryText(entry repository.AuditEntry) string {
+	actor := messages.MsgAuditActorBot
+	if entry.ActorID != 0 {
+		actor = fmt.Sprintf(messages.MsgAuditActorAdmin, entry.ActorID)
+	}
+	lines := []string{fmt.Sprintf(messages.MsgAuditEntry, entry.CreatedAt.Format("02.01.2006 15:04"), auditActionLabel(entry.Action))}
+	if entry.TargetUserID != 0 {
+		lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryTarget, entry.TargetUserID))
+	}
+	lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryActor, actor))
+	if entry.Filter != "" {
+		lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryFilter, entry.Filter))
+	}
+	if entry.Rule != "" {
+		lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryRule, entry.Rule))
+	}
+	if entry.Reason != "" {
+		lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryReason, entry.Reason))
+	}
+	if entry.MessageID != "" {
+		lines = append(lines, fmt.Sprintf(messages.MsgAuditEntryMessage, entry.MessageID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func auditActionParam(action string) string {
+	if action == "" {
+		return "all"
+	}
+	return action
+}
+
+func auditLogPayload(chatID int64, page int, filter repository.AuditFilter) string {
+	return fmt.Sprintf("al_%d_%d_%d_%s", chatID, page, filter.UserID, auditActionParam(filter.Action))
+}
+
+func parseAuditLogPayload(payload string) (int64, int, repository.AuditFilter, bool) {
+	var chatID, filterUserID int64
+	var page int
+	var action string
+	if _, err := fmt.Sscanf(payload, "al_%d_%d_%d_%s", &chatID, &page, &filterUserID, &action); err == nil {
+		if action == "all" {
+			action = ""
+		}
+		return chatID, page, repository.AuditFilter{Action: action, UserID: filterUserID}, true
+	}
+	if _, err := fmt.Sscanf(payload, "al_%d", &chatID); err == nil {
+		return chatID, 1, repository.AuditFilter{}, true
+	}
+	return 0, 0, repository.AuditFilter{}, false
+}
+
+func (h *CallbackHandler) LogChange(ctx context.Context, chatID, actorID int64, action, reason string) {
+	h.svc.LogAction(ctx, repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: actorID,
+		Action:  action,
+		Reason:  reason,
+	})
+}
+
+func (h *CallbackHandler) HandleAuditLog(ctx context.Context, chatID, userID int64, filter repository.AuditFilter, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	entries, total, err := h.svc.GetAuditLog(ctx, chatID, filter, page)
+	if err != nil {
+		h.logger.Error("Failed to get audit log", "chat_id", chatID, "error", err)
+		return
+	}
+
+	label := h.chatLabel(ctx, chatID)
+	userLabel := messages.MsgAuditAll
+	if filter.UserID != 0 {
+		userLabel = fmt.Sprintf("%d", filter.UserID)
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+
+	var text string
+	if len(entries) == 0 {
+		text = fmt.Sprintf(messages.MsgAuditLogEmpty, label, auditActionLabel(filter.Action), userLabel)
+	} else {
+		totalPages := (int(total) + 9) / 10
+		lines := make([]string, len(entries))
+		for i, e := range entries {
+			lines[i] = auditEntryText(e)
+		}
+		text = fmt.Sprintf(messages.MsgAuditLogTitle, label, page, totalPages, auditActionLabel(filter.Action), userLabel) + "\n\n" + strings.Join(lines, "\n\n")
+
+		if totalPages > 1 {
+			navRow := kb.AddRow()
+			if page > 1 {
+				navRow.AddCallback(messages.BtnPrevPage, schemes.DEFAULT, auditLogPayload(chatID, page-1, filter))
+			}
+			if page < totalPages {
+				navRow.AddCallback(messages.BtnNextPage, schemes.DEFAULT, auditLogPayload(chatID, page+1, filter))
+			}
+		}
+	}
+
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnAuditFilterAction, auditActionLabel(filter.Action)), schemes.DEFAULT, fmt.Sprintf("alf_%d_%d", chatID, filter.UserID))
+	if filter.UserID != 0 {
+		kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnAuditResetUser, filter.UserID), schemes.DEFAULT, auditLogPayload(chatID, 1, repository.AuditFilter{Action: filter.Action}))
+	} else {
+		kb.AddRow().AddCallback(messages.BtnAuditFilterUser, schemes.DEFAULT, fmt.Sprintf("prompt_audituser_%d", chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send audit log", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleAuditActionPicker(ctx context.Context, chatID, userID, filterUserID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.MsgAuditAll, schemes.DEFAULT, auditLogPayload(chatID, 1, repository.AuditFilter{UserID: filterUserID}))
+	for _, action := range repository.AuditActions {
+		kb.AddRow().AddCallback(auditActionLabel(action), schemes.DEFAULT, auditLogPayload(chatID, 1, repository.AuditFilter{Action: action, UserID: filterUserID}))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, auditLogPayload(chatID, 1, repository.AuditFilter{UserID: filterUserID}))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgAuditPickAction, h.chatLabel(ctx, chatID)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send audit filter picker", "error", err)
+	}
+}
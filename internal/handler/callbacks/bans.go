@@ -0,0 +1,140 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func (h *CallbackHandler) handleListBans(ctx context.Context, chatID int64, userID int64, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	bans, total, err := h.svc.GetActiveBansPaginated(ctx, chatID, page)
+	if err != nil {
+		h.logger.Error("Failed to get bans", "error", err)
+		return
+	}
+
+	label := fmt.Sprintf("%d", chatID)
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+
+	if len(bans) == 0 && page == 1 {
+		kb := h.bot.Messages.NewKeyboardBuilder()
+		kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+		msg := maxbot.NewMessage()
+		msg.SetUser(userID)
+		msg.SetText(messages.MsgNoActiveBans)
+		msg.SetFormat("markdown")
+		msg.AddKeyboard(kb)
+		if err := h.bot.Messages.Send(ctx, msg); err != nil {
+			h.logger.Error("Failed to send no bans message", "error", err)
+		}
+		return
+	}
+
+	totalPages := (int(total) + 9) / 10
+	text := fmt.Sprintf(messages.MsgBanListTitle, label, page, totalPages)
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, b := range bans {
+		name := b.UserName
+		if name == "" {
+			name = fmt.Sprintf("User %d", b.UserID)
+		}
+		userLabel := fmt.Sprintf("👤 %s", name)
+		kb.AddRow().AddCallback(userLabel, schemes.POSITIVE, fmt.Sprintf("vb_%d_%d_%d", chatID, b.UserID, page))
+	}
+
+	if totalPages > 1 {
+		navRow := kb.AddRow()
+		if page > 1 {
+			navRow.AddCallback(messages.BtnPrevPage, schemes.DEFAULT, fmt.Sprintf("lb_%d_%d", chatID, page-1))
+		}
+		if page < totalPages {
+			navRow.AddCallback(messages.BtnNextPage, schemes.DEFAULT, fmt.Sprintf("lb_%d_%d", chatID, page+1))
+		}
+	}
+
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send bans list", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleViewBan(ctx context.Context, chatID int64, userID int64, targetUserID int64, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+
+	targetBan, err := h.svc.GetBan(ctx, chatID, targetUserID)
+	if err != nil {
+		h.logger.Error("Failed to get ban for detail", "error", err)
+		return
+	}
+
+	if targetBan == nil {
+		h.handleListBans(ctx, chatID, userID, page)
+		return
+	}
+
+	label := fmt.Sprintf("%d", chatID)
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+
+	userName := targetBan.UserName
+	if userName == "" {
+		userName = fmt.Sprintf("User %d", targetBan.UserID)
+	}
+
+	expires := messages.MsgBanPermanent
+	if !targetBan.ExpiresAt.Equal(repository.PermanentExpiry) {
+		expires = targetBan.ExpiresAt.Format("02.01.2006 15:04:05")
+	}
+
+	text := fmt.Sprintf(messages.MsgBanDetail,
+		label,
+		userName,
+		targetBan.UserID,
+		expires,
+	)
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnUnban, schemes.NEGATIVE, fmt.Sprintf("ub_%d_%d_%d", chatID, targetUserID, page))
+	kb.AddRow().AddCallback(messages.BtnSanctionHistory, schemes.DEFAULT, fmt.Sprintf("sh_%d_%d_1", chatID, targetUserID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("lb_%d_%d", chatID, page))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send ban detail", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleUnban(ctx context.Context, chatID int64, adminID int64, targetUserID int64, page int) {
+	if err := h.svc.UnbanUser(ctx, chatID, adminID, targetUserID); err != nil {
+		h.logger.Error("Failed to unban", "error", err)
+		return
+	}
+	h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgUnbannedSuccess, targetUserID))
+	h.handleListBans(ctx, chatID, adminID, page)
+}
@@ -0,0 +1,211 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"net/url"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const maxBlocklistQueryLen = 64
+
+func blocklistPrefix(kind string) string {
+	if kind == service.BlocklistDomains {
+		return "bd"
+	}
+	return "bw"
+}
+
+func blocklistListPayload(kind string, chatID int64, page int, query string) string {
+	payload := fmt.Sprintf("%sl_%d_%d", blocklistPrefix(kind), chatID, page)
+	if query != "" {
+		payload += "_" + url.QueryEscape(query)
+	}
+	return payload
+}
+
+func blocklistEntryHash(entry string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(entry))
+	return h.Sum32()
+}
+
+func blocklistDeletePayload(kind string, chatID int64, page, pos int, entry, query string) string {
+	payload := fmt.Sprintf("%sd_%d_%d_%d_%x", blocklistPrefix(kind), chatID, page, pos, blocklistEntryHash(entry))
+	if query != "" {
+		payload += "_" + url.QueryEscape(query)
+	}
+	return payload
+}
+
+func blocklistKind(payload string) string {
+	if len(payload) > 1 && payload[1] == 'd' {
+		return service.BlocklistDomains
+	}
+	return service.BlocklistWords
+}
+
+func unescapeBlocklistQuery(raw string) string {
+	query, err := url.QueryUnescape(raw)
+	if err != nil {
+		return ""
+	}
+	return query
+}
+
+func parseBlocklistListPayload(payload string) (string, int64, int, string, bool) {
+	if len(payload) < 4 {
+		return "", 0, 0, "", false
+	}
+	kind := blocklistKind(payload)
+	var chatID int64
+	var page int
+	var rawQuery string
+	if _, err := fmt.Sscanf(payload[4:], "%d_%d_%s", &chatID, &page, &rawQuery); err == nil {
+		return kind, chatID, page, unescapeBlocklistQuery(rawQuery), true
+	}
+	if _, err := fmt.Sscanf(payload[4:], "%d_%d", &chatID, &page); err == nil {
+		return kind, chatID, page, "", true
+	}
+	if _, err := fmt.Sscanf(payload[4:], "%d", &chatID); err == nil {
+		return kind, chatID, 1, "", true
+	}
+	return "", 0, 0, "", false
+}
+
+func parseBlocklistDeletePayload(payload string) (string, int64, int, int, uint32, string, bool) {
+	if len(payload) < 4 {
+		return "", 0, 0, 0, 0, "", false
+	}
+	kind := blocklistKind(payload)
+	var chatID int64
+	var page, pos int
+	var hash uint32
+	var rawQuery string
+	if _, err := fmt.Sscanf(payload[4:], "%d_%d_%d_%x_%s", &chatID, &page, &pos, &hash, &rawQuery); err == nil {
+		return kind, chatID, page, pos, hash, unescapeBlocklistQuery(rawQuery), true
+	}
+	if _, err := fmt.Sscanf(payload[4:], "%d_%d_%d_%x", &chatID, &page, &pos, &hash); err == nil {
+		return kind, chatID, page, pos, hash, "", true
+	}
+	return "", 0, 0, 0, 0, "", false
+}
+
+func TrimBlocklistQuery(query string) string {
+	runes := []rune(query)
+	if len(runes) > maxBlocklistQueryLen {
+		return string(runes[:maxBlocklistQueryLen])
+	}
+	return query
+}
+
+func (h *CallbackHandler) HandleBlocklist(ctx context.Context, chatID, userID int64, kind, query string, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	entries, total, err := h.svc.GetBlocklistPage(ctx, chatID, kind, query, page)
+	if err != nil {
+		h.logger.Error("Failed to get blocklist", "chat_id", chatID, "kind", kind, "error", err)
+		return
+	}
+	totalPages := (total + 9) / 10
+	if page > totalPages && totalPages > 0 {
+		page = totalPages
+		entries, total, err = h.svc.GetBlocklistPage(ctx, chatID, kind, query, page)
+		if err != nil {
+			h.logger.Error("Failed to get blocklist", "chat_id", chatID, "kind", kind, "error", err)
+			return
+		}
+	}
+
+	titleFormat, emptyFormat := messages.MsgBlocklistWordsTitle, messages.MsgBlocklistWordsEmpty
+	if kind == service.BlocklistDomains {
+		titleFormat, emptyFormat = messages.MsgBlocklistDomainsTitle, messages.MsgBlocklistDomainsEmpty
+	}
+	searchLabel := ""
+	if query != "" {
+		searchLabel = fmt.Sprintf(messages.MsgBlocklistSearch, query)
+	}
+
+	label := h.chatLabel(ctx, chatID)
+	kb := h.bot.Messages.NewKeyboardBuilder()
+
+	var text string
+	if len(entries) == 0 {
+		text = fmt.Sprintf(emptyFormat, label) + searchLabel
+	} else {
+		text = fmt.Sprintf(titleFormat, label, total, page, totalPages) + searchLabel
+		for i, entry := range entries {
+			kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnBlocklistEntry, truncateLabel(entry, 40)), schemes.NEGATIVE, blocklistDeletePayload(kind, chatID, page, i, entry, query))
+		}
+		if totalPages > 1 {
+			navRow := kb.AddRow()
+			if page > 1 {
+				navRow.AddCallback(messages.BtnPrevPage, schemes.DEFAULT, blocklistListPayload(kind, chatID, page-1, query))
+			}
+			if page < totalPages {
+				navRow.AddCallback(messages.BtnNextPage, schemes.DEFAULT, blocklistListPayload(kind, chatID, page+1, query))
+			}
+		}
+	}
+
+	kb.AddRow().AddCallback(messages.BtnBlocklistSearch, schemes.DEFAULT, fmt.Sprintf("prompt_%ssearch_%d", blocklistPrefix(kind), chatID))
+	if query != "" {
+		kb.AddRow().AddCallback(messages.BtnBlocklistResetSearch, schemes.DEFAULT, blocklistListPayload(kind, chatID, 1, ""))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send blocklist", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleRemoveBlocklistEntry(ctx context.Context, chatID, userID int64, kind, query string, page, pos int, hash uint32) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	entries, _, err := h.svc.GetBlocklistPage(ctx, chatID, kind, query, page)
+	if err != nil {
+		h.logger.Error("Failed to get blocklist", "chat_id", chatID, "kind", kind, "error", err)
+		return
+	}
+	if pos < 0 || pos >= len(entries) || blocklistEntryHash(entries[pos]) != hash {
+		h.sendText(ctx, userID, messages.MsgBlocklistEntryGone)
+		h.HandleBlocklist(ctx, chatID, userID, kind, query, page)
+		return
+	}
+	entry := entries[pos]
+
+	var removed bool
+	if kind == service.BlocklistDomains {
+		removed, err = h.svc.RemoveBlockedDomain(ctx, chatID, entry)
+	} else {
+		removed, err = h.svc.RemoveBlockedWord(ctx, chatID, entry)
+	}
+	if err != nil {
+		h.logger.Error("Failed to remove blocklist entry", "chat_id", chatID, "kind", kind, "entry", entry, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	if !removed {
+		h.sendText(ctx, userID, messages.MsgBlocklistEntryGone)
+	} else {
+		h.LogChange(ctx, chatID, userID, repository.AuditActionBlocklist, fmt.Sprintf("remove_%s: %s", kind, entry))
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgBlocklistEntryRemoved, entry))
+	}
+	h.HandleBlocklist(ctx, chatID, userID, kind, query, page)
+}
@@ -0,0 +1,175 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func (h *CallbackHandler) HandleChatGroups(ctx context.Context, userID int64) {
+	groups, err := h.svc.GetChatGroups(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get chat groups", "user_id", userID, "error", err)
+		return
+	}
+
+	text := messages.MsgChatGroupsTitle
+	if len(groups) == 0 {
+		text += "\n\n" + messages.MsgNoChatGroups
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, g := range groups {
+		kb.AddRow().AddCallback(g.Name, schemes.POSITIVE, fmt.Sprintf("cg_%d", g.ID))
+	}
+	kb.AddRow().AddCallback(messages.BtnCreateChatGroup, schemes.DEFAULT, "cgnew")
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "main_menu")
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send chat groups", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleNewChatGroup(ctx context.Context, userID int64) {
+	if err := h.userStateRepo.SetState(userID, 0, "create_chat_group"); err != nil {
+		h.logger.Error("Failed to set user state", "error", err)
+		return
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "cgroups")
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(messages.MsgPromptChatGroupName)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send chat group prompt", "error", err)
+	}
+}
+
+func (h *CallbackHandler) HandleChatGroup(ctx context.Context, userID int64, groupID uint) {
+	group, chats, err := h.svc.GetChatGroup(ctx, userID, groupID)
+	if err != nil {
+		h.logger.Warn("Failed to get chat group", "user_id", userID, "group_id", groupID, "error", err)
+		h.HandleChatGroups(ctx, userID)
+		return
+	}
+
+	propagate := messages.MsgChatGroupOff
+	if group.Propagate {
+		propagate = messages.MsgChatGroupOn
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	lines := make([]string, len(chats))
+	for i, chatID := range chats {
+		label := h.chatLabel(ctx, chatID)
+		lines[i] = fmt.Sprintf("%d. %s", i+1, label)
+		kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnChatGroupRemoveChat, truncateLabel(label, 32)), schemes.NEGATIVE, fmt.Sprintf("cgrm_%d_%d", groupID, chatID))
+	}
+	body := messages.MsgChatGroupEmpty
+	if len(lines) > 0 {
+		body = strings.Join(lines, "\n")
+	}
+
+	kb.AddRow().AddCallback(messages.BtnChatGroupAddChat, schemes.POSITIVE, fmt.Sprintf("cgadd_%d", groupID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnChatGroupPropagate, propagate), schemes.DEFAULT, fmt.Sprintf("cgp_%d", groupID))
+	kb.AddRow().AddCallback(messages.BtnChatGroupDelete, schemes.NEGATIVE, fmt.Sprintf("cgdel_%d", groupID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "cgroups")
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgChatGroupDetail, group.Name, propagate, len(chats), body))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send chat group", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleChatGroupPicker(ctx context.Context, userID int64, groupID uint) {
+	group, members, err := h.svc.GetChatGroup(ctx, userID, groupID)
+	if err != nil {
+		h.logger.Warn("Failed to get chat group", "user_id", userID, "group_id", groupID, "error", err)
+		return
+	}
+	managed, err := h.svc.GetManagedChats(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get managed chats", "error", err)
+		return
+	}
+
+	inGroup := make(map[int64]bool, len(members))
+	for _, id := range members {
+		inGroup[id] = true
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	available := 0
+	for _, chatID := range managed {
+		if inGroup[chatID] {
+			continue
+		}
+		available++
+		kb.AddRow().AddCallback(truncateLabel(h.chatLabel(ctx, chatID), 40), schemes.POSITIVE, fmt.Sprintf("cga_%d_%d", groupID, chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("cg_%d", groupID))
+
+	text := fmt.Sprintf(messages.MsgChatGroupPickChat, group.Name)
+	if available == 0 {
+		text = messages.MsgChatGroupNoChatsToAdd
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send chat group picker", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleAddChatToGroup(ctx context.Context, userID int64, groupID uint, chatID int64) {
+	if err := h.svc.AddChatToGroup(ctx, userID, groupID, chatID); errors.Is(err, service.ErrChatInOtherGroup) {
+		h.sendText(ctx, userID, messages.MsgChatGroupChatTaken)
+	} else if err != nil {
+		h.logger.Error("Failed to add chat to group", "group_id", groupID, "chat_id", chatID, "error", err)
+	}
+	h.HandleChatGroup(ctx, userID, groupID)
+}
+
+func (h *CallbackHandler) handleRemoveChatFromGroup(ctx context.Context, userID int64, groupID uint, chatID int64) {
+	if err := h.svc.RemoveChatFromGroup(ctx, userID, groupID, chatID); err != nil {
+		h.logger.Error("Failed to remove chat from group", "group_id", groupID, "chat_id", chatID, "error", err)
+	}
+	h.HandleChatGroup(ctx, userID, groupID)
+}
+
+func (h *CallbackHandler) handleToggleGroupPropagation(ctx context.Context, userID int64, groupID uint) {
+	if _, err := h.svc.ToggleGroupPropagation(ctx, userID, groupID); err != nil {
+		h.logger.Error("Failed to toggle group propagation", "group_id", groupID, "error", err)
+	}
+	h.HandleChatGroup(ctx, userID, groupID)
+}
+
+func (h *CallbackHandler) handleDeleteChatGroup(ctx context.Context, userID int64, groupID uint) {
+	if err := h.svc.DeleteChatGroup(ctx, userID, groupID); err != nil {
+		h.logger.Error("Failed to delete chat group", "group_id", groupID, "error", err)
+	} else {
+		h.sendText(ctx, userID, messages.MsgChatGroupDeleted)
+	}
+	h.HandleChatGroups(ctx, userID)
+}
@@ -0,0 +1,125 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+var roleLabels = map[string]string{
+	repository.RoleViewer:    messages.MsgRoleViewer,
+	repository.RoleModerator: messages.MsgRoleModerator,
+	repository.RoleManager:   messages.MsgRoleManager,
+	repository.RoleOwner:     messages.MsgRoleOwner,
+}
+
+func RoleLabel(role string) string {
+	if label, ok := roleLabels[role]; ok {
+		return label
+	}
+	return role
+}
+
+func (h *CallbackHandler) HandleCoAdmins(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleOwner) {
+		return
+	}
+	admins, err := h.svc.GetCoAdmins(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get co-admins", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCoAdminFailed)
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(messages.MsgCoAdminsTitle, h.chatLabel(ctx, chatID)))
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, admin := range admins {
+		sb.WriteString(fmt.Sprintf(messages.MsgCoAdminEntry, admin.UserID, RoleLabel(admin.Role)))
+		if admin.Role != repository.RoleOwner {
+			kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnCoAdminRemove, admin.UserID), schemes.NEGATIVE, fmt.Sprintf("car_%d_%d", chatID, admin.UserID))
+		}
+	}
+	sb.WriteString(messages.MsgCoAdminsHint)
+	for _, role := range service.CoAdminRoles {
+		kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnCoAdminInvite, RoleLabel(role)), schemes.POSITIVE, fmt.Sprintf("cai_%d_%s", chatID, role))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(sb.String())
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send co-admins", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleCreateCoAdminInvite(ctx context.Context, chatID, userID int64, role string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleOwner) {
+		return
+	}
+	token, err := h.svc.CreateCoAdminInvite(ctx, chatID, userID, role)
+	if err != nil {
+		h.logger.Error("Failed to create co-admin invite", "chat_id", chatID, "role", role, "error", err)
+		h.sendText(ctx, userID, messages.MsgCoAdminFailed)
+		return
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("cad_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgCoAdminInvite, RoleLabel(role), h.chatLabel(ctx, chatID), token))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send co-admin invite", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleRemoveCoAdmin(ctx context.Context, chatID, userID, targetUserID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleOwner) {
+		return
+	}
+	err := h.svc.RemoveCoAdmin(ctx, chatID, userID, targetUserID)
+	switch {
+	case errors.Is(err, service.ErrCannotRemoveOwner):
+		h.sendText(ctx, userID, messages.MsgCoAdminOwnerRemove)
+	case err != nil:
+		h.logger.Error("Failed to remove co-admin", "chat_id", chatID, "user_id", targetUserID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCoAdminFailed)
+	default:
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCoAdminRemoved, targetUserID))
+		h.sendText(ctx, targetUserID, fmt.Sprintf(messages.MsgCoAdminRevoked, h.chatLabel(ctx, chatID)))
+	}
+	h.HandleCoAdmins(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) HandleJoinInvite(ctx context.Context, userID int64, token string) {
+	invite, err := h.svc.AcceptCoAdminInvite(ctx, token, userID)
+	switch {
+	case errors.Is(err, service.ErrAlreadyOwner):
+		h.sendText(ctx, userID, messages.MsgCoAdminAlreadyOwner)
+		return
+	case errors.Is(err, service.ErrInvalidInvite):
+		h.sendText(ctx, userID, messages.MsgCoAdminInviteInvalid)
+		return
+	case err != nil:
+		h.logger.Error("Failed to accept co-admin invite", "user_id", userID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCoAdminFailed)
+		return
+	}
+	label := h.chatLabel(ctx, invite.ChatID)
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCoAdminJoined, label, RoleLabel(invite.Role)))
+	h.sendText(ctx, invite.UserID, fmt.Sprintf(messages.MsgCoAdminAccepted, userID, RoleLabel(invite.Role), label))
+	h.HandleManageGroup(ctx, invite.ChatID, userID)
+}
@@ -0,0 +1,437 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strconv"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const ActionCopySettings = "copy_settings"
+
+type copyDraft struct {
+	Source   int64    `json:"source"`
+	Targets  []int64  `json:"targets"`
+	Sections []string `json:"sections"`
+	Bulk     bool     `json:"bulk"`
+}
+
+func (d *copyDraft) hasSection(section string) bool {
+	for _, s := range d.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *copyDraft) hasTarget(chatID int64) bool {
+	for _, id := range d.Targets {
+		if id == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *copyDraft) toggleSection(section string) {
+	if !d.hasSection(section) {
+		d.Sections = append(d.Sections, section)
+		return
+	}
+	var kept []string
+	for _, s := range d.Sections {
+		if s != section {
+			kept = append(kept, s)
+		}
+	}
+	d.Sections = kept
+}
+
+func (d *copyDraft) toggleTarget(chatID int64) {
+	if !d.hasTarget(chatID) {
+		d.Targets = append(d.Targets, chatID)
+		return
+	}
+	var kept []int64
+	for _, id := range d.Targets {
+		if id != chatID {
+			kept = append(kept, id)
+		}
+	}
+	d.Targets = kept
+}
+
+var copySectionLabels = map[string]string{
+	service.CopySectionFilters:     messages.MsgCopySectionFilters,
+	service.CopySectionBlocklists:  messages.MsgCopySectionBlocklists,
+	service.CopySectionAttachments: messages.MsgCopySectionAttachments,
+	service.CopySectionSchedules:   messages.MsgCopySectionSchedules,
+}
+
+var copyFieldLabels = map[string]string{
+	"auto_delete":      messages.MsgCopyFieldAutoDelete,
+	"word_filter":      messages.MsgCopyFieldWordFilter,
+	"link_filter":      messages.MsgCopyFieldLinkFilter,
+	"mute":             messages.MsgCopyFieldMute,
+	"global_bans":      messages.MsgCopyFieldGlobalBans,
+	"slow_mode":        messages.MsgCopyFieldSlowMode,
+	"restrict_image":   messages.MsgCopyFieldRestrictImage,
+	"restrict_video":   messages.MsgCopyFieldRestrictVideo,
+	"restrict_audio":   messages.MsgCopyFieldRestrictAudio,
+	"restrict_file":    messages.MsgCopyFieldRestrictFile,
+	"timezone":         messages.MsgCopyFieldTimezone,
+	"schedule_notices": messages.MsgCopyFieldScheduleNotices,
+	"blocked_words":    messages.MsgCopyFieldBlockedWords,
+	"blocked_domains":  messages.MsgCopyFieldBlockedDomains,
+	"word_rules":       messages.MsgCopyFieldWordRules,
+	"schedule":         messages.MsgCopyFieldSchedule,
+}
+
+func copyValueLabel(field, value string) string {
+	switch value {
+	case "true":
+		return "✅"
+	case "false":
+		return "❌"
+	}
+	if field == "slow_mode" {
+		seconds, _ := strconv.Atoi(value)
+		return SlowModeLabel(seconds)
+	}
+	return value
+}
+
+func FormatCopyChange(change service.CopyChange) string {
+	label := copyFieldLabels[change.Field]
+	if label == "" {
+		label = change.Field
+	}
+	if change.Added > 0 || change.Removed > 0 {
+		return fmt.Sprintf(messages.MsgCopyChangeList, label, change.Added, change.Removed)
+	}
+	return fmt.Sprintf(messages.MsgCopyChangeValue, label, copyValueLabel(change.Field, change.From), copyValueLabel(change.Field, change.To))
+}
+
+func (h *CallbackHandler) loadCopyDraft(userID, chatID int64) (*copyDraft, bool) {
+	state, err := h.userStateRepo.GetState(userID)
+	if err != nil {
+		h.logger.Error("Failed to get copy draft", "user_id", userID, "error", err)
+		return nil, false
+	}
+	if state == nil || state.Action != ActionCopySettings || state.ChatID != chatID {
+		return nil, false
+	}
+	var draft copyDraft
+	if err := json.Unmarshal([]byte(state.Payload), &draft); err != nil {
+		h.logger.Error("Failed to decode copy draft", "user_id", userID, "error", err)
+		return nil, false
+	}
+	return &draft, true
+}
+
+func (h *CallbackHandler) saveCopyDraft(userID, chatID int64, draft *copyDraft) bool {
+	payload, err := json.Marshal(draft)
+	if err != nil {
+		h.logger.Error("Failed to encode copy draft", "error", err)
+		return false
+	}
+	if err := h.userStateRepo.SetStateWithPayload(userID, chatID, ActionCopySettings, string(payload)); err != nil {
+		h.logger.Error("Failed to store copy draft", "error", err)
+		return false
+	}
+	return true
+}
+
+func (h *CallbackHandler) otherManagedChats(ctx context.Context, userID, chatID int64) ([]int64, error) {
+	managed, err := h.svc.GetManagedChats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var others []int64
+	for _, id := range managed {
+		if id == chatID {
+			continue
+		}
+		role, err := h.svc.GetChatRole(ctx, id, userID)
+		if err != nil {
+			return nil, err
+		}
+		if service.RoleAtLeast(role, repository.RoleManager) {
+			others = append(others, id)
+		}
+	}
+	return others, nil
+}
+
+func (h *CallbackHandler) HandleCopyMenu(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Warn("Failed to clear user state", "error", err)
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnCopyFrom, schemes.DEFAULT, fmt.Sprintf("cpf_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnCopyTo, schemes.DEFAULT, fmt.Sprintf("cpt_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgCopyMenu, h.chatLabel(ctx, chatID)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send copy menu", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleCopySourcePicker(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	others, err := h.otherManagedChats(ctx, userID, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get managed chats", "error", err)
+		return
+	}
+	if len(others) == 0 {
+		h.sendText(ctx, userID, messages.MsgCopyNoOtherChats)
+		h.HandleCopyMenu(ctx, chatID, userID)
+		return
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, id := range others {
+		kb.AddRow().AddCallback(truncateLabel(h.chatLabel(ctx, id), 40), schemes.DEFAULT, fmt.Sprintf("cps_%d_%d", chatID, id))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("cpy_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgCopyPickSource, h.chatLabel(ctx, chatID)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send copy source picker", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleStartCopy(ctx context.Context, chatID, userID int64, draft *copyDraft) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) || !h.verifyAccess(ctx, userID, draft.Source, repository.RoleManager) {
+		return
+	}
+	draft.Sections = append([]string(nil), service.CopySections...)
+	if !h.saveCopyDraft(userID, chatID, draft) {
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.HandleCopyDraft(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) HandleCopyDraft(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	draft, ok := h.loadCopyDraft(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgCopyExpired)
+		h.HandleCopyMenu(ctx, chatID, userID)
+		return
+	}
+	status := func(enabled bool) string {
+		if enabled {
+			return "✅"
+		}
+		return "❌"
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, section := range service.CopySections {
+		kb.AddRow().AddCallback(fmt.Sprintf("%s %s", status(draft.hasSection(section)), copySectionLabels[section]), schemes.DEFAULT, fmt.Sprintf("cpx_%d_%s", chatID, section))
+	}
+
+	var text string
+	if draft.Bulk {
+		others, err := h.otherManagedChats(ctx, userID, chatID)
+		if err != nil {
+			h.logger.Error("Failed to get managed chats", "error", err)
+			return
+		}
+		for _, id := range others {
+			kb.AddRow().AddCallback(fmt.Sprintf("%s %s", status(draft.hasTarget(id)), truncateLabel(h.chatLabel(ctx, id), 36)), schemes.DEFAULT, fmt.Sprintf("cpc_%d_%d", chatID, id))
+		}
+		if len(others) > 1 {
+			kb.AddRow().AddCallback(messages.BtnCopyAllChats, schemes.DEFAULT, fmt.Sprintf("cpa_%d", chatID))
+		}
+		text = fmt.Sprintf(messages.MsgCopyDraftBulk, h.chatLabel(ctx, chatID), len(draft.Targets))
+	} else {
+		text = fmt.Sprintf(messages.MsgCopyDraftFrom, h.chatLabel(ctx, draft.Source), h.chatLabel(ctx, chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnCopyPreview, schemes.POSITIVE, fmt.Sprintf("cpp_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("cpy_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send copy draft", "error", err)
+	}
+}
+
+func (h *CallbackHandler) updateCopyDraft(ctx context.Context, chatID, userID int64, update func(*copyDraft)) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	draft, ok := h.loadCopyDraft(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgCopyExpired)
+		h.HandleCopyMenu(ctx, chatID, userID)
+		return
+	}
+	update(draft)
+	if !h.saveCopyDraft(userID, chatID, draft) {
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.HandleCopyDraft(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleToggleCopySection(ctx context.Context, chatID, userID int64, section string) {
+	if _, ok := copySectionLabels[section]; !ok {
+		return
+	}
+	h.updateCopyDraft(ctx, chatID, userID, func(d *copyDraft) { d.toggleSection(section) })
+}
+
+func (h *CallbackHandler) handleToggleCopyTarget(ctx context.Context, chatID, userID, targetID int64) {
+	if !h.verifyAccess(ctx, userID, targetID, repository.RoleManager) {
+		return
+	}
+	h.updateCopyDraft(ctx, chatID, userID, func(d *copyDraft) {
+		if d.Bulk {
+			d.toggleTarget(targetID)
+		}
+	})
+}
+
+func (h *CallbackHandler) handleToggleAllCopyTargets(ctx context.Context, chatID, userID int64) {
+	others, err := h.otherManagedChats(ctx, userID, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get managed chats", "error", err)
+		return
+	}
+	h.updateCopyDraft(ctx, chatID, userID, func(d *copyDraft) {
+		if !d.Bulk {
+			return
+		}
+		if len(d.Targets) == len(others) {
+			d.Targets = nil
+			return
+		}
+		d.Targets = others
+	})
+}
+
+func (h *CallbackHandler) verifyCopyAccess(ctx context.Context, userID int64, draft *copyDraft) bool {
+	if !h.verifyAccess(ctx, userID, draft.Source, repository.RoleManager) {
+		return false
+	}
+	for _, id := range draft.Targets {
+		if !h.verifyAccess(ctx, userID, id, repository.RoleManager) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *CallbackHandler) formatCopyPreviews(ctx context.Context, previews []service.CopyPreview) string {
+	var sb strings.Builder
+	for _, preview := range previews {
+		sb.WriteString(fmt.Sprintf(messages.MsgCopyPreviewChat, h.chatLabel(ctx, preview.ChatID)))
+		if len(preview.Changes) == 0 {
+			sb.WriteString(messages.MsgCopyPreviewNoChanges)
+			continue
+		}
+		for _, change := range preview.Changes {
+			sb.WriteString("\n• " + FormatCopyChange(change))
+		}
+	}
+	return sb.String()
+}
+
+func (h *CallbackHandler) handleCopyPreview(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	draft, ok := h.loadCopyDraft(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgCopyExpired)
+		h.HandleCopyMenu(ctx, chatID, userID)
+		return
+	}
+	if len(draft.Sections) == 0 || len(draft.Targets) == 0 {
+		h.sendText(ctx, userID, messages.MsgCopyNothingSelected)
+		h.HandleCopyDraft(ctx, chatID, userID)
+		return
+	}
+	if !h.verifyCopyAccess(ctx, userID, draft) {
+		h.logger.Warn("Access denied for settings copy", "user_id", userID, "chat_id", chatID)
+		return
+	}
+	previews, err := h.svc.PreviewSettingsCopy(ctx, draft.Source, draft.Targets, draft.Sections)
+	if err != nil {
+		h.logger.Error("Failed to preview settings copy", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnImportConfirm, schemes.POSITIVE, fmt.Sprintf("cpk_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("cpd_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgCopyPreview, h.chatLabel(ctx, draft.Source), h.formatCopyPreviews(ctx, previews)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send copy preview", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleConfirmCopy(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	draft, ok := h.loadCopyDraft(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgCopyExpired)
+		h.HandleCopyMenu(ctx, chatID, userID)
+		return
+	}
+	if !h.verifyCopyAccess(ctx, userID, draft) {
+		h.logger.Warn("Access denied for settings copy", "user_id", userID, "chat_id", chatID)
+		return
+	}
+	applied, err := h.svc.CopySettings(ctx, draft.Source, userID, draft.Targets, draft.Sections)
+	if err != nil {
+		h.logger.Error("Failed to copy settings", "source", draft.Source, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCopyFailed, len(applied)))
+		return
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Error("Failed to clear user state", "error", err)
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCopyApplied, len(applied)))
+	h.HandleManageGroup(ctx, chatID, userID)
+}
@@ -0,0 +1,83 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strings"
+)
+
+func (h *CallbackHandler) loadCorrectionEntry(ctx context.Context, userID int64, auditID uint, minRole string) *repository.AuditEntry {
+	entry, err := h.svc.GetAuditEntry(ctx, auditID)
+	if err != nil {
+		h.logger.Error("Failed to get audit entry", "audit_id", auditID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCorrectionFailed)
+		return nil
+	}
+	if entry == nil || entry.Action != repository.AuditActionDelete {
+		h.sendText(ctx, userID, messages.MsgCorrectionNotFound)
+		return nil
+	}
+	if !h.verifyAccess(ctx, userID, entry.ChatID, minRole) {
+		return nil
+	}
+	return entry
+}
+
+func (h *CallbackHandler) handleRemoveMatchedRule(ctx context.Context, userID int64, auditID uint) {
+	entry := h.loadCorrectionEntry(ctx, userID, auditID, repository.RoleManager)
+	if entry == nil {
+		return
+	}
+	err := h.svc.RemoveMatchedRule(ctx, auditID, userID)
+	switch {
+	case errors.Is(err, service.ErrNoMatchedRule), errors.Is(err, service.ErrRuleAlreadyRemoved):
+		h.sendText(ctx, userID, messages.MsgCorrectionRuleMissing)
+	case err != nil:
+		h.logger.Error("Failed to remove matched rule", "audit_id", auditID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCorrectionFailed)
+	default:
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCorrectionRuleRemoved, entry.Rule))
+	}
+}
+
+func (h *CallbackHandler) handleExemptUser(ctx context.Context, userID int64, auditID uint) {
+	entry := h.loadCorrectionEntry(ctx, userID, auditID, repository.RoleModerator)
+	if entry == nil {
+		return
+	}
+	if err := h.svc.ExemptUser(ctx, auditID, userID); err != nil {
+		h.logger.Error("Failed to exempt user", "audit_id", auditID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCorrectionFailed)
+		return
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCorrectionExempted, entry.TargetUserID))
+}
+
+func (h *CallbackHandler) handleUndoStrike(ctx context.Context, userID int64, auditID uint) {
+	entry := h.loadCorrectionEntry(ctx, userID, auditID, repository.RoleModerator)
+	if entry == nil {
+		return
+	}
+	removed, unmuted, err := h.svc.UndoStrike(ctx, auditID, userID)
+	if errors.Is(err, service.ErrStrikeAlreadyUndone) {
+		h.sendText(ctx, userID, messages.MsgCorrectionAlreadyUndone)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to undo strike", "audit_id", auditID, "error", err)
+		h.sendText(ctx, userID, messages.MsgCorrectionFailed)
+		return
+	}
+	parts := []string{messages.MsgCorrectionNoStrike}
+	if removed {
+		parts[0] = messages.MsgCorrectionStrikeRemoved
+	}
+	if unmuted {
+		parts = append(parts, messages.MsgCorrectionUnmuted)
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgCorrectionUndone, entry.TargetUserID, strings.Join(parts, ", ")))
+}
@@ -0,0 +1,88 @@
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func (h *CallbackHandler) HandleExport(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnExportWords, schemes.DEFAULT, fmt.Sprintf("expw_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnExportDomains, schemes.DEFAULT, fmt.Sprintf("expd_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnExportSnapshotJSON, schemes.DEFAULT, fmt.Sprintf("exps_%d_%s", chatID, service.SnapshotFormatJSON))
+	kb.AddRow().AddCallback(messages.BtnExportSnapshotYAML, schemes.DEFAULT, fmt.Sprintf("exps_%d_%s", chatID, service.SnapshotFormatYAML))
+	kb.AddRow().AddCallback(messages.BtnImportSnapshot, schemes.NEGATIVE, fmt.Sprintf("prompt_snapshot_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgExportTitle, h.chatLabel(ctx, chatID)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send export menu", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleExportBlocklist(ctx context.Context, chatID, userID int64, kind string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	data, count, err := h.svc.ExportBlocklist(ctx, chatID, kind)
+	if err != nil {
+		h.logger.Error("Failed to export blocklist", "chat_id", chatID, "kind", kind, "error", err)
+		h.sendText(ctx, userID, messages.MsgExportFailed)
+		return
+	}
+	if count == 0 {
+		h.sendText(ctx, userID, messages.MsgExportEmpty)
+		h.HandleExport(ctx, chatID, userID)
+		return
+	}
+	name := fmt.Sprintf("%s_%d_%s.txt", kind, chatID, time.Now().Format("20060102"))
+	h.sendExportFile(ctx, userID, data, name, fmt.Sprintf(messages.MsgExportBlocklistCaption, h.chatLabel(ctx, chatID), count))
+	h.HandleExport(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleExportSnapshot(ctx context.Context, chatID, userID int64, format string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	data, err := h.svc.ExportSettingsSnapshot(ctx, chatID, format)
+	if err != nil {
+		h.logger.Error("Failed to export settings snapshot", "chat_id", chatID, "format", format, "error", err)
+		h.sendText(ctx, userID, messages.MsgExportFailed)
+		return
+	}
+	name := fmt.Sprintf("settings_%d_%s.%s", chatID, time.Now().Format("20060102"), format)
+	h.sendExportFile(ctx, userID, data, name, fmt.Sprintf(messages.MsgExportSnapshotCaption, h.chatLabel(ctx, chatID), service.SnapshotVersion))
+	h.HandleExport(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) sendExportFile(ctx context.Context, userID int64, data []byte, name, caption string) {
+	info, err := h.bot.Uploads.UploadMediaFromReaderWithName(ctx, schemes.FILE, bytes.NewReader(data), name)
+	if err != nil {
+		h.logger.Error("Failed to upload export", "name", name, "error", err)
+		h.sendText(ctx, userID, messages.MsgExportFailed)
+		return
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(caption)
+	msg.AddFile(info)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send export", "name", name, "error", err)
+	}
+}
@@ -2,6 +2,7 @@ package callbacks
 
 import (
 	"log/slog"
+	"time"
 
 	"max-moderation-bot/internal/repository"
 	"max-moderation-bot/internal/service"
@@ -11,19 +12,32 @@ import (
 )
 
 type CallbackHandler struct {
-	logger        *slog.Logger
-	svc           service.Service
-	bot           *maxbot.Api
-	userStateRepo repository.UserStateRepository
-	tracer        trace.Tracer
+	logger         *slog.Logger
+	svc            service.Service
+	bot            *maxbot.Api
+	userStateRepo  repository.UserStateRepository
+	tracer         trace.Tracer
+	appealCooldown time.Duration
+	operatorIDs    []int64
 }
 
-func NewCallbackHandler(logger *slog.Logger, svc service.Service, bot *maxbot.Api, userStateRepo repository.UserStateRepository, tracer trace.Tracer) *CallbackHandler {
+func NewCallbackHandler(logger *slog.Logger, svc service.Service, bot *maxbot.Api, userStateRepo repository.UserStateRepository, tracer trace.Tracer, appealCooldown time.Duration, operatorIDs []int64) *CallbackHandler {
 	return &CallbackHandler{
-		logger:        logger,
-		svc:           svc,
-		bot:           bot,
-		userStateRepo: userStateRepo,
-		tracer:        tracer,
+		logger:         logger,
+		svc:            svc,
+		bot:            bot,
+		userStateRepo:  userStateRepo,
+		tracer:         tracer,
+		appealCooldown: appealCooldown,
+		operatorIDs:    operatorIDs,
 	}
 }
+
+func (h *CallbackHandler) isOperator(userID int64) bool {
+	for _, operatorID := range h.operatorIDs {
+		if operatorID == userID {
+			return true
+		}
+	}
+	return false
+}
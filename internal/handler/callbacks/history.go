@@ -0,0 +1,156 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"strings"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func sanctionTypeLabel(sanctionType string) string {
+	switch sanctionType {
+	case service.SanctionBan:
+		return messages.MsgSanctionTypeBan
+	case service.SanctionKick:
+		return messages.MsgSanctionTypeKick
+	}
+	return messages.MsgSanctionTypeMute
+}
+
+func sanctionIssuerLabel(record repository.SanctionRecord) string {
+	switch record.Source {
+	case service.SanctionSourceStrikeLadder:
+		return messages.MsgSanctionIssuerLadder
+	case service.SanctionSourceGlobalBan:
+		return messages.MsgSanctionIssuerGlobalBan
+	case service.SanctionSourceChatGroup:
+		return fmt.Sprintf(messages.MsgSanctionIssuerChatGroup, record.IssuedBy)
+	case service.SanctionSourceAppeal:
+		return fmt.Sprintf(messages.MsgSanctionIssuerAppeal, record.IssuedBy)
+	}
+	if record.IssuedBy != 0 {
+		return fmt.Sprintf(messages.MsgSanctionIssuerAdmin, record.IssuedBy)
+	}
+	return fmt.Sprintf(messages.MsgSanctionIssuerFilter, record.Source)
+}
+
+func sanctionEndLabel(reason string) string {
+	switch reason {
+	case repository.SanctionEndUnmuted:
+		return messages.MsgSanctionEndUnmuted
+	case repository.SanctionEndUnbanned:
+		return messages.MsgSanctionEndUnbanned
+	case repository.SanctionEndReplaced:
+		return messages.MsgSanctionEndReplaced
+	case repository.SanctionEndAppeal:
+		return messages.MsgSanctionEndAppeal
+	case repository.SanctionEndReduced:
+		return messages.MsgSanctionEndReduced
+	}
+	return messages.MsgSanctionEndExpired
+}
+
+func sanctionRecordText(record repository.SanctionRecord, now time.Time) string {
+	duration := ""
+	if record.Type != service.SanctionKick {
+		duration = messages.MsgSanctionForever
+		if record.DurationSeconds > 0 {
+			duration = fmt.Sprintf(messages.MsgSanctionDuration, utils.FormatDuration(time.Duration(record.DurationSeconds)*time.Second))
+		}
+	}
+
+	reason := record.Reason
+	if reason == "" {
+		reason = messages.MsgWarnNoReason
+	}
+
+	status := ""
+	switch {
+	case record.EndedAt != nil:
+		end := sanctionEndLabel(record.EndReason)
+		if record.EndedBy != 0 && record.EndReason != repository.SanctionEndExpired {
+			end = fmt.Sprintf(messages.MsgSanctionEndBy, end, record.EndedBy)
+		}
+		status = fmt.Sprintf(messages.MsgSanctionEnded, record.EndedAt.Format("02.01.2006 15:04"), end)
+	case record.ExpiresAt == nil:
+	case record.ExpiresAt.Equal(repository.PermanentExpiry):
+		status = messages.MsgSanctionActiveForever
+	case !record.ExpiresAt.After(now):
+		status = fmt.Sprintf(messages.MsgSanctionEnded, record.ExpiresAt.Format("02.01.2006 15:04"), messages.MsgSanctionEndExpired)
+	default:
+		status = fmt.Sprintf(messages.MsgSanctionActiveUntil, record.ExpiresAt.Format("02.01.2006 15:04"))
+	}
+
+	return strings.TrimSpace(fmt.Sprintf(messages.MsgSanctionHistoryEntry,
+		record.CreatedAt.Format("02.01.2006 15:04"),
+		sanctionTypeLabel(record.Type),
+		duration,
+		sanctionIssuerLabel(record),
+		reason,
+		status,
+	))
+}
+
+func (h *CallbackHandler) HandleSanctionHistory(ctx context.Context, chatID, userID, targetUserID int64, page int) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	records, total, err := h.svc.GetSanctionHistory(ctx, chatID, targetUserID, page)
+	if err != nil {
+		h.logger.Error("Failed to get sanction history", "chat_id", chatID, "user_id", targetUserID, "error", err)
+		return
+	}
+
+	label := h.chatLabel(ctx, chatID)
+	kb := h.bot.Messages.NewKeyboardBuilder()
+
+	var text string
+	if len(records) == 0 {
+		text = fmt.Sprintf(messages.MsgSanctionHistoryEmpty, targetUserID, label)
+	} else {
+		totalPages := (int(total) + 9) / 10
+		userName := fmt.Sprintf("User %d", targetUserID)
+		for _, r := range records {
+			if r.UserName != "" {
+				userName = r.UserName
+				break
+			}
+		}
+		now := time.Now()
+		entries := make([]string, len(records))
+		for i, r := range records {
+			entries[i] = sanctionRecordText(r, now)
+		}
+		text = fmt.Sprintf(messages.MsgSanctionHistoryTitle, userName, targetUserID, label, page, totalPages) + "\n\n" + strings.Join(entries, "\n\n")
+
+		if totalPages > 1 {
+			navRow := kb.AddRow()
+			if page > 1 {
+				navRow.AddCallback(messages.BtnPrevPage, schemes.DEFAULT, fmt.Sprintf("sh_%d_%d_%d", chatID, targetUserID, page-1))
+			}
+			if page < totalPages {
+				navRow.AddCallback(messages.BtnNextPage, schemes.DEFAULT, fmt.Sprintf("sh_%d_%d_%d", chatID, targetUserID, page+1))
+			}
+		}
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send sanction history", "error", err)
+	}
+}
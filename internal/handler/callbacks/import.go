@@ -0,0 +1,146 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const (
+	ActionConfirmImport = "confirm_import"
+
+	importPreviewExamples = 5
+)
+
+func (h *CallbackHandler) pendingImport(userID, chatID int64) (*service.BlocklistImport, bool) {
+	state, err := h.userStateRepo.GetState(userID)
+	if err != nil {
+		h.logger.Error("Failed to get pending import", "user_id", userID, "error", err)
+		return nil, false
+	}
+	if state == nil || state.Action != ActionConfirmImport || state.ChatID != chatID {
+		return nil, false
+	}
+	var imp service.BlocklistImport
+	if err := json.Unmarshal([]byte(state.Payload), &imp); err != nil {
+		h.logger.Error("Failed to decode pending import", "user_id", userID, "error", err)
+		return nil, false
+	}
+	return &imp, true
+}
+
+func importExamples(entries []string) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	shown := entries
+	if len(shown) > importPreviewExamples {
+		shown = shown[:importPreviewExamples]
+	}
+	labels := make([]string, len(shown))
+	for i, entry := range shown {
+		labels[i] = truncateLabel(entry, 40)
+	}
+	text := strings.Join(labels, ", ")
+	if len(entries) > len(shown) {
+		text += fmt.Sprintf(messages.MsgImportMore, len(entries)-len(shown))
+	}
+	return "\n  " + text
+}
+
+func formatImportListDiff(title string, diff service.ImportListDiff) string {
+	text := fmt.Sprintf(messages.MsgImportPreviewList, title, len(diff.Added), importExamples(diff.Added), diff.Duplicates)
+	if len(diff.Removed) > 0 {
+		text += fmt.Sprintf(messages.MsgImportPreviewRemoved, len(diff.Removed), importExamples(diff.Removed))
+	}
+	return text
+}
+
+func FormatImportDiff(diff service.ImportDiff) string {
+	var sb strings.Builder
+	sb.WriteString(formatImportListDiff(messages.MsgImportWordsTitle, diff.Words))
+	sb.WriteString(formatImportListDiff(messages.MsgImportDomainsTitle, diff.Domains))
+	if len(diff.Invalid) > 0 {
+		sb.WriteString(fmt.Sprintf(messages.MsgImportPreviewInvalid, len(diff.Invalid), importExamples(diff.Invalid)))
+	}
+	return sb.String()
+}
+
+func (h *CallbackHandler) HandleImportPreview(ctx context.Context, chatID, userID int64, mode string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	imp, ok := h.pendingImport(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgImportExpired)
+		h.HandleManageGroup(ctx, chatID, userID)
+		return
+	}
+	diff, err := h.svc.PreviewBlocklistImport(ctx, chatID, imp, mode)
+	if err != nil {
+		h.logger.Error("Failed to preview import", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+
+	modeLabel, toggleLabel, toggleMode := messages.MsgImportModeMerge, messages.BtnImportModeReplace, service.ImportModeReplace
+	if mode == service.ImportModeReplace {
+		modeLabel, toggleLabel, toggleMode = messages.MsgImportModeReplace, messages.BtnImportModeMerge, service.ImportModeMerge
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnImportConfirm, schemes.POSITIVE, fmt.Sprintf("imc_%d_%s", chatID, mode))
+	kb.AddRow().AddCallback(toggleLabel, schemes.DEFAULT, fmt.Sprintf("imp_%d_%s", chatID, toggleMode))
+	kb.AddRow().AddCallback(messages.BtnCancel, schemes.NEGATIVE, fmt.Sprintf("imx_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgImportPreview, h.chatLabel(ctx, chatID), modeLabel, FormatImportDiff(diff)))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send import preview", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleConfirmImport(ctx context.Context, chatID, userID int64, mode string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	imp, ok := h.pendingImport(userID, chatID)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgImportExpired)
+		h.HandleManageGroup(ctx, chatID, userID)
+		return
+	}
+	diff, err := h.svc.ApplyBlocklistImport(ctx, chatID, imp, mode)
+	if err != nil {
+		h.logger.Error("Failed to apply import", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Error("Failed to clear user state", "error", err)
+	}
+
+	h.LogChange(ctx, chatID, userID, repository.AuditActionBlocklist, fmt.Sprintf("import (%s): +%d/-%d words, +%d/-%d domains",
+		mode, len(diff.Words.Added), len(diff.Words.Removed), len(diff.Domains.Added), len(diff.Domains.Removed)))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgImportApplied,
+		len(diff.Words.Added), len(diff.Words.Removed), len(diff.Domains.Added), len(diff.Domains.Removed), len(diff.Invalid)))
+	h.HandleManageGroup(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleCancelImport(ctx context.Context, chatID, userID int64) {
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Error("Failed to clear user state", "error", err)
+	}
+	h.sendText(ctx, userID, messages.MsgImportCancelled)
+	h.HandleManageGroup(ctx, chatID, userID)
+}
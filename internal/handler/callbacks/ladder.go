@@ -0,0 +1,128 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"sort"
+	"strings"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func StrikeStepLabel(strikes int, action string, duration time.Duration) string {
+	switch action {
+	case service.StrikeActionMute:
+		return fmt.Sprintf(messages.MsgStrikeStepMute, strikes, utils.FormatDuration(duration))
+	case service.StrikeActionKick:
+		return fmt.Sprintf(messages.MsgStrikeStepKick, strikes)
+	default:
+		return fmt.Sprintf(messages.MsgStrikeStepWarn, strikes)
+	}
+}
+
+func FormatStrikeStep(step repository.StrikeStep) string {
+	label := StrikeStepLabel(step.Strikes, step.Action, time.Duration(step.DurationSeconds)*time.Second)
+	return fmt.Sprintf(messages.MsgStrikeStepWindow, label, utils.FormatDuration(time.Duration(step.WindowSeconds)*time.Second))
+}
+
+func StrikeDecayLabel(days int) string {
+	if days <= 0 {
+		return messages.MsgStrikeDecayOff
+	}
+	return fmt.Sprintf(messages.MsgStrikeDecayInterval, days)
+}
+
+func (h *CallbackHandler) HandleStrikeLadder(ctx context.Context, chatID int64, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		h.logger.Warn("Access denied for strike ladder", "user_id", userID, "chat_id", chatID)
+		return
+	}
+
+	steps, isDefault, err := h.svc.GetStrikeLadder(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get strike ladder", "chat_id", chatID, "error", err)
+		return
+	}
+	weights, err := h.svc.GetViolationWeights(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get violation weights", "chat_id", chatID, "error", err)
+		return
+	}
+	decay := messages.MsgStrikeDecayOff
+	if settings, err := h.svc.GetChatSettings(ctx, chatID); err == nil {
+		decay = StrikeDecayLabel(settings.StrikeDecayDays)
+	}
+
+	label := fmt.Sprintf("%d", chatID)
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Strikes < steps[j].Strikes })
+	lines := make([]string, len(steps))
+	for i, step := range steps {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, FormatStrikeStep(step))
+	}
+	body := strings.Join(lines, "\n")
+	if isDefault {
+		body += "\n" + messages.MsgStrikeLadderDefault
+	}
+
+	weightsBody := messages.MsgViolationWeightsDefault
+	if len(weights) > 0 {
+		types := make([]string, 0, len(weights))
+		for violationType := range weights {
+			types = append(types, violationType)
+		}
+		sort.Strings(types)
+		weightLines := make([]string, len(types))
+		for i, violationType := range types {
+			name := violationType
+			if l, ok := service.ViolationTypeLabels[violationType]; ok {
+				name = l
+			}
+			weightLines[i] = fmt.Sprintf(messages.MsgViolationWeightLine, name, weights[violationType])
+		}
+		weightsBody = strings.Join(weightLines, "\n")
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	if !isDefault {
+		for _, step := range steps {
+			kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnDeleteStrikeStep, FormatStrikeStep(step)), schemes.NEGATIVE, fmt.Sprintf("ldel_%d_%d", chatID, step.ID))
+		}
+	}
+	kb.AddRow().AddCallback(messages.BtnAddStrikeStep, schemes.POSITIVE, fmt.Sprintf("prompt_ladder_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnSetViolationWeights, schemes.DEFAULT, fmt.Sprintf("prompt_weights_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnStrikeDecay, schemes.DEFAULT, fmt.Sprintf("prompt_decay_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnForgiveUser, schemes.POSITIVE, fmt.Sprintf("prompt_forgive_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgStrikeLadderTitle, label, body, weightsBody, decay))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send strike ladder message", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleDeleteStrikeStep(ctx context.Context, chatID int64, userID int64, stepID uint) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		h.logger.Warn("Access denied for strike step deletion", "user_id", userID, "chat_id", chatID)
+		return
+	}
+	if err := h.svc.DeleteStrikeStep(ctx, chatID, stepID); err != nil {
+		h.logger.Error("Failed to delete strike step", "step_id", stepID, "error", err)
+	} else {
+		h.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("ladder- #%d", stepID))
+	}
+	h.HandleStrikeLadder(ctx, chatID, userID)
+}
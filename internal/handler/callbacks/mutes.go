@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
 	"github.com/max-messenger/max-bot-api-client-go/schemes"
 )
 
 func (h *CallbackHandler) handleListMutes(ctx context.Context, chatID int64, userID int64, page int) {
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
 		return
 	}
 	if page < 1 {
@@ -51,6 +52,9 @@ func (h *CallbackHandler) handleListMutes(ctx context.Context, chatID int64, use
 			name = fmt.Sprintf("User %d", m.UserID)
 		}
 		userLabel := fmt.Sprintf("👤 %s", name)
+		if m.Reason != "" {
+			userLabel = fmt.Sprintf("👤 %s — %s", name, truncateLabel(m.Reason, 30))
+		}
 		kb.AddRow().AddCallback(userLabel, schemes.POSITIVE, fmt.Sprintf("vm_%d_%d_%d", chatID, m.UserID, page))
 	}
 
@@ -77,7 +81,7 @@ func (h *CallbackHandler) handleListMutes(ctx context.Context, chatID int64, use
 }
 
 func (h *CallbackHandler) handleViewMute(ctx context.Context, chatID int64, userID int64, targetUserID int64, page int) {
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleModerator) {
 		return
 	}
 
@@ -102,15 +106,26 @@ func (h *CallbackHandler) handleViewMute(ctx context.Context, chatID int64, user
 		userName = fmt.Sprintf("User %d", targetMute.UserID)
 	}
 
+	expires := messages.MsgBanPermanent
+	if !targetMute.ExpiresAt.Equal(repository.PermanentExpiry) {
+		expires = targetMute.ExpiresAt.Format("02.01.2006 15:04:05")
+	}
+	reason := targetMute.Reason
+	if reason == "" {
+		reason = messages.MsgWarnNoReason
+	}
+
 	text := fmt.Sprintf(messages.MsgMuteDetail,
 		label,
 		userName,
 		targetMute.UserID,
-		targetMute.ExpiresAt.Format("02.01.2006 15:04:05"),
+		expires,
+		reason,
 	)
 
 	kb := h.bot.Messages.NewKeyboardBuilder()
 	kb.AddRow().AddCallback(messages.BtnUnmute, schemes.NEGATIVE, fmt.Sprintf("um_%d_%d_%d", chatID, targetUserID, page))
+	kb.AddRow().AddCallback(messages.BtnSanctionHistory, schemes.DEFAULT, fmt.Sprintf("sh_%d_%d_1", chatID, targetUserID))
 	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("lm_%d_%d", chatID, page))
 
 	msg := maxbot.NewMessage()
@@ -141,3 +156,11 @@ func (h *CallbackHandler) sendText(ctx context.Context, userID int64, text strin
 		h.logger.Error("Failed to send text message", "error", err)
 	}
 }
+
+func truncateLabel(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
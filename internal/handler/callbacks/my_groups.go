@@ -18,6 +18,16 @@ func (h *CallbackHandler) handleAddGroup(ctx context.Context, userID int64) {
 	}
 	text := fmt.Sprintf(messages.MsgTokenGenerated, token, token)
 	kb := h.bot.Messages.NewKeyboardBuilder()
+	presets, err := h.svc.GetPresets(ctx, userID)
+	if err != nil {
+		h.logger.Warn("Failed to get presets for link", "user_id", userID, "error", err)
+	}
+	if len(presets) > 0 {
+		text += messages.MsgTokenPresetHint
+	}
+	for _, preset := range presets {
+		kb.AddRow().AddCallback(truncateLabel(presetLabel(preset), 40), schemes.DEFAULT, fmt.Sprintf("addp_%d", preset.ID))
+	}
 	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "main_menu")
 
 	msg := maxbot.NewMessage()
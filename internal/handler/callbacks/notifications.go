@@ -0,0 +1,96 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func digestLabel(digest string) string {
+	switch digest {
+	case repository.DigestDaily:
+		return messages.MsgDigestDaily
+	case repository.DigestWeekly:
+		return messages.MsgDigestWeekly
+	}
+	return messages.MsgDigestOff
+}
+
+func parseNotificationToggle(payload string) (int64, string, bool) {
+	var chatID int64
+	var option string
+	if _, err := fmt.Sscanf(payload, "ntt_%d_%s", &chatID, &option); err != nil {
+		return 0, "", false
+	}
+	switch option {
+	case service.NotifyEventAutoMute, service.NotifyEventRaid, service.NotifyEventRights, service.NotifyEventDeletes, service.NotifyOptionSilence:
+		return chatID, option, true
+	}
+	return 0, "", false
+}
+
+func (h *CallbackHandler) HandleNotifications(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleViewer) {
+		return
+	}
+	pref, err := h.svc.GetNotificationPrefs(ctx, chatID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get notification preferences", "chat_id", chatID, "user_id", userID, "error", err)
+		return
+	}
+
+	status := func(enabled bool) string {
+		if enabled {
+			return "✅"
+		}
+		return "❌"
+	}
+
+	text := fmt.Sprintf(messages.MsgNotificationsTitle, h.chatLabel(ctx, chatID))
+	if pref.Silenced {
+		text += messages.MsgNotificationsSilenced
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifyAutoMute, status(pref.NotifyAutoMute)), schemes.POSITIVE, fmt.Sprintf("ntt_%d_%s", chatID, service.NotifyEventAutoMute))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifyRaid, status(pref.NotifyRaid)), schemes.POSITIVE, fmt.Sprintf("ntt_%d_%s", chatID, service.NotifyEventRaid))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifyRights, status(pref.NotifyRights)), schemes.POSITIVE, fmt.Sprintf("ntt_%d_%s", chatID, service.NotifyEventRights))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifyDeletes, status(pref.NotifyDeletes)), schemes.POSITIVE, fmt.Sprintf("ntt_%d_%s", chatID, service.NotifyEventDeletes))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifyDigest, digestLabel(pref.Digest)), schemes.DEFAULT, fmt.Sprintf("ntd_%d", chatID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnNotifySilence, status(pref.Silenced)), schemes.NEGATIVE, fmt.Sprintf("ntt_%d_%s", chatID, service.NotifyOptionSilence))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send notification settings", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleToggleNotification(ctx context.Context, chatID, userID int64, option string) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleViewer) {
+		return
+	}
+	if _, err := h.svc.ToggleNotification(ctx, chatID, userID, option); err != nil {
+		h.logger.Error("Failed to toggle notification", "chat_id", chatID, "user_id", userID, "option", option, "error", err)
+	}
+	h.HandleNotifications(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleCycleDigest(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleViewer) {
+		return
+	}
+	if _, err := h.svc.CycleDigest(ctx, chatID, userID); err != nil {
+		h.logger.Error("Failed to change digest frequency", "chat_id", chatID, "user_id", userID, "error", err)
+	}
+	h.HandleNotifications(ctx, chatID, userID)
+}
@@ -0,0 +1,287 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+var presetFieldLabels = map[string]string{
+	"enable_auto_delete": messages.MsgCopyFieldAutoDelete,
+	"enable_word_filter": messages.MsgCopyFieldWordFilter,
+	"enable_link_filter": messages.MsgCopyFieldLinkFilter,
+	"enable_mute":        messages.MsgCopyFieldMute,
+	"restrict_image":     messages.MsgCopyFieldRestrictImage,
+	"restrict_video":     messages.MsgCopyFieldRestrictVideo,
+	"restrict_audio":     messages.MsgCopyFieldRestrictAudio,
+	"restrict_file":      messages.MsgCopyFieldRestrictFile,
+	"timezone":           messages.MsgCopyFieldTimezone,
+	"schedule_notices":   messages.MsgCopyFieldScheduleNotices,
+	"slow_mode_seconds":  messages.MsgCopyFieldSlowMode,
+	"strike_decay_days":  messages.MsgPresetFieldStrikeDecay,
+	"use_global_bans":    messages.MsgCopyFieldGlobalBans,
+	"enable_archive":     messages.MsgPresetFieldArchive,
+	"archive_days":       messages.MsgPresetFieldArchiveDays,
+	"blocked_words":      messages.MsgCopyFieldBlockedWords,
+	"blocked_domains":    messages.MsgCopyFieldBlockedDomains,
+	"word_rules":         messages.MsgCopyFieldWordRules,
+}
+
+func presetLabel(preset repository.SettingsPreset) string {
+	if preset.OwnerID == 0 {
+		return "🌐 " + preset.Name
+	}
+	return "👤 " + preset.Name
+}
+
+func presetOverridesLabel(overrides []string) string {
+	if len(overrides) == 0 {
+		return messages.MsgPresetNoOverrides
+	}
+	labels := make([]string, len(overrides))
+	for i, field := range overrides {
+		labels[i] = presetFieldLabels[field]
+		if labels[i] == "" {
+			labels[i] = field
+		}
+	}
+	return fmt.Sprintf(messages.MsgPresetOverrides, strings.Join(labels, ", "))
+}
+
+func (h *CallbackHandler) HandlePresets(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	presets, err := h.svc.GetPresets(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get presets", "user_id", userID, "error", err)
+		return
+	}
+	status, err := h.svc.GetPresetStatus(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get preset status", "chat_id", chatID, "error", err)
+		return
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, preset := range presets {
+		kb.AddRow().AddCallback(truncateLabel(presetLabel(preset), 40), schemes.DEFAULT, fmt.Sprintf("psv_%d_%d", chatID, preset.ID))
+	}
+	subscription := messages.MsgPresetNotSubscribed
+	if status != nil {
+		subscription = fmt.Sprintf(messages.MsgPresetSubscribed, status.Preset.Name, presetOverridesLabel(status.Overrides))
+		if len(status.Overrides) > 0 {
+			kb.AddRow().AddCallback(messages.BtnPresetReset, schemes.DEFAULT, fmt.Sprintf("psr_%d", chatID))
+		}
+		kb.AddRow().AddCallback(messages.BtnPresetUnsubscribe, schemes.NEGATIVE, fmt.Sprintf("psn_%d", chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnPresetSavePersonal, schemes.POSITIVE, fmt.Sprintf("prompt_preset_%d", chatID))
+	if h.isOperator(userID) {
+		kb.AddRow().AddCallback(messages.BtnPresetSaveShared, schemes.POSITIVE, fmt.Sprintf("prompt_spreset_%d", chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	text := fmt.Sprintf(messages.MsgPresetsTitle, h.chatLabel(ctx, chatID), subscription)
+	if len(presets) == 0 {
+		text += messages.MsgPresetsEmpty
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send presets", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handlePresetDetail(ctx context.Context, chatID, userID int64, presetID uint) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	preset, err := h.svc.GetPreset(ctx, userID, presetID)
+	if err != nil {
+		h.logger.Warn("Failed to get preset", "preset_id", presetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgPresetNotFound)
+		h.HandlePresets(ctx, chatID, userID)
+		return
+	}
+	data, err := h.svc.GetPresetData(ctx, userID, presetID)
+	if err != nil {
+		h.logger.Error("Failed to read preset", "preset_id", presetID, "error", err)
+		return
+	}
+	status := func(enabled bool) string {
+		if enabled {
+			return "✅"
+		}
+		return "❌"
+	}
+	scope := messages.MsgPresetScopePersonal
+	if preset.OwnerID == 0 {
+		scope = messages.MsgPresetScopeShared
+	}
+	text := fmt.Sprintf(messages.MsgPresetDetail, preset.Name, scope,
+		status(data.Settings.EnableWordFilter), status(data.Settings.EnableLinkFilter), status(data.Settings.EnableAutoDelete),
+		SlowModeLabel(data.Settings.SlowModeSeconds),
+		status(data.Settings.RestrictImage), status(data.Settings.RestrictVideo), status(data.Settings.RestrictAudio), status(data.Settings.RestrictFile),
+		len(data.BlockedWords), len(data.BlockedDomains), data.Settings.Timezone)
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnPresetApply, schemes.POSITIVE, fmt.Sprintf("psa_%d_%d", chatID, presetID))
+	kb.AddRow().AddCallback(messages.BtnPresetSubscribe, schemes.POSITIVE, fmt.Sprintf("pss_%d_%d", chatID, presetID))
+	if service.CanEditPreset(*preset, userID, h.isOperator(userID)) {
+		kb.AddRow().AddCallback(messages.BtnPresetUpdate, schemes.DEFAULT, fmt.Sprintf("psu_%d_%d", chatID, presetID))
+		kb.AddRow().AddCallback(messages.BtnPresetDelete, schemes.NEGATIVE, fmt.Sprintf("psx_%d_%d", chatID, presetID))
+	}
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("pst_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send preset detail", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleApplyPreset(ctx context.Context, chatID, userID int64, presetID uint, subscribe bool) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	if err := h.svc.ApplyPreset(ctx, chatID, userID, presetID, subscribe); err != nil {
+		h.logger.Error("Failed to apply preset", "chat_id", chatID, "preset_id", presetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	if subscribe {
+		h.sendText(ctx, userID, messages.MsgPresetSubscribedDone)
+	} else {
+		h.sendText(ctx, userID, messages.MsgPresetApplied)
+	}
+	h.HandlePresets(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleResetPreset(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	status, err := h.svc.GetPresetStatus(ctx, chatID)
+	if err != nil || status == nil {
+		h.HandlePresets(ctx, chatID, userID)
+		return
+	}
+	h.handleApplyPreset(ctx, chatID, userID, status.Preset.ID, true)
+}
+
+func (h *CallbackHandler) handleUnsubscribePreset(ctx context.Context, chatID, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	if err := h.svc.UnsubscribePreset(ctx, chatID, userID); err != nil {
+		h.logger.Error("Failed to unsubscribe preset", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.sendText(ctx, userID, messages.MsgPresetUnsubscribed)
+	h.HandlePresets(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleUpdatePreset(ctx context.Context, chatID, userID int64, presetID uint) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	updated, err := h.svc.UpdatePresetFromChat(ctx, presetID, chatID, userID, h.isOperator(userID))
+	if errors.Is(err, service.ErrPresetReadOnly) {
+		h.sendText(ctx, userID, messages.MsgPresetReadOnly)
+		h.handlePresetDetail(ctx, chatID, userID, presetID)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to update preset", "preset_id", presetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgPresetUpdated, updated))
+	h.handlePresetDetail(ctx, chatID, userID, presetID)
+}
+
+func (h *CallbackHandler) handleDeletePreset(ctx context.Context, chatID, userID int64, presetID uint) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	err := h.svc.DeletePreset(ctx, presetID, userID, h.isOperator(userID))
+	if errors.Is(err, service.ErrPresetReadOnly) {
+		h.sendText(ctx, userID, messages.MsgPresetReadOnly)
+		h.handlePresetDetail(ctx, chatID, userID, presetID)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to delete preset", "preset_id", presetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.sendText(ctx, userID, messages.MsgPresetDeleted)
+	h.HandlePresets(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) HandleSavePreset(ctx context.Context, chatID, userID int64, name string, shared bool) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		return
+	}
+	preset, err := h.svc.SavePresetFromChat(ctx, chatID, userID, name, shared, h.isOperator(userID))
+	if errors.Is(err, service.ErrPresetReadOnly) {
+		h.sendText(ctx, userID, messages.MsgPresetReadOnly)
+		h.HandlePresets(ctx, chatID, userID)
+		return
+	}
+	if errors.Is(err, service.ErrInvalidPresetName) {
+		h.sendText(ctx, userID, messages.MsgPresetInvalidName)
+		h.HandlePresets(ctx, chatID, userID)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to save preset", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgPresetSaved, preset.Name))
+	h.HandlePresets(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleAddGroupWithPreset(ctx context.Context, userID int64, presetID uint) {
+	preset, err := h.svc.GetPreset(ctx, userID, presetID)
+	if err != nil {
+		h.logger.Warn("Failed to get preset for link", "preset_id", presetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgPresetNotFound)
+		return
+	}
+	token, err := h.svc.GenerateLinkTokenWithPreset(ctx, userID, presetID)
+	if err != nil {
+		h.logger.Error("Failed to generate token", "error", err)
+		return
+	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "main_menu")
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgTokenGeneratedWithPreset, preset.Name, token, token))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send token message", "error", err)
+	} else {
+		metrics.IncBotAction("add_group")
+	}
+}
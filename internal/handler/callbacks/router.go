@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
 	"strings"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
@@ -40,6 +42,11 @@ func (h *CallbackHandler) Handle(ctx context.Context, upd *schemes.MessageCallba
 	switch {
 	case payload == "add_group":
 		h.handleAddGroup(ctx, upd.Callback.User.UserId)
+	case strings.HasPrefix(payload, "addp_"):
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "addp_%d", &presetID); err == nil {
+			h.handleAddGroupWithPreset(ctx, upd.Callback.User.UserId, presetID)
+		}
 	case strings.HasPrefix(payload, "my_groups"):
 		var page int
 		if _, err := fmt.Sscanf(payload, "my_groups_%d", &page); err == nil {
@@ -49,6 +56,42 @@ func (h *CallbackHandler) Handle(ctx context.Context, upd *schemes.MessageCallba
 		}
 	case payload == "main_menu":
 		h.sendMainMenu(ctx, upd.Callback.User.UserId)
+	case payload == "cgroups":
+		h.HandleChatGroups(ctx, upd.Callback.User.UserId)
+	case payload == "cgnew":
+		h.handleNewChatGroup(ctx, upd.Callback.User.UserId)
+	case strings.HasPrefix(payload, "cg_"):
+		var groupID uint
+		if _, err := fmt.Sscanf(payload, "cg_%d", &groupID); err == nil {
+			h.HandleChatGroup(ctx, upd.Callback.User.UserId, groupID)
+		}
+	case strings.HasPrefix(payload, "cgadd_"):
+		var groupID uint
+		if _, err := fmt.Sscanf(payload, "cgadd_%d", &groupID); err == nil {
+			h.handleChatGroupPicker(ctx, upd.Callback.User.UserId, groupID)
+		}
+	case strings.HasPrefix(payload, "cga_"):
+		var groupID uint
+		var groupChatID int64
+		if _, err := fmt.Sscanf(payload, "cga_%d_%d", &groupID, &groupChatID); err == nil {
+			h.handleAddChatToGroup(ctx, upd.Callback.User.UserId, groupID, groupChatID)
+		}
+	case strings.HasPrefix(payload, "cgrm_"):
+		var groupID uint
+		var groupChatID int64
+		if _, err := fmt.Sscanf(payload, "cgrm_%d_%d", &groupID, &groupChatID); err == nil {
+			h.handleRemoveChatFromGroup(ctx, upd.Callback.User.UserId, groupID, groupChatID)
+		}
+	case strings.HasPrefix(payload, "cgp_"):
+		var groupID uint
+		if _, err := fmt.Sscanf(payload, "cgp_%d", &groupID); err == nil {
+			h.handleToggleGroupPropagation(ctx, upd.Callback.User.UserId, groupID)
+		}
+	case strings.HasPrefix(payload, "cgdel_"):
+		var groupID uint
+		if _, err := fmt.Sscanf(payload, "cgdel_%d", &groupID); err == nil {
+			h.handleDeleteChatGroup(ctx, upd.Callback.User.UserId, groupID)
+		}
 	case strings.HasPrefix(payload, "manage_"):
 		var groupID int64
 		if _, err := fmt.Sscanf(payload, "manage_%d", &groupID); err == nil {
@@ -64,6 +107,180 @@ func (h *CallbackHandler) Handle(ctx context.Context, upd *schemes.MessageCallba
 		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "add_domains")
 	case strings.HasPrefix(payload, "prompt_import_words_"):
 		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "import_words")
+	case strings.HasPrefix(payload, "prompt_import_domains_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "import_domains")
+	case strings.HasPrefix(payload, "prompt_schedule_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "add_schedule")
+	case strings.HasPrefix(payload, "prompt_slowmode_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "set_slowmode")
+	case strings.HasPrefix(payload, "prompt_ladder_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "add_strike_step")
+	case strings.HasPrefix(payload, "prompt_weights_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "set_weights")
+	case strings.HasPrefix(payload, "prompt_decay_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "set_strike_decay")
+	case strings.HasPrefix(payload, "prompt_forgive_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "forgive_user")
+	case strings.HasPrefix(payload, "prompt_history_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "sanction_history")
+	case strings.HasPrefix(payload, "prompt_audituser_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "audit_user")
+	case strings.HasPrefix(payload, "prompt_archdays_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "set_archive_days")
+	case strings.HasPrefix(payload, "prompt_timezone_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "set_timezone")
+	case strings.HasPrefix(payload, "prompt_bwsearch_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "search_words")
+	case strings.HasPrefix(payload, "prompt_bdsearch_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "search_domains")
+	case strings.HasPrefix(payload, "bwl_"), strings.HasPrefix(payload, "bdl_"):
+		if kind, groupID, page, query, ok := parseBlocklistListPayload(payload); ok {
+			h.HandleBlocklist(ctx, groupID, upd.Callback.User.UserId, kind, query, page)
+		}
+	case strings.HasPrefix(payload, "bwd_"), strings.HasPrefix(payload, "bdd_"):
+		if kind, groupID, page, pos, hash, query, ok := parseBlocklistDeletePayload(payload); ok {
+			h.handleRemoveBlocklistEntry(ctx, groupID, upd.Callback.User.UserId, kind, query, page, pos, hash)
+		}
+	case strings.HasPrefix(payload, "prompt_preset_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "save_preset")
+	case strings.HasPrefix(payload, "prompt_spreset_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "save_shared_preset")
+	case strings.HasPrefix(payload, "pst_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "pst_%d", &groupID); err == nil {
+			h.HandlePresets(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "psv_"):
+		var groupID int64
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "psv_%d_%d", &groupID, &presetID); err == nil {
+			h.handlePresetDetail(ctx, groupID, upd.Callback.User.UserId, presetID)
+		}
+	case strings.HasPrefix(payload, "psa_"):
+		var groupID int64
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "psa_%d_%d", &groupID, &presetID); err == nil {
+			h.handleApplyPreset(ctx, groupID, upd.Callback.User.UserId, presetID, false)
+		}
+	case strings.HasPrefix(payload, "pss_"):
+		var groupID int64
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "pss_%d_%d", &groupID, &presetID); err == nil {
+			h.handleApplyPreset(ctx, groupID, upd.Callback.User.UserId, presetID, true)
+		}
+	case strings.HasPrefix(payload, "psu_"):
+		var groupID int64
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "psu_%d_%d", &groupID, &presetID); err == nil {
+			h.handleUpdatePreset(ctx, groupID, upd.Callback.User.UserId, presetID)
+		}
+	case strings.HasPrefix(payload, "psx_"):
+		var groupID int64
+		var presetID uint
+		if _, err := fmt.Sscanf(payload, "psx_%d_%d", &groupID, &presetID); err == nil {
+			h.handleDeletePreset(ctx, groupID, upd.Callback.User.UserId, presetID)
+		}
+	case strings.HasPrefix(payload, "psr_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "psr_%d", &groupID); err == nil {
+			h.handleResetPreset(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "psn_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "psn_%d", &groupID); err == nil {
+			h.handleUnsubscribePreset(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "prompt_snapshot_"):
+		h.handlePromptInput(ctx, payload, upd.Callback.User.UserId, "import_snapshot")
+	case strings.HasPrefix(payload, "exp_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "exp_%d", &groupID); err == nil {
+			h.HandleExport(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "expw_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "expw_%d", &groupID); err == nil {
+			h.handleExportBlocklist(ctx, groupID, upd.Callback.User.UserId, service.BlocklistWords)
+		}
+	case strings.HasPrefix(payload, "expd_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "expd_%d", &groupID); err == nil {
+			h.handleExportBlocklist(ctx, groupID, upd.Callback.User.UserId, service.BlocklistDomains)
+		}
+	case strings.HasPrefix(payload, "exps_"):
+		var groupID int64
+		var format string
+		if _, err := fmt.Sscanf(payload, "exps_%d_%s", &groupID, &format); err == nil && (format == service.SnapshotFormatJSON || format == service.SnapshotFormatYAML) {
+			h.handleExportSnapshot(ctx, groupID, upd.Callback.User.UserId, format)
+		}
+	case strings.HasPrefix(payload, "cpy_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpy_%d", &groupID); err == nil {
+			h.HandleCopyMenu(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cpf_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpf_%d", &groupID); err == nil {
+			h.handleCopySourcePicker(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cps_"):
+		var groupID, sourceID int64
+		if _, err := fmt.Sscanf(payload, "cps_%d_%d", &groupID, &sourceID); err == nil {
+			h.handleStartCopy(ctx, groupID, upd.Callback.User.UserId, &copyDraft{Source: sourceID, Targets: []int64{groupID}})
+		}
+	case strings.HasPrefix(payload, "cpt_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpt_%d", &groupID); err == nil {
+			h.handleStartCopy(ctx, groupID, upd.Callback.User.UserId, &copyDraft{Source: groupID, Bulk: true})
+		}
+	case strings.HasPrefix(payload, "cpd_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpd_%d", &groupID); err == nil {
+			h.HandleCopyDraft(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cpx_"):
+		var groupID int64
+		var section string
+		if _, err := fmt.Sscanf(payload, "cpx_%d_%s", &groupID, &section); err == nil {
+			h.handleToggleCopySection(ctx, groupID, upd.Callback.User.UserId, section)
+		}
+	case strings.HasPrefix(payload, "cpc_"):
+		var groupID, targetID int64
+		if _, err := fmt.Sscanf(payload, "cpc_%d_%d", &groupID, &targetID); err == nil {
+			h.handleToggleCopyTarget(ctx, groupID, upd.Callback.User.UserId, targetID)
+		}
+	case strings.HasPrefix(payload, "cpa_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpa_%d", &groupID); err == nil {
+			h.handleToggleAllCopyTargets(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cpp_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpp_%d", &groupID); err == nil {
+			h.handleCopyPreview(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cpk_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cpk_%d", &groupID); err == nil {
+			h.handleConfirmCopy(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "imp_"):
+		var groupID int64
+		var mode string
+		if _, err := fmt.Sscanf(payload, "imp_%d_%s", &groupID, &mode); err == nil && (mode == service.ImportModeMerge || mode == service.ImportModeReplace) {
+			h.HandleImportPreview(ctx, groupID, upd.Callback.User.UserId, mode)
+		}
+	case strings.HasPrefix(payload, "imc_"):
+		var groupID int64
+		var mode string
+		if _, err := fmt.Sscanf(payload, "imc_%d_%s", &groupID, &mode); err == nil && (mode == service.ImportModeMerge || mode == service.ImportModeReplace) {
+			h.handleConfirmImport(ctx, groupID, upd.Callback.User.UserId, mode)
+		}
+	case strings.HasPrefix(payload, "imx_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "imx_%d", &groupID); err == nil {
+			h.handleCancelImport(ctx, groupID, upd.Callback.User.UserId)
+		}
 	case strings.HasPrefix(payload, "clear_words_"):
 		h.handleClearBlocked(ctx, payload, upd.Callback.User.UserId, "clear_words")
 	case strings.HasPrefix(payload, "clear_domains_"):
@@ -88,11 +305,171 @@ func (h *CallbackHandler) Handle(ctx context.Context, upd *schemes.MessageCallba
 		if _, err := fmt.Sscanf(payload, "vm_%d_%d_%d", &groupID, &targetUserID, &page); err == nil {
 			h.handleViewMute(ctx, groupID, upd.Callback.User.UserId, targetUserID, page)
 		}
+	case strings.HasPrefix(payload, "lb_"):
+		var groupID int64
+		var page int
+		if _, err := fmt.Sscanf(payload, "lb_%d_%d", &groupID, &page); err == nil {
+			h.handleListBans(ctx, groupID, upd.Callback.User.UserId, page)
+		} else if _, err := fmt.Sscanf(payload, "lb_%d", &groupID); err == nil {
+			h.handleListBans(ctx, groupID, upd.Callback.User.UserId, 1)
+		}
+	case strings.HasPrefix(payload, "ub_"):
+		var groupID, targetUserID int64
+		var page int
+		if _, err := fmt.Sscanf(payload, "ub_%d_%d_%d", &groupID, &targetUserID, &page); err == nil {
+			h.handleUnban(ctx, groupID, upd.Callback.User.UserId, targetUserID, page)
+		}
+	case strings.HasPrefix(payload, "vb_"):
+		var groupID, targetUserID int64
+		var page int
+		if _, err := fmt.Sscanf(payload, "vb_%d_%d_%d", &groupID, &targetUserID, &page); err == nil {
+			h.handleViewBan(ctx, groupID, upd.Callback.User.UserId, targetUserID, page)
+		}
+	case strings.HasPrefix(payload, "sh_"):
+		var groupID, targetUserID int64
+		var page int
+		if _, err := fmt.Sscanf(payload, "sh_%d_%d_%d", &groupID, &targetUserID, &page); err == nil {
+			h.HandleSanctionHistory(ctx, groupID, upd.Callback.User.UserId, targetUserID, page)
+		}
+	case strings.HasPrefix(payload, "al_"):
+		if groupID, page, filter, ok := parseAuditLogPayload(payload); ok {
+			h.HandleAuditLog(ctx, groupID, upd.Callback.User.UserId, filter, page)
+		}
+	case strings.HasPrefix(payload, "alf_"):
+		var groupID, filterUserID int64
+		if _, err := fmt.Sscanf(payload, "alf_%d_%d", &groupID, &filterUserID); err == nil {
+			h.handleAuditActionPicker(ctx, groupID, upd.Callback.User.UserId, filterUserID)
+		}
+	case strings.HasPrefix(payload, "arl_"):
+		var groupID int64
+		var page int
+		if _, err := fmt.Sscanf(payload, "arl_%d_%d", &groupID, &page); err == nil {
+			h.HandleArchive(ctx, groupID, upd.Callback.User.UserId, page)
+		}
+	case strings.HasPrefix(payload, "arv_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "arv_%d", &id); err == nil {
+			h.handleViewArchived(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "arr_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "arr_%d", &id); err == nil {
+			h.handleRestoreArchived(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "arf_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "arf_%d", &id); err == nil {
+			h.handleFalsePositive(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "art_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "art_%d", &id); err == nil {
+			h.handleTrustSender(ctx, upd.Callback.User.UserId, id, true)
+		}
+	case strings.HasPrefix(payload, "aru_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "aru_%d", &id); err == nil {
+			h.handleTrustSender(ctx, upd.Callback.User.UserId, id, false)
+		}
+	case strings.HasPrefix(payload, "ntf_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "ntf_%d", &groupID); err == nil {
+			h.HandleNotifications(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "ntt_"):
+		if groupID, option, ok := parseNotificationToggle(payload); ok {
+			h.handleToggleNotification(ctx, groupID, upd.Callback.User.UserId, option)
+		}
+	case strings.HasPrefix(payload, "ntd_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "ntd_%d", &groupID); err == nil {
+			h.handleCycleDigest(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "fxr_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "fxr_%d", &id); err == nil {
+			h.handleRemoveMatchedRule(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "fxe_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "fxe_%d", &id); err == nil {
+			h.handleExemptUser(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "fxu_"):
+		var id uint
+		if _, err := fmt.Sscanf(payload, "fxu_%d", &id); err == nil {
+			h.handleUndoStrike(ctx, upd.Callback.User.UserId, id)
+		}
+	case strings.HasPrefix(payload, "schedule_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "schedule_%d", &groupID); err == nil {
+			h.HandleSchedule(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "sntf_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "sntf_%d", &groupID); err == nil {
+			h.handleToggleScheduleNotices(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "sdel_"):
+		var groupID int64
+		var ruleID uint
+		if _, err := fmt.Sscanf(payload, "sdel_%d_%d", &groupID, &ruleID); err == nil {
+			h.handleDeleteScheduleRule(ctx, groupID, upd.Callback.User.UserId, ruleID)
+		}
+	case strings.HasPrefix(payload, "ladder_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "ladder_%d", &groupID); err == nil {
+			h.HandleStrikeLadder(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "ldel_"):
+		var groupID int64
+		var stepID uint
+		if _, err := fmt.Sscanf(payload, "ldel_%d_%d", &groupID, &stepID); err == nil {
+			h.handleDeleteStrikeStep(ctx, groupID, upd.Callback.User.UserId, stepID)
+		}
+	case strings.HasPrefix(payload, "cad_"):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, "cad_%d", &groupID); err == nil {
+			h.HandleCoAdmins(ctx, groupID, upd.Callback.User.UserId)
+		}
+	case strings.HasPrefix(payload, "cai_"):
+		var groupID int64
+		var role string
+		if _, err := fmt.Sscanf(payload, "cai_%d_%s", &groupID, &role); err == nil {
+			h.handleCreateCoAdminInvite(ctx, groupID, upd.Callback.User.UserId, role)
+		}
+	case strings.HasPrefix(payload, "car_"):
+		var groupID, targetUserID int64
+		if _, err := fmt.Sscanf(payload, "car_%d_%d", &groupID, &targetUserID); err == nil {
+			h.handleRemoveCoAdmin(ctx, groupID, upd.Callback.User.UserId, targetUserID)
+		}
 	case strings.HasPrefix(payload, "stats_"):
 		var groupID int64
 		if _, err := fmt.Sscanf(payload, "stats_%d", &groupID); err == nil {
 			h.handleViewStats(ctx, groupID, upd.Callback.User.UserId)
 		}
+	case strings.HasPrefix(payload, AppealActionPrefix):
+		var groupID int64
+		if _, err := fmt.Sscanf(payload, AppealActionPrefix+service.SanctionMute+"_%d", &groupID); err == nil {
+			h.handleAppealSelect(ctx, upd.Callback.User.UserId, service.SanctionMute, groupID)
+		} else if _, err := fmt.Sscanf(payload, AppealActionPrefix+service.SanctionBan+"_%d", &groupID); err == nil {
+			h.handleAppealSelect(ctx, upd.Callback.User.UserId, service.SanctionBan, groupID)
+		}
+	case strings.HasPrefix(payload, "apok_"):
+		var appealID uint
+		if _, err := fmt.Sscanf(payload, "apok_%d", &appealID); err == nil {
+			h.handleAppealDecision(ctx, upd.Callback.User.UserId, appealID, repository.AppealStatusApproved)
+		}
+	case strings.HasPrefix(payload, "aprd_"):
+		var appealID uint
+		if _, err := fmt.Sscanf(payload, "aprd_%d", &appealID); err == nil {
+			h.handleAppealDecision(ctx, upd.Callback.User.UserId, appealID, repository.AppealStatusReduced)
+		}
+	case strings.HasPrefix(payload, "aprj_"):
+		var appealID uint
+		if _, err := fmt.Sscanf(payload, "aprj_%d", &appealID); err == nil {
+			h.handleAppealDecision(ctx, upd.Callback.User.UserId, appealID, repository.AppealStatusRejected)
+		}
 	default:
 		h.logger.Warn("Unknown callback payload", "payload", payload)
 	}
@@ -101,6 +478,7 @@ func (h *CallbackHandler) Handle(ctx context.Context, upd *schemes.MessageCallba
 func (h *CallbackHandler) sendMainMenu(ctx context.Context, userID int64) {
 	kb := h.bot.Messages.NewKeyboardBuilder()
 	kb.AddRow().AddCallback("Мои чаты", schemes.DEFAULT, "my_groups")
+	kb.AddRow().AddCallback(messages.BtnChatGroups, schemes.DEFAULT, "cgroups")
 	kb.AddRow().AddCallback("Добавить чат", schemes.POSITIVE, "add_group")
 
 	msg := maxbot.NewMessage()
@@ -0,0 +1,101 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/schedule"
+	"strings"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func (h *CallbackHandler) HandleSchedule(ctx context.Context, chatID int64, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		h.logger.Warn("Access denied for schedule", "user_id", userID, "chat_id", chatID)
+		return
+	}
+
+	settings, err := h.svc.GetChatSettings(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get settings for schedule", "chat_id", chatID, "error", err)
+		return
+	}
+	rules, err := h.svc.GetScheduleRules(ctx, chatID)
+	if err != nil {
+		h.logger.Error("Failed to get schedule rules", "chat_id", chatID, "error", err)
+		return
+	}
+
+	label := fmt.Sprintf("%d", chatID)
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+
+	tz := settings.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	body := messages.MsgScheduleEmpty
+	if len(rules) > 0 {
+		lines := make([]string, len(rules))
+		for i, rule := range rules {
+			lines[i] = fmt.Sprintf("%d. %s", i+1, schedule.FormatRule(rule))
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	status := "❌"
+	if settings.ScheduleNotices {
+		status = "✅"
+	}
+
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	for _, rule := range rules {
+		kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnDeleteScheduleRule, schedule.FormatRule(rule)), schemes.NEGATIVE, fmt.Sprintf("sdel_%d_%d", chatID, rule.ID))
+	}
+	kb.AddRow().AddCallback(messages.BtnAddScheduleRule, schemes.POSITIVE, fmt.Sprintf("prompt_schedule_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnSetTimezone, schemes.DEFAULT, fmt.Sprintf("prompt_timezone_%d", chatID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnScheduleNotices, status), schemes.POSITIVE, fmt.Sprintf("sntf_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgScheduleTitle, label, tz, body))
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send schedule message", "error", err)
+	}
+}
+
+func (h *CallbackHandler) handleToggleScheduleNotices(ctx context.Context, chatID int64, userID int64) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		h.logger.Warn("Access denied for schedule notices toggle", "user_id", userID, "chat_id", chatID)
+		return
+	}
+	if val, err := h.svc.ToggleSetting(ctx, chatID, "schedulenotices"); err != nil {
+		h.logger.Error("Failed to toggle schedule notices", "error", err)
+	} else {
+		h.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("schedulenotices=%t", val))
+	}
+	metrics.IncBotAction("toggle_setting")
+	h.HandleSchedule(ctx, chatID, userID)
+}
+
+func (h *CallbackHandler) handleDeleteScheduleRule(ctx context.Context, chatID int64, userID int64, ruleID uint) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
+		h.logger.Warn("Access denied for schedule rule deletion", "user_id", userID, "chat_id", chatID)
+		return
+	}
+	if err := h.svc.DeleteScheduleRule(ctx, chatID, ruleID); err != nil {
+		h.logger.Error("Failed to delete schedule rule", "rule_id", ruleID, "error", err)
+	} else {
+		h.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("schedule- #%d", ruleID))
+	}
+	h.HandleSchedule(ctx, chatID, userID)
+}
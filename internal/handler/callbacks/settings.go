@@ -5,28 +5,45 @@ import (
 	"fmt"
 	"max-moderation-bot/internal/messages"
 	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
 	"strings"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
 	"github.com/max-messenger/max-bot-api-client-go/schemes"
 )
 
-func (h *CallbackHandler) verifyAccess(ctx context.Context, userID, targetChatID int64) bool {
-	managedChats, err := h.svc.GetManagedChats(ctx, userID)
+func (h *CallbackHandler) chatRole(ctx context.Context, userID, chatID int64) string {
+	role, err := h.svc.GetChatRole(ctx, chatID, userID)
 	if err != nil {
-		h.logger.Error("Failed to get managed chats for verification", "user_id", userID, "error", err)
-		return false
+		h.logger.Error("Failed to get chat role for verification", "user_id", userID, "chat_id", chatID, "error", err)
+		return ""
 	}
-	for _, id := range managedChats {
-		if id == targetChatID {
-			return true
+	return role
+}
+
+func (h *CallbackHandler) verifyAccess(ctx context.Context, userID, targetChatID int64, minRole string) bool {
+	role := h.chatRole(ctx, userID, targetChatID)
+	if !service.RoleAtLeast(role, minRole) {
+		if role != "" {
+			h.sendText(ctx, userID, messages.MsgRoleInsufficient)
 		}
+		return false
 	}
-	return false
+	return true
+}
+
+func promptRole(action string) string {
+	switch action {
+	case "forgive_user", "sanction_history", "audit_user":
+		return repository.RoleModerator
+	}
+	return repository.RoleManager
 }
 
 func (h *CallbackHandler) HandleManageGroup(ctx context.Context, chatID int64, userID int64) {
-	if !h.verifyAccess(ctx, userID, chatID) {
+	role := h.chatRole(ctx, userID, chatID)
+	if role == "" {
 		h.logger.Warn("Access denied", "user_id", userID, "chat_id", chatID)
 		return
 	}
@@ -47,13 +64,56 @@ func (h *CallbackHandler) HandleManageGroup(ctx context.Context, chatID int64, u
 		}
 		return
 	}
+	kb := h.bot.Messages.NewKeyboardBuilder()
+	if service.RoleAtLeast(role, repository.RoleManager) {
+		addSettingsButtons(kb, chatID, settings)
+	}
+	if service.RoleAtLeast(role, repository.RoleModerator) {
+		kb.AddRow().AddCallback(messages.BtnMutesManagement, schemes.DEFAULT, fmt.Sprintf("lm_%d", chatID))
+		kb.AddRow().AddCallback(messages.BtnBansManagement, schemes.DEFAULT, fmt.Sprintf("lb_%d", chatID))
+		kb.AddRow().AddCallback(messages.BtnSanctionHistory, schemes.DEFAULT, fmt.Sprintf("prompt_history_%d", chatID))
+		kb.AddRow().AddCallback(messages.BtnArchive, schemes.DEFAULT, fmt.Sprintf("arl_%d_1", chatID))
+		kb.AddRow().AddCallback(messages.BtnAuditLog, schemes.DEFAULT, fmt.Sprintf("al_%d", chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnNotifications, schemes.DEFAULT, fmt.Sprintf("ntf_%d", chatID))
+	if service.RoleAtLeast(role, repository.RoleManager) {
+		kb.AddRow().AddCallback(messages.BtnExport, schemes.DEFAULT, fmt.Sprintf("exp_%d", chatID))
+		kb.AddRow().AddCallback(messages.BtnCopySettings, schemes.DEFAULT, fmt.Sprintf("cpy_%d", chatID))
+		kb.AddRow().AddCallback(messages.BtnPresets, schemes.DEFAULT, fmt.Sprintf("pst_%d", chatID))
+	}
+	kb.AddRow().AddCallback(messages.BtnStatistics, schemes.DEFAULT, fmt.Sprintf("stats_%d", chatID))
+	if role == repository.RoleOwner {
+		kb.AddRow().AddCallback(messages.BtnCoAdmins, schemes.DEFAULT, fmt.Sprintf("cad_%d", chatID))
+	}
+
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "my_groups")
+
+	label := fmt.Sprintf("%d", chatID)
+	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+	text := fmt.Sprintf(messages.MsgSettingsForGroup, label)
+	if role != repository.RoleOwner {
+		text += fmt.Sprintf(messages.MsgYourRole, RoleLabel(role))
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	msg.AddKeyboard(kb)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send settings message", "error", err)
+	}
+}
+
+func addSettingsButtons(kb *maxbot.Keyboard, chatID int64, settings *repository.ChatSettings) {
 	status := func(enabled bool) string {
 		if enabled {
 			return "✅"
 		}
 		return "❌"
 	}
-	kb := h.bot.Messages.NewKeyboardBuilder()
 	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnAutoDelete, status(settings.EnableAutoDelete)), schemes.POSITIVE, fmt.Sprintf("toggle_autodelete_%d", chatID))
 
 	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnWordFilter, status(settings.EnableWordFilter)), schemes.POSITIVE, fmt.Sprintf("toggle_words_%d", chatID))
@@ -63,32 +123,29 @@ func (h *CallbackHandler) HandleManageGroup(ctx context.Context, chatID int64, u
 	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnRestrictVideo, status(settings.RestrictVideo)), schemes.POSITIVE, fmt.Sprintf("toggle_video_%d", chatID))
 	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnRestrictAudio, status(settings.RestrictAudio)), schemes.POSITIVE, fmt.Sprintf("toggle_audio_%d", chatID))
 	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnRestrictFile, status(settings.RestrictFile)), schemes.POSITIVE, fmt.Sprintf("toggle_file_%d", chatID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnGlobalBans, status(settings.UseGlobalBans)), schemes.POSITIVE, fmt.Sprintf("toggle_globalbans_%d", chatID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnArchiveToggle, status(settings.EnableArchive)), schemes.POSITIVE, fmt.Sprintf("toggle_archive_%d", chatID))
+	kb.AddRow().AddCallback(fmt.Sprintf(messages.BtnSlowMode, SlowModeLabel(settings.SlowModeSeconds)), schemes.POSITIVE, fmt.Sprintf("prompt_slowmode_%d", chatID))
 
 	kb.AddRow().AddCallback(messages.BtnAddWords, schemes.DEFAULT, fmt.Sprintf("prompt_words_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBlockedWordsList, schemes.DEFAULT, fmt.Sprintf("bwl_%d_1", chatID))
 	kb.AddRow().AddCallback(messages.BtnImportWords, schemes.DEFAULT, fmt.Sprintf("prompt_import_words_%d", chatID))
 	kb.AddRow().AddCallback(messages.BtnClearWords, schemes.NEGATIVE, fmt.Sprintf("clear_words_%d", chatID))
 
 	kb.AddRow().AddCallback(messages.BtnAddDomains, schemes.DEFAULT, fmt.Sprintf("prompt_domains_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBlockedDomainsList, schemes.DEFAULT, fmt.Sprintf("bdl_%d_1", chatID))
+	kb.AddRow().AddCallback(messages.BtnImportDomains, schemes.DEFAULT, fmt.Sprintf("prompt_import_domains_%d", chatID))
 	kb.AddRow().AddCallback(messages.BtnClearDomains, schemes.NEGATIVE, fmt.Sprintf("clear_domains_%d", chatID))
 
-	kb.AddRow().AddCallback(messages.BtnMutesManagement, schemes.DEFAULT, fmt.Sprintf("lm_%d", chatID))
-	kb.AddRow().AddCallback(messages.BtnStatistics, schemes.DEFAULT, fmt.Sprintf("stats_%d", chatID))
-
-	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, "my_groups")
-
-	label := fmt.Sprintf("%d", chatID)
-	if chat, err := h.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
-		label = chat.Title
-	}
+	kb.AddRow().AddCallback(messages.BtnSchedule, schemes.DEFAULT, fmt.Sprintf("schedule_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnStrikeLadder, schemes.DEFAULT, fmt.Sprintf("ladder_%d", chatID))
+}
 
-	msg := maxbot.NewMessage()
-	msg.SetUser(userID)
-	msg.SetText(fmt.Sprintf(messages.MsgSettingsForGroup, label))
-	msg.SetFormat("markdown")
-	msg.AddKeyboard(kb)
-	if err := h.bot.Messages.Send(ctx, msg); err != nil {
-		h.logger.Error("Failed to send settings message", "error", err)
+func SlowModeLabel(seconds int) string {
+	if seconds <= 0 {
+		return messages.MsgSlowModeOff
 	}
+	return fmt.Sprintf(messages.MsgSlowModeInterval, seconds)
 }
 
 func (h *CallbackHandler) handleToggleSetting(ctx context.Context, payload string, userID int64) {
@@ -103,7 +160,7 @@ func (h *CallbackHandler) handleToggleSetting(ctx context.Context, payload strin
 		return
 	}
 
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
 		h.logger.Warn("Access denied for toggle", "user_id", userID, "chat_id", chatID)
 		return
 	}
@@ -114,6 +171,7 @@ func (h *CallbackHandler) handleToggleSetting(ctx context.Context, payload strin
 		h.logger.Error("Failed to toggle setting", "error", err)
 	} else {
 		h.logger.Info("Toggle setting success", "setting", setting, "chat_id", chatID, "old_value", !val, "new_value", val)
+		h.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("%s=%t", setting, val))
 	}
 	h.HandleManageGroup(ctx, chatID, userID)
 	metrics.IncBotAction("toggle_setting")
@@ -130,7 +188,7 @@ func (h *CallbackHandler) handlePromptInput(ctx context.Context, payload string,
 		return
 	}
 
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, promptRole(action)) {
 		h.logger.Warn("Access denied for prompt", "user_id", userID, "chat_id", chatID)
 		return
 	}
@@ -152,6 +210,7 @@ func (h *CallbackHandler) handlePromptInput(ctx context.Context, payload string,
 	msg.SetUser(userID)
 	msg.SetFormat("markdown")
 
+	backPayload := fmt.Sprintf("manage_%d", chatID)
 	switch action {
 	case "add_words":
 		examples := "плохое, злое, спам"
@@ -161,6 +220,60 @@ func (h *CallbackHandler) handlePromptInput(ctx context.Context, payload string,
 		msg.SetText(fmt.Sprintf(messages.MsgPromptAddWords, label, examples))
 	case "import_words":
 		msg.SetText(fmt.Sprintf(messages.MsgPromptImportWords, label))
+	case "import_domains":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptImportDomains, label))
+	case "add_schedule":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptAddScheduleRule, label))
+		backPayload = fmt.Sprintf("schedule_%d", chatID)
+	case "set_slowmode":
+		current := messages.MsgSlowModeOff
+		if settings != nil {
+			current = SlowModeLabel(settings.SlowModeSeconds)
+		}
+		msg.SetText(fmt.Sprintf(messages.MsgPromptSetSlowMode, label, current))
+	case "set_timezone":
+		tz := "UTC"
+		if settings != nil && settings.Timezone != "" {
+			tz = settings.Timezone
+		}
+		msg.SetText(fmt.Sprintf(messages.MsgPromptSetTimezone, label, tz))
+		backPayload = fmt.Sprintf("schedule_%d", chatID)
+	case "add_strike_step":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptAddStrikeStep, label))
+		backPayload = fmt.Sprintf("ladder_%d", chatID)
+	case "set_weights":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptSetViolationWeights, label))
+		backPayload = fmt.Sprintf("ladder_%d", chatID)
+	case "set_strike_decay":
+		current := messages.MsgStrikeDecayOff
+		if settings != nil {
+			current = StrikeDecayLabel(settings.StrikeDecayDays)
+		}
+		msg.SetText(fmt.Sprintf(messages.MsgPromptSetStrikeDecay, label, current))
+		backPayload = fmt.Sprintf("ladder_%d", chatID)
+	case "forgive_user":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptForgiveUser, label))
+		backPayload = fmt.Sprintf("ladder_%d", chatID)
+	case "sanction_history":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptSanctionHistory, label))
+	case "set_archive_days":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptArchiveDays, label, archiveRetentionDays(settings)))
+		backPayload = fmt.Sprintf("arl_%d_1", chatID)
+	case "audit_user":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptAuditUser, label))
+		backPayload = fmt.Sprintf("al_%d", chatID)
+	case "import_snapshot":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptImportSnapshot, label))
+		backPayload = fmt.Sprintf("exp_%d", chatID)
+	case "save_preset", "save_shared_preset":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptPresetName, label))
+		backPayload = fmt.Sprintf("pst_%d", chatID)
+	case "search_words":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptBlocklistSearch, label))
+		backPayload = fmt.Sprintf("bwl_%d_1", chatID)
+	case "search_domains":
+		msg.SetText(fmt.Sprintf(messages.MsgPromptBlocklistSearch, label))
+		backPayload = fmt.Sprintf("bdl_%d_1", chatID)
 	default:
 		examples := "bad.com, spam.org"
 		if settings != nil && len(settings.BlockedDomains) > 0 {
@@ -169,7 +282,7 @@ func (h *CallbackHandler) handlePromptInput(ctx context.Context, payload string,
 		msg.SetText(fmt.Sprintf(messages.MsgPromptAddDomains, label, examples))
 	}
 	kb := h.bot.Messages.NewKeyboardBuilder()
-	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, fmt.Sprintf("manage_%d", chatID))
+	kb.AddRow().AddCallback(messages.BtnBack, schemes.DEFAULT, backPayload)
 	msg.AddKeyboard(kb)
 
 	if err := h.bot.Messages.Send(ctx, msg); err != nil {
@@ -188,7 +301,7 @@ func (h *CallbackHandler) handleClearBlocked(ctx context.Context, payload string
 		return
 	}
 
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleManager) {
 		h.logger.Warn("Access denied for clear", "user_id", userID, "chat_id", chatID)
 		return
 	}
@@ -208,6 +321,7 @@ func (h *CallbackHandler) handleClearBlocked(ctx context.Context, payload string
 		h.logger.Error("Failed to clear blocked items", "error", err)
 		return
 	}
+	h.LogChange(ctx, chatID, userID, repository.AuditActionBlocklist, action)
 
 	h.HandleManageGroup(ctx, chatID, userID)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
 	"max-moderation-bot/internal/utils"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 func (h *CallbackHandler) handleViewStats(ctx context.Context, chatID int64, userID int64) {
-	if !h.verifyAccess(ctx, userID, chatID) {
+	if !h.verifyAccess(ctx, userID, chatID, repository.RoleViewer) {
 		return
 	}
 
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func parseCommandTarget(text string, markups []schemes.MarkUp, reply *schemes.User) (*schemes.User, []string) {
+	units := utf16.Encode([]rune(text))
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	command := trimmed
+	if idx := strings.IndexFunc(trimmed, unicode.IsSpace); idx >= 0 {
+		command = trimmed[:idx]
+	}
+	rest := trimmed[len(command):]
+	offset := len(units) - len(utf16.Encode([]rune(rest)))
+	if reply != nil {
+		return reply, strings.Fields(rest)
+	}
+
+	for _, m := range markups {
+		if m.Type != schemes.MarkupUser || m.UserId == 0 || m.From < offset || m.From+m.Length > len(units) {
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimSpace(string(utf16.Decode(units[m.From:m.From+m.Length]))), "@")
+		remaining := string(utf16.Decode(units[offset:m.From])) + " " + string(utf16.Decode(units[m.From+m.Length:]))
+		return &schemes.User{UserId: m.UserId, Name: name}, strings.Fields(remaining)
+	}
+
+	args := strings.Fields(rest)
+	if len(args) > 0 {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(args[0], "@"), 10, 64); err == nil && id > 0 {
+			return &schemes.User{UserId: id}, args[1:]
+		}
+	}
+	return nil, args
+}
+
+func splitDurationArg(args []string) (time.Duration, string, error) {
+	if len(args) == 0 {
+		return 0, "", nil
+	}
+	duration, err := utils.ParseDuration(args[0])
+	if err != nil {
+		if unicode.IsDigit([]rune(args[0])[0]) {
+			return 0, "", fmt.Errorf("invalid duration: %s", args[0])
+		}
+		return 0, strings.Join(args, " "), nil
+	}
+	return duration, strings.Join(args[1:], " "), nil
+}
+
+func (h *Handler) commandTarget(ctx context.Context, upd *schemes.MessageCreatedUpdate) (*schemes.User, []string) {
+	var reply *schemes.User
+	if upd.Message.Link != nil {
+		reply = &upd.Message.Link.Sender
+	}
+	target, args := parseCommandTarget(upd.Message.Body.Text, upd.Message.Body.Markups, reply)
+	if target == nil || target.Name != "" {
+		return target, args
+	}
+	members, err := h.bot.Chats.GetSpecificChatMembers(ctx, upd.Message.Recipient.ChatId, []int64{target.UserId})
+	if err != nil {
+		h.logger.Warn("Failed to resolve command target", "user_id", target.UserId, "error", err)
+	} else if len(members.Members) > 0 {
+		target.Name = members.Members[0].Name
+	}
+	if target.Name == "" {
+		target.Name = fmt.Sprintf("User %d", target.UserId)
+	}
+	return target, args
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func TestParseCommandTarget(t *testing.T) {
+	reply := &schemes.User{UserId: 7, Name: "Reply"}
+
+	tests := []struct {
+		name       string
+		text       string
+		markups    []schemes.MarkUp
+		reply      *schemes.User
+		wantUserID int64
+		wantName   string
+		wantArgs   []string
+	}{
+		{
+			name:       "Reply with duration and reason",
+			text:       "/mute 1h флуд в чате",
+			reply:      reply,
+			wantUserID: 7,
+			wantName:   "Reply",
+			wantArgs:   []string{"1h", "флуд", "в", "чате"},
+		},
+		{
+			name:       "Numeric ID",
+			text:       "/ban 12345 24h спам",
+			wantUserID: 12345,
+			wantArgs:   []string{"24h", "спам"},
+		},
+		{
+			name:       "Mention with cyrillic name",
+			text:       "/warn @Иван Петров спам",
+			markups:    []schemes.MarkUp{{Type: schemes.MarkupUser, From: 6, Length: 12, UserId: 99}},
+			wantUserID: 99,
+			wantName:   "Иван Петров",
+			wantArgs:   []string{"спам"},
+		},
+		{
+			name:       "Mention after emoji in command",
+			text:       "/unmute 😀 @Bob",
+			markups:    []schemes.MarkUp{{Type: schemes.MarkupUser, From: 11, Length: 4, UserId: 5}},
+			wantUserID: 5,
+			wantName:   "Bob",
+			wantArgs:   []string{"😀"},
+		},
+		{
+			name:     "No target",
+			text:     "/mute 1h",
+			wantArgs: []string{"1h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, args := parseCommandTarget(tt.text, tt.markups, tt.reply)
+			var gotID int64
+			var gotName string
+			if target != nil {
+				gotID, gotName = target.UserId, target.Name
+			}
+			if gotID != tt.wantUserID || gotName != tt.wantName {
+				t.Errorf("target = %d %q, want %d %q", gotID, gotName, tt.wantUserID, tt.wantName)
+			}
+			if len(args) == 0 && len(tt.wantArgs) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSplitDurationArg(t *testing.T) {
+	tests := []struct {
+		args         []string
+		wantDuration time.Duration
+		wantReason   string
+		wantErr      bool
+	}{
+		{args: nil},
+		{args: []string{"1h", "флуд"}, wantDuration: time.Hour, wantReason: "флуд"},
+		{args: []string{"спам", "и", "реклама"}, wantReason: "спам и реклама"},
+		{args: []string{"1x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		duration, reason, err := splitDurationArg(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitDurationArg(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if duration != tt.wantDuration || reason != tt.wantReason {
+			t.Errorf("splitDurationArg(%q) = %v %q, want %v %q", tt.args, duration, reason, tt.wantDuration, tt.wantReason)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/service"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const maxGlobalBanImportSize = 5 << 20
+
+func (h *Handler) enforceGlobalBan(ctx context.Context, chatID, userID int64, trigger string) bool {
+	enforced, err := h.svc.EnforceGlobalBan(ctx, chatID, userID, trigger)
+	if err != nil {
+		h.logger.Error("Failed to enforce global ban", "chat_id", chatID, "user_id", userID, "trigger", trigger, "error", err)
+		return false
+	}
+	return enforced
+}
+
+func (h *Handler) handleGlobalBanCommand(ctx context.Context, userID int64, text string) bool {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "/gban":
+		h.handleGlobalBanAdd(ctx, userID, fields[1:])
+	case "/gunban":
+		h.handleGlobalBanRemove(ctx, userID, fields[1:])
+	case "/gbans":
+		count, err := h.svc.CountGlobalBans(ctx)
+		if err != nil {
+			h.logger.Error("Failed to count global bans", "error", err)
+			h.sendText(ctx, userID, messages.MsgGlobalBanFailed)
+			return true
+		}
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBansUsage, count))
+	case "/gimport":
+		if err := h.userStateRepo.SetState(userID, 0, "import_global_bans"); err != nil {
+			h.logger.Error("Failed to set user state", "error", err)
+			return true
+		}
+		h.sendText(ctx, userID, messages.MsgPromptGlobalBanImport)
+	case "/gexport":
+		format := service.GlobalBanFormatCSV
+		if len(fields) > 1 && strings.EqualFold(fields[1], service.GlobalBanFormatJSON) {
+			format = service.GlobalBanFormatJSON
+		}
+		h.sendGlobalBanExport(ctx, userID, format)
+	default:
+		return false
+	}
+	return true
+}
+
+func parseGlobalBanTarget(args []string) (int64, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) handleGlobalBanAdd(ctx context.Context, userID int64, args []string) {
+	targetID, ok := parseGlobalBanTarget(args)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgGlobalBanInvalidID)
+		return
+	}
+	if err := h.svc.AddGlobalBan(ctx, userID, targetID, strings.Join(args[1:], " ")); err != nil {
+		h.logger.Error("Failed to add global ban", "user_id", targetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgGlobalBanFailed)
+		return
+	}
+	h.logger.Info("Global ban added", "operator_id", userID, "user_id", targetID)
+	metrics.IncBotAction("global_ban_add")
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanAdded, targetID))
+}
+
+func (h *Handler) handleGlobalBanRemove(ctx context.Context, userID int64, args []string) {
+	targetID, ok := parseGlobalBanTarget(args)
+	if !ok {
+		h.sendText(ctx, userID, messages.MsgGlobalBanInvalidID)
+		return
+	}
+	removed, err := h.svc.RemoveGlobalBan(ctx, targetID)
+	if err != nil {
+		h.logger.Error("Failed to remove global ban", "user_id", targetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgGlobalBanFailed)
+		return
+	}
+	if !removed {
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanNotListed, targetID))
+		return
+	}
+	h.logger.Info("Global ban removed", "operator_id", userID, "user_id", targetID)
+	metrics.IncBotAction("global_ban_remove")
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanRemoved, targetID))
+}
+
+func (h *Handler) handleGlobalBanImport(ctx context.Context, userID int64, text string, rawAttachments []json.RawMessage) {
+	var data []byte
+	var format string
+	if len(rawAttachments) > 0 {
+		fileURL, fileName := findFileAttachment(rawAttachments)
+		if fileURL == "" {
+			h.sendText(ctx, userID, messages.MsgGlobalBanImportFileType)
+			return
+		}
+		ext := strings.ToLower(filepath.Ext(fileName))
+		if ext == "" {
+			if u, err := url.Parse(fileURL); err == nil {
+				ext = strings.ToLower(filepath.Ext(u.Path))
+			}
+		}
+		switch ext {
+		case ".csv":
+			format = service.GlobalBanFormatCSV
+		case ".json":
+			format = service.GlobalBanFormatJSON
+		default:
+			h.sendText(ctx, userID, messages.MsgGlobalBanImportFileType)
+			return
+		}
+		body, err := downloadFile(ctx, fileURL, maxGlobalBanImportSize)
+		if err != nil {
+			h.logger.Error("Failed to download file", "url", fileURL, "error", err)
+			h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanImportError, err))
+			return
+		}
+		data = body
+	} else {
+		if text == "" {
+			h.sendText(ctx, userID, messages.MsgOnlyTextSupported)
+			return
+		}
+		data = []byte(text)
+	}
+
+	count, err := h.svc.ImportGlobalBans(ctx, userID, data, format)
+	if err != nil {
+		h.logger.Warn("Failed to import global bans", "operator_id", userID, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanImportError, err))
+		return
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Warn("Failed to clear state after global ban import", "error", err)
+	}
+	h.logger.Info("Global bans imported", "operator_id", userID, "count", count)
+	metrics.IncBotAction("global_ban_import")
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgGlobalBanImported, count))
+}
+
+func downloadFile(ctx context.Context, fileURL string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file exceeds %d bytes", limit)
+	}
+	return data, nil
+}
+
+func (h *Handler) sendGlobalBanExport(ctx context.Context, userID int64, format string) {
+	count, err := h.svc.CountGlobalBans(ctx)
+	if err != nil {
+		h.logger.Error("Failed to count global bans", "error", err)
+		h.sendText(ctx, userID, messages.MsgGlobalBanExportFailed)
+		return
+	}
+	if count == 0 {
+		h.sendText(ctx, userID, messages.MsgGlobalBanExportEmpty)
+		return
+	}
+	data, err := h.svc.ExportGlobalBans(ctx, format)
+	if err != nil {
+		h.logger.Error("Failed to export global bans", "error", err)
+		h.sendText(ctx, userID, messages.MsgGlobalBanExportFailed)
+		return
+	}
+
+	name := fmt.Sprintf("global_bans_%s.%s", time.Now().Format("20060102"), format)
+	info, err := h.bot.Uploads.UploadMediaFromReaderWithName(ctx, schemes.FILE, bytes.NewReader(data), name)
+	if err != nil {
+		h.logger.Error("Failed to upload global ban export", "error", err)
+		h.sendText(ctx, userID, messages.MsgGlobalBanExportFailed)
+		return
+	}
+
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(fmt.Sprintf(messages.MsgGlobalBanExportCaption, count))
+	msg.AddFile(info)
+	if err := h.bot.Messages.Send(ctx, msg); err != nil {
+		h.logger.Error("Failed to send global ban export", "error", err)
+	}
+	metrics.IncBotAction("global_ban_export")
+}
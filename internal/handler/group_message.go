@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
 	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
 	"strings"
 	"time"
 
@@ -21,6 +24,42 @@ func (h *Handler) handleGroupMessage(ctx context.Context, upd *schemes.MessageCr
 		h.handleMuteCommand(ctx, upd)
 		return
 	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/unmute") {
+		h.handleUnmuteCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/warns") {
+		h.handleWarnsCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/warn") {
+		h.handleWarnCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/unwarn") {
+		h.handleUnwarnCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/forgive") {
+		h.handleForgiveCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/kick") {
+		h.handleKickCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/ban") {
+		h.handleBanCommand(ctx, upd)
+		return
+	}
+	if strings.HasPrefix(upd.Message.Body.Text, "/report") {
+		h.handleReportCommand(ctx, upd)
+		return
+	}
+	if h.enforceGlobalBan(ctx, upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, service.GlobalBanTriggerMessage) {
+		h.deleteFilteredMessage(ctx, upd.Message, "global_ban", "", messages.MsgAuditReasonGlobalBan)
+		return
+	}
 	var attachmentTypes []string
 	if len(upd.Message.Body.RawAttachments) > 0 {
 		for _, raw := range upd.Message.Body.RawAttachments {
@@ -54,29 +93,29 @@ func (h *Handler) handleGroupMessage(ctx context.Context, upd *schemes.MessageCr
 		h.logger.Info("Message blocked", "reason", res.Reason, "filter", res.FilterName)
 
 		go func() {
+			if res.Silent {
+				return
+			}
 			if res.ShouldMute {
 				h.logger.Info("Muting user for rate limit", "user_id", upd.Message.Sender.UserId, "duration", res.MuteDuration)
-				if err := h.svc.SystemMuteUser(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, upd.Message.Sender.Name, res.MuteDuration); err != nil {
+				if err := h.svc.SystemMuteUser(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, upd.Message.Sender.Name, res.Reason, res.FilterName, res.MuteDuration); err != nil {
 					h.logger.Error("Failed to system mute user", "error", err)
 				}
 				h.sendWarningWithMention(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender, res.Reason)
 				return
 			}
+			if res.SkipViolation {
+				h.SendAutoDeleteMessage(context.Background(), upd.Message.Recipient.ChatId, fmt.Sprintf(messages.MsgNoticeWithMention, mentionLink(upd.Message.Sender), res.Reason))
+				return
+			}
 			if res.FilterName != "mute_filter" {
-				shouldMute, duration, err := h.svc.TrackViolation(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, res.FilterName)
+				outcome, err := h.svc.TrackViolation(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, res.FilterName, res.Severity, upd.Message.Body.Mid)
 				if err != nil {
 					h.logger.Error("Failed to track violation", "error", err)
-				}
-				if shouldMute {
-					h.logger.Info("Muting user for persistent violations", "user_id", upd.Message.Sender.UserId)
-					if err := h.svc.SystemMuteUser(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender.UserId, upd.Message.Sender.Name, duration); err != nil {
-						h.logger.Error("Failed to system mute user", "error", err)
-					}
-					h.sendWarningWithMention(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender, messages.MsgReasonPersistentViolation)
+					h.sendWarningWithMention(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender, res.Reason)
 					return
 				}
-
-				h.sendWarningWithMention(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender, res.Reason)
+				h.applyStrikeOutcome(context.Background(), upd.Message.Recipient.ChatId, upd.Message.Sender, messages.MsgProhibitedContent, res.Reason, outcome)
 			}
 		}()
 		go func() {
@@ -84,7 +123,7 @@ func (h *Handler) handleGroupMessage(ctx context.Context, upd *schemes.MessageCr
 
 			if res.ShouldDelete {
 				h.logger.Info("Deleting message as requested by filter", "mid", upd.Message.Body.Mid, "filter", res.FilterName)
-				_ = h.deleteMessage(bgCtx, upd.Message.Body.Mid, res.FilterName)
+				h.deleteFilteredMessage(bgCtx, upd.Message, res.FilterName, res.MatchedRule, res.Reason)
 
 				return
 			}
@@ -96,7 +135,7 @@ func (h *Handler) handleGroupMessage(ctx context.Context, upd *schemes.MessageCr
 				h.logger.Info("Checking auto-delete setting", "enabled", settings.EnableAutoDelete, "chat_id", settings.ChatID)
 				if settings.EnableAutoDelete {
 					h.logger.Info("Attempting to delete message", "message_id", upd.Message.Body.Mid)
-					_ = h.deleteMessage(bgCtx, upd.Message.Body.Mid, res.FilterName)
+					h.deleteFilteredMessage(bgCtx, upd.Message, res.FilterName, res.MatchedRule, res.Reason)
 
 				} else {
 					h.logger.Info("Auto-delete is disabled for this chat")
@@ -157,64 +196,181 @@ func (h *Handler) handleMuteCommand(ctx context.Context, upd *schemes.MessageCre
 		"text", upd.Message.Body.Text,
 		"mid", upd.Message.Body.Mid,
 	)
-	if upd.Message.Link == nil {
-
-		h.logger.Info("Mute command used without reply (Link is nil)")
-		h.SendAutoDeleteMessage(ctx, upd.Message.Recipient.ChatId, messages.MsgMuteCommandInvalid)
+	chatID := upd.Message.Recipient.ChatId
+	target, args := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.logger.Info("Mute command used without target")
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteCommandInvalid)
 		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_mute_command_cleanup")
 
 		return
 	}
 
-	parts := strings.Fields(upd.Message.Body.Text)
-	duration, err := time.ParseDuration(h.config.DefaultMuteDuration)
+	duration, reason, err := splitDurationArg(args)
 	if err != nil {
-		h.logger.Error("Invalid default mute duration in config, using fallback", "error", err)
-		duration = 30 * time.Minute
+		h.logger.Info("Invalid mute duration format", "args", args)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteDurationInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_mute_duration_cleanup")
+
+		return
 	}
-	if len(parts) > 1 {
-		var err error
-		duration, err = time.ParseDuration(parts[1])
+	if duration == 0 {
+		duration, err = utils.ParseDuration(h.config.DefaultMuteDuration)
 		if err != nil {
-			h.logger.Info("Invalid mute duration format", "input", parts[1])
-			h.SendAutoDeleteMessage(ctx, upd.Message.Recipient.ChatId, messages.MsgMuteDurationInvalid)
-			_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_mute_duration_cleanup")
+			h.logger.Error("Invalid default mute duration in config, using fallback", "error", err)
+			duration = 30 * time.Minute
+		}
+	}
+
+	adminID := upd.Message.Sender.UserId
+	if !h.checkCommandAdmin(ctx, upd, "mute") {
+		return
+	}
+
+	err = h.svc.MuteUser(ctx, chatID, adminID, target.UserId, target.Name, reason, duration)
 
-			return
+	if err != nil {
+		h.logger.Error("Failed to mute user", "error", err)
+		if isRoleError(err) {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
+		} else {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteFailed)
 		}
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "mute_command_cleanup")
+		return
 	}
 
-	targetUserID := upd.Message.Link.Sender.UserId
+	text := fmt.Sprintf(messages.MsgUserMuted, target.Name, utils.FormatDuration(duration))
+	if duration == utils.Forever {
+		text = fmt.Sprintf(messages.MsgUserMutedForever, target.Name)
+	}
+	h.SendAutoDeleteMessage(ctx, chatID, withReason(text, reason))
 
-	targetUserName := upd.Message.Link.Sender.Name
-	adminID := upd.Message.Sender.UserId
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "mute_command_cleanup")
+
+}
+
+func (h *Handler) handleUnmuteCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
 	chatID := upd.Message.Recipient.ChatId
+	target, _ := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgUnmuteCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_unmute_command_cleanup")
+		return
+	}
+	if !h.checkCommandAdmin(ctx, upd, "unmute") {
+		return
+	}
 
-	isAdmin, err := h.svc.IsChatAdmin(ctx, chatID, adminID)
-	if err != nil {
-		h.logger.Error("Failed to check real-time admin status for mute", "error", err)
-	} else if !isAdmin {
-		h.logger.Info("Non-admin user tried to use mute command", "user_id", adminID)
-		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
-		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "non_admin_mute_cleanup")
+	if err := h.svc.UnmuteUser(ctx, chatID, upd.Message.Sender.UserId, target.UserId); err != nil {
+		h.logger.Error("Failed to unmute user", "user_id", target.UserId, "error", err)
+		text := messages.MsgUnmuteFailed
+		if isRoleError(err) {
+			text = messages.MsgMuteAdminError
+		}
+		h.SendAutoDeleteMessage(ctx, chatID, text)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "unmute_command_cleanup")
+		return
+	}
+	metrics.IncBotAction("unmute")
+
+	h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgUserUnmuted, target.Name))
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "unmute_command_cleanup")
+}
+
+func (h *Handler) handleKickCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, args := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.logger.Info("Kick command used without target")
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgKickCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_kick_command_cleanup")
+		return
+	}
+
+	if !h.checkCommandAdmin(ctx, upd, "kick") {
 		return
 	}
 
-	err = h.svc.MuteUser(ctx, chatID, adminID, targetUserID, targetUserName, duration)
+	if err := h.svc.KickUser(ctx, chatID, upd.Message.Sender.UserId, target.UserId, target.Name, strings.Join(args, " "), service.SanctionSourceAdmin); err != nil {
+		h.logger.Error("Failed to kick user", "user_id", target.UserId, "error", err)
+		text := messages.MsgKickFailed
+		if isRoleError(err) {
+			text = messages.MsgMuteAdminError
+		}
+		h.SendAutoDeleteMessage(ctx, chatID, text)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "kick_command_cleanup")
+		return
+	}
+	metrics.IncBotAction("kick")
+
+	h.SendAutoDeleteMessage(ctx, chatID, withReason(fmt.Sprintf(messages.MsgUserKicked, target.Name), strings.Join(args, " ")))
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "kick_command_cleanup")
+}
+
+func (h *Handler) handleBanCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, args := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.logger.Info("Ban command used without target")
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgBanCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_ban_command_cleanup")
+		return
+	}
 
+	duration, reason, err := splitDurationArg(args)
 	if err != nil {
-		h.logger.Error("Failed to mute user", "error", err)
-		if strings.Contains(err.Error(), "not a bot admin") {
-			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
-			_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "start_mute_admin_cleanup")
+		h.logger.Info("Invalid ban duration format", "args", args)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgBanDurationInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_ban_duration_cleanup")
+		return
+	}
+
+	if !h.checkCommandAdmin(ctx, upd, "ban") {
+		return
+	}
 
+	if err := h.svc.BanUser(ctx, chatID, upd.Message.Sender.UserId, target.UserId, target.Name, reason, duration); err != nil {
+		h.logger.Error("Failed to ban user", "user_id", target.UserId, "error", err)
+		text := messages.MsgBanFailed
+		if isRoleError(err) {
+			text = messages.MsgMuteAdminError
 		}
+		h.SendAutoDeleteMessage(ctx, chatID, text)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "ban_command_cleanup")
 		return
 	}
+	metrics.IncBotAction("ban")
 
-	humanDuration := duration.String()
-	h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgUserMuted, targetUserName, humanDuration))
+	text := fmt.Sprintf(messages.MsgUserBannedPermanent, target.Name)
+	if duration > 0 && duration != utils.Forever {
+		text = fmt.Sprintf(messages.MsgUserBanned, target.Name, utils.FormatDuration(duration))
+	}
+	h.SendAutoDeleteMessage(ctx, chatID, withReason(text, reason))
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "ban_command_cleanup")
+}
 
-	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "mute_command_cleanup")
+func withReason(text, reason string) string {
+	if reason == "" {
+		return text
+	}
+	return fmt.Sprintf(messages.MsgSanctionWithReason, text, reason)
+}
 
+func (h *Handler) handleUserAdded(ctx context.Context, upd *schemes.UserAddedToChatUpdate) {
+	h.syncMemberAccess(ctx, upd.ChatId, upd.User.UserId)
+	if h.svc.TrackJoin(ctx, upd.ChatId) {
+		metrics.IncBotAction("raid_detected")
+	}
+	banned, err := h.svc.EnforceBan(ctx, upd.ChatId, upd.User.UserId)
+	if err != nil {
+		h.logger.Error("Failed to enforce ban", "chat_id", upd.ChatId, "user_id", upd.User.UserId, "error", err)
+		return
+	}
+	if banned {
+		h.logger.Info("Removed banned user on rejoin", "chat_id", upd.ChatId, "user_id", upd.User.UserId)
+		metrics.IncBotAction("ban_enforced")
+		return
+	}
+	h.enforceGlobalBan(ctx, upd.ChatId, upd.User.UserId, service.GlobalBanTriggerJoin)
 }
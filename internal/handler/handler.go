@@ -37,7 +37,7 @@ func NewHandler(logger *slog.Logger, svc service.Service, bot *maxbot.Api, userS
 		userStateRepo:   userStateRepo,
 		tracer:          otel.Tracer("handler"),
 		config:          cfg,
-		callbackHandler: callbacks.NewCallbackHandler(logger, svc, bot, userStateRepo, otel.Tracer("callbacks")),
+		callbackHandler: callbacks.NewCallbackHandler(logger, svc, bot, userStateRepo, otel.Tracer("callbacks"), parseAppealCooldown(cfg.AppealCooldown), cfg.AdminUserIDs),
 	}
 }
 
@@ -67,6 +67,22 @@ func (h *Handler) HandleUpdate(ctx context.Context, upd schemes.UpdateInterface)
 			span.SetAttributes(attribute.String("update_type", "bot_started"))
 		}
 		h.handleBotStarted(ctx, u)
+	case *schemes.UserAddedToChatUpdate:
+		if h.config.EnableTelemetry {
+			span.SetAttributes(attribute.String("update_type", "user_added"))
+		}
+		h.handleUserAdded(ctx, u)
+	case *schemes.UserRemovedFromChatUpdate:
+		if h.config.EnableTelemetry {
+			span.SetAttributes(attribute.String("update_type", "user_removed"))
+		}
+		h.handleUserRemoved(ctx, u)
+	case *schemes.BotRemovedFromChatUpdate:
+		if h.config.EnableTelemetry {
+			span.SetAttributes(attribute.String("update_type", "bot_removed"))
+		}
+		h.logger.Warn("Bot removed from chat", "chat_id", u.ChatId, "user_id", u.User.UserId)
+		h.svc.NotifyBotRemoved(ctx, u.ChatId)
 	default:
 		h.logger.Debug("Received unhandled update type", "type", fmt.Sprintf("%T", u))
 	}
@@ -1,67 +1,22 @@
 package handler
 
-import (
-	"bufio"
-	"strings"
-	"testing"
-)
+import "testing"
 
-func TestParseWordsFile(t *testing.T) {
+func TestImportFileName(t *testing.T) {
 	tests := []struct {
-		name            string
-		input           string
-		expectedWords   []string
-		expectedSkipped int
-		wantErr         bool
+		name     string
+		fileName string
+		fileURL  string
+		want     string
 	}{
-		{
-			name: "Valid input",
-			input: `apple
-banana
-cherry`,
-			expectedWords:   []string{"apple", "banana", "cherry"},
-			expectedSkipped: 0,
-			wantErr:         false,
-		},
-		{
-			name: "Input with spaces and empty lines",
-			input: `apple
-   
-banana with space
-cherry
-`,
-			expectedWords:   []string{"apple", "cherry"},
-			expectedSkipped: 1, // "banana with space" is skipped.
-			wantErr:         false,
-		},
-		{
-			name: "Input with surrounding whitespace",
-			input: `  apple  
-	banana	`,
-			expectedWords:   []string{"apple", "banana"},
-			expectedSkipped: 0,
-			wantErr:         false,
-		},
+		{name: "Attachment name wins", fileName: "words.csv", fileURL: "https://files.example/abc.txt", want: "words.csv"},
+		{name: "Falls back to URL path", fileURL: "https://files.example/d/domains.zip?sig=1", want: "domains.zip"},
+		{name: "No extension", fileURL: "https://files.example/d/abc", want: "abc"},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			scanner := bufio.NewScanner(strings.NewReader(tt.input))
-			words, skipped, err := parseWordsFile(scanner)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseWordsFile() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if len(words) != len(tt.expectedWords) {
-				t.Errorf("parseWordsFile() got words count = %d, want %d", len(words), len(tt.expectedWords))
-			}
-			for i, w := range words {
-				if w != tt.expectedWords[i] {
-					t.Errorf("parseWordsFile() word[%d] = %v, want %v", i, w, tt.expectedWords[i])
-				}
-			}
-			if skipped != tt.expectedSkipped {
-				t.Errorf("parseWordsFile() skipped = %v, want %v", skipped, tt.expectedSkipped)
+			if got := importFileName(tt.fileName, tt.fileURL); got != tt.want {
+				t.Errorf("importFileName() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -1,16 +1,19 @@
 package handler
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"max-moderation-bot/internal/handler/callbacks"
 	"max-moderation-bot/internal/messages"
 	"max-moderation-bot/internal/metrics"
 	"max-moderation-bot/internal/repository"
-	"net/http"
+	"max-moderation-bot/internal/schedule"
+	"max-moderation-bot/internal/service"
 	"net/url"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +22,10 @@ import (
 )
 
 func (h *Handler) handlePrivateMessage(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	if h.handleJoinCommand(ctx, upd.Message.Sender.UserId, strings.TrimSpace(upd.Message.Body.Text)) {
+		return
+	}
+
 	// === ПРОВЕРКА НА АДМИНИСТРАТОРА ===
 	isAdmin := false
 	for _, adminID := range h.config.AdminUserIDs {
@@ -27,9 +34,9 @@ func (h *Handler) handlePrivateMessage(ctx context.Context, upd *schemes.Message
 			break
 		}
 	}
-	if !isAdmin {
-		h.logger.Warn("Non-admin user access denied", "user_id", upd.Message.Sender.UserId)
-		return // Игнорируем всех неадминов
+	if !isAdmin && !h.managesChats(ctx, upd.Message.Sender.UserId) {
+		h.handleSanctionedUserMessage(ctx, upd)
+		return
 	}
 	// ===================================
 
@@ -54,7 +61,19 @@ func (h *Handler) handlePrivateMessage(ctx context.Context, upd *schemes.Message
 	state, _ := h.userStateRepo.GetState(upd.Message.Sender.UserId)
 	if state != nil {
 		if state.Action == "import_words" {
-			h.handleFileImport(ctx, upd.Message.Sender.UserId, state.ChatID, upd.Message.Body.RawAttachments)
+			h.handleFileImport(ctx, upd.Message.Sender.UserId, state.ChatID, upd.Message.Body.RawAttachments, service.BlocklistWords)
+			return
+		}
+		if state.Action == "import_domains" {
+			h.handleFileImport(ctx, upd.Message.Sender.UserId, state.ChatID, upd.Message.Body.RawAttachments, service.BlocklistDomains)
+			return
+		}
+		if state.Action == "import_snapshot" {
+			h.handleSnapshotImport(ctx, upd.Message.Sender.UserId, state.ChatID, text, upd.Message.Body.RawAttachments)
+			return
+		}
+		if state.Action == "import_global_bans" {
+			h.handleGlobalBanImport(ctx, upd.Message.Sender.UserId, text, upd.Message.Body.RawAttachments)
 			return
 		}
 		if text == "" {
@@ -66,11 +85,15 @@ func (h *Handler) handlePrivateMessage(ctx context.Context, upd *schemes.Message
 	}
 
 	// Обработка команды /broadcast
-	if strings.HasPrefix(upd.GetCommand(), "/broadcast") {
+	if isAdmin && strings.HasPrefix(upd.GetCommand(), "/broadcast") {
 		h.handleBroadcast(ctx, upd)
 		return
 	}
 
+	if isAdmin && h.handleGlobalBanCommand(ctx, upd.Message.Sender.UserId, text) {
+		return
+	}
+
 	if strings.HasPrefix(text, "/start") || strings.HasPrefix(text, "/menu") {
 		h.sendMainMenu(ctx, upd.Message.Sender.UserId)
 	}
@@ -85,8 +108,10 @@ func (h *Handler) handleBotStarted(ctx context.Context, upd *schemes.BotStartedU
 			break
 		}
 	}
-	if !isAdmin {
-		h.logger.Warn("Non-admin user started bot", "user_id", upd.User.UserId)
+	if !isAdmin && !h.managesChats(ctx, upd.User.UserId) {
+		if !h.callbackHandler.SendAppealMenu(ctx, upd.User.UserId) {
+			h.logger.Warn("Non-admin user started bot", "user_id", upd.User.UserId)
+		}
 		return
 	}
 	// ===================================
@@ -99,10 +124,87 @@ func (h *Handler) handleBotStarted(ctx context.Context, upd *schemes.BotStartedU
 	h.sendMainMenu(ctx, upd.User.UserId)
 }
 
+func (h *Handler) managesChats(ctx context.Context, userID int64) bool {
+	chats, err := h.svc.GetManagedChats(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to get managed chats", "user_id", userID, "error", err)
+		return false
+	}
+	return len(chats) > 0
+}
+
+func (h *Handler) handleJoinCommand(ctx context.Context, userID int64, text string) bool {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || fields[0] != "/join" {
+		return false
+	}
+	if len(fields) != 2 {
+		h.sendText(ctx, userID, messages.MsgCoAdminJoinUsage)
+		return true
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Error("Failed to delete user state", "error", err)
+	}
+	h.callbackHandler.HandleJoinInvite(ctx, userID, fields[1])
+	return true
+}
+
 func (h *Handler) handleUserInput(ctx context.Context, text string, userID int64, state *repository.UserState) {
 	if err := h.userStateRepo.ClearState(userID); err != nil {
 		h.logger.Error("Failed to delete user state", "error", err)
 	}
+	switch state.Action {
+	case "add_schedule":
+		h.handleScheduleRuleInput(ctx, text, userID, state.ChatID)
+		return
+	case "set_timezone":
+		h.handleTimezoneInput(ctx, text, userID, state.ChatID)
+		return
+	case "set_slowmode":
+		h.handleSlowModeInput(ctx, text, userID, state.ChatID)
+		return
+	case "add_strike_step":
+		h.handleStrikeStepInput(ctx, text, userID, state.ChatID)
+		return
+	case "set_weights":
+		h.handleViolationWeightsInput(ctx, text, userID, state.ChatID)
+		return
+	case "set_strike_decay":
+		h.handleStrikeDecayInput(ctx, text, userID, state.ChatID)
+		return
+	case "forgive_user":
+		h.handleForgiveInput(ctx, text, userID, state.ChatID)
+		return
+	case "create_chat_group":
+		h.handleChatGroupNameInput(ctx, text, userID)
+		return
+	case "sanction_history":
+		h.handleSanctionHistoryInput(ctx, text, userID, state.ChatID)
+		return
+	case "audit_user":
+		h.handleAuditUserInput(ctx, text, userID, state.ChatID)
+		return
+	case "set_archive_days":
+		h.handleArchiveDaysInput(ctx, text, userID, state.ChatID)
+		return
+	case "save_preset", "save_shared_preset":
+		h.callbackHandler.HandleSavePreset(ctx, state.ChatID, userID, text, state.Action == "save_shared_preset")
+		return
+	case callbacks.ActionCopySettings:
+		h.sendText(ctx, userID, messages.MsgCopyCancelled)
+		h.callbackHandler.HandleManageGroup(ctx, state.ChatID, userID)
+		return
+	case callbacks.ActionConfirmImport:
+		h.sendText(ctx, userID, messages.MsgImportCancelled)
+		h.callbackHandler.HandleManageGroup(ctx, state.ChatID, userID)
+		return
+	case "search_words":
+		h.callbackHandler.HandleBlocklist(ctx, state.ChatID, userID, service.BlocklistWords, callbacks.TrimBlocklistQuery(text), 1)
+		return
+	case "search_domains":
+		h.callbackHandler.HandleBlocklist(ctx, state.ChatID, userID, service.BlocklistDomains, callbacks.TrimBlocklistQuery(text), 1)
+		return
+	}
 	rawItems := strings.Split(text, ",")
 	var items []string
 	for _, item := range rawItems {
@@ -133,10 +235,165 @@ func (h *Handler) handleUserInput(ctx context.Context, text string, userID int64
 		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
 		return
 	}
+	h.callbackHandler.LogChange(ctx, state.ChatID, userID, repository.AuditActionBlocklist, state.Action+": "+strings.Join(items, ", "))
 	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgSettingsUpdated, msg, len(items)))
 	h.callbackHandler.HandleManageGroup(ctx, state.ChatID, userID)
 }
 
+func (h *Handler) handleScheduleRuleInput(ctx context.Context, text string, userID, chatID int64) {
+	rule, err := h.svc.AddScheduleRule(ctx, chatID, text)
+	if err != nil {
+		h.logger.Info("Invalid schedule rule input", "input", text, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgScheduleRuleInvalid, err))
+		h.callbackHandler.HandleSchedule(ctx, chatID, userID)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, "schedule+ "+schedule.FormatRule(*rule))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgScheduleRuleAdded, schedule.FormatRule(*rule)))
+	h.callbackHandler.HandleSchedule(ctx, chatID, userID)
+}
+
+func (h *Handler) handleTimezoneInput(ctx context.Context, text string, userID, chatID int64) {
+	tz := strings.TrimSpace(text)
+	if err := h.svc.SetTimezone(ctx, chatID, tz); err != nil {
+		h.logger.Info("Invalid timezone input", "input", tz, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgTimezoneInvalid, tz))
+		h.callbackHandler.HandleSchedule(ctx, chatID, userID)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, "timezone="+tz)
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgTimezoneUpdated, tz))
+	h.callbackHandler.HandleSchedule(ctx, chatID, userID)
+}
+
+func (h *Handler) handleSlowModeInput(ctx context.Context, text string, userID, chatID int64) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || seconds < 0 {
+		h.sendTextWithBack(ctx, userID, chatID, messages.MsgSlowModeInvalid)
+		return
+	}
+	if err := h.svc.SetSlowMode(ctx, chatID, seconds); err != nil {
+		h.logger.Error("Failed to set slow mode", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("slowmode=%d", seconds))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgSlowModeUpdated, callbacks.SlowModeLabel(seconds)))
+	h.callbackHandler.HandleManageGroup(ctx, chatID, userID)
+}
+
+func (h *Handler) handleStrikeStepInput(ctx context.Context, text string, userID, chatID int64) {
+	step, err := h.svc.AddStrikeStep(ctx, chatID, text)
+	if err != nil {
+		h.logger.Info("Invalid strike step input", "input", text, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgStrikeStepInvalid, err))
+		h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, "ladder+ "+callbacks.FormatStrikeStep(*step))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgStrikeStepAdded, callbacks.FormatStrikeStep(*step)))
+	h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+}
+
+func (h *Handler) handleViolationWeightsInput(ctx context.Context, text string, userID, chatID int64) {
+	if err := h.svc.SetViolationWeights(ctx, chatID, text); err != nil {
+		h.logger.Info("Invalid violation weights input", "input", text, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgViolationWeightsInvalid, err))
+		h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, "weights="+strings.TrimSpace(text))
+	h.sendText(ctx, userID, messages.MsgViolationWeightsUpdated)
+	h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+}
+
+func (h *Handler) handleStrikeDecayInput(ctx context.Context, text string, userID, chatID int64) {
+	days, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || days < 0 {
+		h.sendText(ctx, userID, messages.MsgStrikeDecayInvalid)
+		h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+		return
+	}
+	if err := h.svc.SetStrikeDecay(ctx, chatID, days); err != nil {
+		h.logger.Error("Failed to set strike decay", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("strikedecay=%d", days))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgStrikeDecayUpdated, callbacks.StrikeDecayLabel(days)))
+	h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+}
+
+func (h *Handler) handleForgiveInput(ctx context.Context, text string, userID, chatID int64) {
+	targetID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil || targetID <= 0 {
+		h.sendText(ctx, userID, messages.MsgForgiveInvalidID)
+		h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+		return
+	}
+	forgiven, err := h.svc.ForgiveUser(ctx, chatID, userID, targetID)
+	if err != nil {
+		h.logger.Error("Failed to forgive user", "chat_id", chatID, "user_id", targetID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	name := strconv.FormatInt(targetID, 10)
+	if forgiven == 0 {
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgForgiveNothing, name))
+	} else {
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgForgiveSuccess, name, forgiven))
+	}
+	h.callbackHandler.HandleStrikeLadder(ctx, chatID, userID)
+}
+
+func (h *Handler) handleSanctionHistoryInput(ctx context.Context, text string, userID, chatID int64) {
+	targetID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil || targetID <= 0 {
+		h.sendText(ctx, userID, messages.MsgSanctionHistoryInvalidID)
+		h.callbackHandler.HandleManageGroup(ctx, chatID, userID)
+		return
+	}
+	h.callbackHandler.HandleSanctionHistory(ctx, chatID, userID, targetID, 1)
+}
+
+func (h *Handler) handleArchiveDaysInput(ctx context.Context, text string, userID, chatID int64) {
+	days, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || days < 1 || days > 365 {
+		h.sendText(ctx, userID, messages.MsgArchiveDaysInvalid)
+		h.callbackHandler.HandleArchive(ctx, chatID, userID, 1)
+		return
+	}
+	if err := h.svc.SetArchiveRetention(ctx, chatID, days); err != nil {
+		h.logger.Error("Failed to set archive retention", "chat_id", chatID, "error", err)
+		h.sendText(ctx, userID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.callbackHandler.LogChange(ctx, chatID, userID, repository.AuditActionSetting, fmt.Sprintf("archivedays=%d", days))
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgArchiveDaysUpdated, days))
+	h.callbackHandler.HandleArchive(ctx, chatID, userID, 1)
+}
+
+func (h *Handler) handleAuditUserInput(ctx context.Context, text string, userID, chatID int64) {
+	targetID, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil || targetID < 0 {
+		h.sendText(ctx, userID, messages.MsgSanctionHistoryInvalidID)
+		targetID = 0
+	}
+	h.callbackHandler.HandleAuditLog(ctx, chatID, userID, repository.AuditFilter{UserID: targetID}, 1)
+}
+
+func (h *Handler) handleChatGroupNameInput(ctx context.Context, text string, userID int64) {
+	group, err := h.svc.CreateChatGroup(ctx, userID, text)
+	if err != nil {
+		h.logger.Info("Invalid chat group name", "input", text, "error", err)
+		h.sendText(ctx, userID, fmt.Sprintf(messages.MsgChatGroupInvalid, err))
+		h.callbackHandler.HandleChatGroups(ctx, userID)
+		return
+	}
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgChatGroupCreated, group.Name))
+	h.callbackHandler.HandleChatGroup(ctx, userID, group.ID)
+}
+
 func (h *Handler) sendText(ctx context.Context, userID int64, text string) {
 	msg := maxbot.NewMessage()
 	msg.SetUser(userID)
@@ -150,6 +407,7 @@ func (h *Handler) sendText(ctx context.Context, userID int64, text string) {
 func (h *Handler) sendMainMenu(ctx context.Context, userID int64) {
 	kb := h.bot.Messages.NewKeyboardBuilder()
 	kb.AddRow().AddCallback(messages.BtnMyGroups, schemes.DEFAULT, "my_groups")
+	kb.AddRow().AddCallback(messages.BtnChatGroups, schemes.DEFAULT, "cgroups")
 	kb.AddRow().AddCallback(messages.BtnAddGroup, schemes.POSITIVE, "add_group")
 
 	msg := maxbot.NewMessage()
@@ -162,12 +420,65 @@ func (h *Handler) sendMainMenu(ctx context.Context, userID int64) {
 	}
 }
 
-func (h *Handler) handleFileImport(ctx context.Context, userID, chatID int64, rawAttachments []json.RawMessage) {
-	if len(rawAttachments) == 0 {
+func importFileName(fileName, fileURL string) string {
+	if fileName != "" {
+		return fileName
+	}
+	if u, err := url.Parse(fileURL); err == nil {
+		return path.Base(u.Path)
+	}
+	return ""
+}
+
+func (h *Handler) handleFileImport(ctx context.Context, userID, chatID int64, rawAttachments []json.RawMessage, defaultList string) {
+	fileURL, fileName := findFileAttachment(rawAttachments)
+	if fileURL == "" {
 		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportFileRequired)
 		return
 	}
 
+	name := importFileName(fileName, fileURL)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".txt", ".csv", ".zip":
+	default:
+		h.logger.Warn("Import blocked: unsupported file type", "url", fileURL, "name", name)
+		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportFileRequired)
+		return
+	}
+
+	data, err := downloadFile(ctx, fileURL, service.MaxImportSize)
+	if err != nil {
+		h.logger.Error("Failed to download file", "url", fileURL, "error", err)
+		h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgImportError, err))
+		return
+	}
+
+	imp, err := service.ParseBlocklistImport(name, data, defaultList)
+	if err != nil {
+		h.logger.Warn("Failed to parse import file", "name", name, "error", err)
+		h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgImportError, err))
+		return
+	}
+	if len(imp.Entries) == 0 {
+		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportEmpty)
+		return
+	}
+
+	payload, err := json.Marshal(imp)
+	if err != nil {
+		h.logger.Error("Failed to encode pending import", "error", err)
+		h.sendTextWithBack(ctx, userID, chatID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	if err := h.userStateRepo.SetStateWithPayload(userID, chatID, callbacks.ActionConfirmImport, string(payload)); err != nil {
+		h.logger.Error("Failed to store pending import", "error", err)
+		h.sendTextWithBack(ctx, userID, chatID, messages.MsgSettingsUpdateFailed)
+		return
+	}
+	h.callbackHandler.HandleImportPreview(ctx, chatID, userID, service.ImportModeMerge)
+}
+
+func findFileAttachment(rawAttachments []json.RawMessage) (string, string) {
 	var fileURL string
 	var fileName string
 	for _, raw := range rawAttachments {
@@ -206,83 +517,7 @@ func (h *Handler) handleFileImport(ctx context.Context, userID, chatID int64, ra
 			}
 		}
 	}
-
-	if fileURL == "" {
-		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportFileRequired)
-		return
-	}
-
-	ext := strings.ToLower(filepath.Ext(fileName))
-	if ext == "" {
-		if u, err := url.Parse(fileURL); err == nil {
-			ext = strings.ToLower(filepath.Ext(u.Path))
-		}
-	}
-
-	resp, err := http.Get(fileURL)
-	if err != nil {
-		h.logger.Error("Failed to download file", "url", fileURL, "error", err)
-		h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgImportError, err))
-		return
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			h.logger.Warn("Failed to close response body", "error", err)
-		}
-	}()
-
-	peekBuf := bufio.NewReader(resp.Body)
-	sniff, _ := peekBuf.Peek(512)
-	contentType := http.DetectContentType(sniff)
-	headerType := resp.Header.Get("Content-Type")
-
-	isTxtExtension := ext == ".txt"
-	isTxtContent := strings.HasPrefix(contentType, "text/plain") || strings.HasPrefix(headerType, "text/plain")
-
-	if !isTxtContent {
-		h.logger.Warn("Import blocked: content is not text", "url", fileURL, "content_type", contentType, "header_type", headerType)
-		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportFileRequired)
-		return
-	}
-
-	if !isTxtExtension {
-		h.logger.Warn("Import blocked: strictly .txt required", "url", fileURL, "ext", ext)
-		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportFileRequired)
-		return
-	}
-
-	scanner := bufio.NewScanner(peekBuf)
-	words, skippedCount, err := parseWordsFile(scanner)
-	if err != nil {
-		h.logger.Error("Error scanning file", "error", err)
-		h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgImportError, err))
-		return
-	}
-
-	if len(words) == 0 {
-		h.sendTextWithBack(ctx, userID, chatID, messages.MsgImportEmpty)
-		return
-	}
-
-	if err := h.svc.AddBlockedWords(ctx, chatID, words); err != nil {
-		h.logger.Error("Failed to save imported words", "error", err)
-		h.sendTextWithBack(ctx, userID, chatID, messages.MsgSettingsUpdateFailed)
-		return
-	}
-
-	if err := h.userStateRepo.ClearState(userID); err != nil {
-		h.logger.Warn("Failed to clear state after successful import", "error", err)
-	}
-
-	var msgText string
-	if skippedCount > 0 {
-		msgText = fmt.Sprintf(messages.MsgImportPartialSuccess, len(words), skippedCount)
-	} else {
-		msgText = fmt.Sprintf(messages.MsgImportSuccess, len(words))
-	}
-
-	h.sendText(ctx, userID, msgText)
-	h.callbackHandler.HandleManageGroup(ctx, chatID, userID)
+	return fileURL, fileName
 }
 
 func (h *Handler) sendTextWithBack(ctx context.Context, userID, chatID int64, text string) {
@@ -299,27 +534,6 @@ func (h *Handler) sendTextWithBack(ctx context.Context, userID, chatID int64, te
 	}
 }
 
-func parseWordsFile(scanner *bufio.Scanner) ([]string, int, error) {
-	var words []string
-	var skippedCount int
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-		if strings.Contains(line, " ") {
-			skippedCount++
-			continue
-		}
-		words = append(words, line)
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, 0, err
-	}
-	return words, skippedCount, nil
-}
-
 func (h *Handler) handleBroadcast(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
 	userID := upd.Message.Sender.UserId
 
@@ -459,4 +673,4 @@ func (h *Handler) handleBroadcast(ctx context.Context, upd *schemes.MessageCreat
 	} else {
 		h.sendText(ctx, userID, fmt.Sprintf("✅ Рассылка отправлена в %d чат(ов)", len(targetChatIDs)))
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const reportMuteDuration = time.Hour
+
+var reportDecisions = map[string]string{
+	"rpd": repository.ReportStatusDeleted,
+	"rpw": repository.ReportStatusWarned,
+	"rpm": repository.ReportStatusMuted,
+	"rpb": repository.ReportStatusBanned,
+	"rpx": repository.ReportStatusDismissed,
+}
+
+func reportStatusLabel(status string) string {
+	switch status {
+	case repository.ReportStatusDeleted:
+		return messages.MsgReportStatusDeleted
+	case repository.ReportStatusWarned:
+		return messages.MsgReportStatusWarned
+	case repository.ReportStatusMuted:
+		return messages.MsgReportStatusMuted
+	case repository.ReportStatusBanned:
+		return messages.MsgReportStatusBanned
+	}
+	return messages.MsgReportStatusDismissed
+}
+
+func parseReportCallback(payload string) (string, uint, bool) {
+	prefix, rest, ok := strings.Cut(payload, "_")
+	if !ok {
+		return "", 0, false
+	}
+	status, ok := reportDecisions[prefix]
+	if !ok {
+		return "", 0, false
+	}
+	id, err := strconv.ParseUint(rest, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return status, uint(id), true
+}
+
+func (h *Handler) handleReportCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	reporter := upd.Message.Sender
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "report_command_cleanup")
+
+	link := upd.Message.Link
+	if link == nil || link.Type != schemes.REPLY || link.Message.Mid == "" {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgReportUsage)
+		return
+	}
+
+	report := &repository.Report{
+		ChatID:       chatID,
+		ReporterID:   reporter.UserId,
+		ReporterName: reporter.Name,
+		TargetUserID: link.Sender.UserId,
+		TargetName:   link.Sender.Name,
+		MessageID:    link.Message.Mid,
+		Text:         link.Message.Text,
+		Reason:       strings.TrimSpace(strings.TrimPrefix(upd.Message.Body.Text, "/report")),
+	}
+	admins, err := h.svc.FileReport(ctx, report)
+	if errors.Is(err, service.ErrReportRateLimited) {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgReportRateLimited)
+		return
+	}
+	if err != nil && report.ID == 0 {
+		h.logger.Error("Failed to file report", "chat_id", chatID, "reporter_id", reporter.UserId, "error", err)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgReportFailed)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get chat admins for report", "report_id", report.ID, "error", err)
+	}
+	if len(admins) == 0 {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgReportNoAdmins)
+		return
+	}
+	metrics.IncBotAction("report")
+
+	text := h.reportNoticeText(ctx, report)
+	var noticeIDs []string
+	for _, adminID := range admins {
+		kb := h.bot.Messages.NewKeyboardBuilder()
+		kb.AddRow().
+			AddCallback(messages.BtnReportDelete, schemes.DEFAULT, fmt.Sprintf("rpd_%d", report.ID)).
+			AddCallback(messages.BtnReportWarn, schemes.DEFAULT, fmt.Sprintf("rpw_%d", report.ID))
+		kb.AddRow().
+			AddCallback(messages.BtnReportMute, schemes.NEGATIVE, fmt.Sprintf("rpm_%d", report.ID)).
+			AddCallback(messages.BtnReportBan, schemes.NEGATIVE, fmt.Sprintf("rpb_%d", report.ID))
+		kb.AddRow().AddCallback(messages.BtnReportDismiss, schemes.POSITIVE, fmt.Sprintf("rpx_%d", report.ID))
+
+		msg := maxbot.NewMessage()
+		msg.SetUser(adminID)
+		msg.SetText(text)
+		msg.SetFormat("markdown")
+		msg.AddKeyboard(kb)
+		sent, err := h.bot.Messages.SendWithResult(ctx, msg)
+		if err != nil {
+			h.logger.Error("Failed to send report to admin", "admin_id", adminID, "report_id", report.ID, "error", err)
+			continue
+		}
+		if sent != nil && sent.Body.Mid != "" {
+			noticeIDs = append(noticeIDs, sent.Body.Mid)
+		}
+	}
+	if err := h.svc.SetReportNotices(ctx, report.ID, noticeIDs); err != nil {
+		h.logger.Error("Failed to save report notices", "report_id", report.ID, "error", err)
+	}
+	h.SendAutoDeleteMessage(ctx, chatID, messages.MsgReportSent)
+}
+
+func (h *Handler) reportNoticeText(ctx context.Context, report *repository.Report) string {
+	label := strconv.FormatInt(report.ChatID, 10)
+	if chat, err := h.bot.Chats.GetChat(ctx, report.ChatID); err == nil && chat.Title != "" {
+		label = chat.Title
+	}
+	reason := report.Reason
+	if reason == "" {
+		reason = messages.MsgWarnNoReason
+	}
+	body := report.Text
+	if body == "" {
+		body = messages.MsgReportNoText
+	}
+	return fmt.Sprintf(messages.MsgReportAdminNotice,
+		report.ID,
+		label,
+		report.TargetName,
+		report.TargetUserID,
+		report.ReporterName,
+		report.ReporterID,
+		reason,
+		body,
+	)
+}
+
+func (h *Handler) handleReportDecision(ctx context.Context, adminID int64, reportID uint, status string) {
+	report, err := h.svc.ResolveReport(ctx, reportID, adminID, status)
+	if errors.Is(err, service.ErrReportResolved) {
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgReportAlreadyResolved, reportID))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to resolve report", "report_id", reportID, "admin_id", adminID, "error", err)
+		h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgReportDecisionFailed, reportID))
+		return
+	}
+
+	if status != repository.ReportStatusDismissed {
+		if err := h.applyReportDecision(ctx, report, adminID); err != nil {
+			h.logger.Error("Failed to apply report decision", "report_id", reportID, "status", status, "error", err)
+			if err := h.svc.ReopenReport(ctx, reportID, status); err != nil {
+				h.logger.Error("Failed to reopen report", "report_id", reportID, "error", err)
+			}
+			if isRoleError(err) {
+				h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgReportActionForbidden, reportID))
+			} else {
+				h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgReportActionFailed, reportID))
+			}
+			return
+		}
+	}
+
+	text := h.reportNoticeText(ctx, report) + fmt.Sprintf(messages.MsgReportResolution, reportStatusLabel(status), adminID)
+	for _, mid := range report.NoticeIDs {
+		msg := maxbot.NewMessage()
+		msg.SetText(text)
+		msg.SetFormat("markdown")
+		if err := h.bot.Messages.EditMessage(ctx, mid, msg); err != nil {
+			h.logger.Warn("Failed to update report notice", "report_id", reportID, "message_id", mid, "error", err)
+		}
+	}
+	h.sendText(ctx, adminID, fmt.Sprintf(messages.MsgReportResolvedAdmin, reportID, reportStatusLabel(status)))
+}
+
+func (h *Handler) applyReportDecision(ctx context.Context, report *repository.Report, adminID int64) error {
+	chatID := report.ChatID
+	reason := report.Reason
+	if reason == "" {
+		reason = messages.MsgReportDefaultReason
+	}
+	target := schemes.User{UserId: report.TargetUserID, Name: report.TargetName}
+
+	switch report.Status {
+	case repository.ReportStatusWarned:
+		outcome, err := h.svc.WarnUser(ctx, chatID, adminID, target.UserId, reason)
+		if err != nil {
+			return err
+		}
+		metrics.IncBotAction("warn")
+		h.applyStrikeOutcome(ctx, chatID, target, messages.MsgManualWarning, reason, outcome)
+	case repository.ReportStatusMuted:
+		if err := h.svc.MuteUser(ctx, chatID, adminID, target.UserId, target.Name, reason, reportMuteDuration); err != nil {
+			return err
+		}
+		h.SendAutoDeleteMessage(ctx, chatID, withReason(fmt.Sprintf(messages.MsgUserMuted, target.Name, utils.FormatDuration(reportMuteDuration)), reason))
+	case repository.ReportStatusBanned:
+		if err := h.svc.BanUser(ctx, chatID, adminID, target.UserId, target.Name, reason, 0); err != nil {
+			return err
+		}
+		metrics.IncBotAction("ban")
+		h.SendAutoDeleteMessage(ctx, chatID, withReason(fmt.Sprintf(messages.MsgUserBannedPermanent, target.Name), reason))
+	}
+
+	if err := h.deleteMessage(ctx, report.MessageID, "report"); err == nil {
+		h.svc.LogAction(ctx, repository.AuditEntry{
+			ChatID:       chatID,
+			ActorID:      adminID,
+			TargetUserID: report.TargetUserID,
+			Action:       repository.AuditActionDelete,
+			Filter:       "report",
+			Reason:       reason,
+			MessageID:    report.MessageID,
+		})
+	}
+	return nil
+}
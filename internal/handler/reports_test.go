@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"max-moderation-bot/internal/repository"
+	"testing"
+)
+
+func TestParseReportCallback(t *testing.T) {
+	tests := []struct {
+		payload    string
+		wantStatus string
+		wantID     uint
+		wantOK     bool
+	}{
+		{payload: "rpd_12", wantStatus: repository.ReportStatusDeleted, wantID: 12, wantOK: true},
+		{payload: "rpw_3", wantStatus: repository.ReportStatusWarned, wantID: 3, wantOK: true},
+		{payload: "rpm_4", wantStatus: repository.ReportStatusMuted, wantID: 4, wantOK: true},
+		{payload: "rpb_5", wantStatus: repository.ReportStatusBanned, wantID: 5, wantOK: true},
+		{payload: "rpx_6", wantStatus: repository.ReportStatusDismissed, wantID: 6, wantOK: true},
+		{payload: "rpd_abc"},
+		{payload: "rpq_1"},
+		{payload: "manage_100"},
+		{payload: "rpd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			status, id, ok := parseReportCallback(tt.payload)
+			if ok != tt.wantOK || status != tt.wantStatus || id != tt.wantID {
+				t.Errorf("parseReportCallback(%q) = (%q, %d, %v), want (%q, %d, %v)", tt.payload, status, id, ok, tt.wantStatus, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
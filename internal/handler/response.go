@@ -5,19 +5,27 @@ import (
 	"fmt"
 	"max-moderation-bot/internal/messages"
 	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
 	"time"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
 	"github.com/max-messenger/max-bot-api-client-go/schemes"
 )
 
-func (h *Handler) sendWarningWithMention(ctx context.Context, chatID int64, user schemes.User, reason string) {
+func mentionLink(user schemes.User) string {
 	name := user.Name
 	if name == "" {
 		name = "User"
 	}
-	userLink := fmt.Sprintf("[%s](max://max.ru/%%%d%%)", name, user.UserId)
-	text := fmt.Sprintf(messages.MsgProhibitedContent, userLink, reason)
+	return fmt.Sprintf("[%s](max://max.ru/%%%d%%)", name, user.UserId)
+}
+
+func (h *Handler) sendWarningWithMention(ctx context.Context, chatID int64, user schemes.User, reason string) {
+	h.sendMentionNotice(ctx, chatID, user, messages.MsgProhibitedContent, reason)
+}
+
+func (h *Handler) sendMentionNotice(ctx context.Context, chatID int64, user schemes.User, format, reason string) {
+	text := fmt.Sprintf(format, mentionLink(user), reason)
 	msg := maxbot.NewMessage()
 	msg.SetChat(chatID)
 	msg.SetText(text)
@@ -46,6 +54,38 @@ func (h *Handler) deleteMessage(ctx context.Context, messageID string, reason st
 	return nil
 }
 
+func (h *Handler) deleteFilteredMessage(ctx context.Context, message schemes.Message, filter, rule, reason string) {
+	if err := h.deleteMessage(ctx, message.Body.Mid, filter); err != nil {
+		return
+	}
+	entry := repository.AuditEntry{
+		ChatID:       message.Recipient.ChatId,
+		TargetUserID: message.Sender.UserId,
+		Action:       repository.AuditActionDelete,
+		Filter:       filter,
+		Rule:         rule,
+		Reason:       reason,
+		MessageID:    message.Body.Mid,
+	}
+	entry.ID = h.svc.LogAction(ctx, entry)
+	archived := repository.ArchivedMessage{
+		ChatID:      message.Recipient.ChatId,
+		UserID:      message.Sender.UserId,
+		UserName:    message.Sender.Name,
+		MessageID:   message.Body.Mid,
+		Text:        message.Body.Text,
+		Attachments: attachmentTokens(message.Body.RawAttachments),
+		Filter:      filter,
+		Rule:        rule,
+		AuditID:     entry.ID,
+		Reason:      reason,
+	}
+	if err := h.svc.ArchiveMessage(ctx, archived); err != nil {
+		h.logger.Error("Failed to archive deleted message", "message_id", message.Body.Mid, "error", err)
+	}
+	h.svc.NotifyDeletion(ctx, entry, message.Sender.Name)
+}
+
 func (h *Handler) SendTemporaryMessage(ctx context.Context, chatID int64, text string, duration time.Duration) {
 	msg := maxbot.NewMessage()
 	msg.SetChat(chatID)
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/service"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const maxSnapshotImportSize = 5 << 20
+
+func snapshotFormatFromExt(ext string) (string, bool) {
+	switch ext {
+	case ".json":
+		return service.SnapshotFormatJSON, true
+	case ".yaml", ".yml":
+		return service.SnapshotFormatYAML, true
+	}
+	return "", false
+}
+
+func (h *Handler) handleSnapshotImport(ctx context.Context, userID, chatID int64, text string, rawAttachments []json.RawMessage) {
+	var data []byte
+	var format string
+	if len(rawAttachments) > 0 {
+		fileURL, fileName := findFileAttachment(rawAttachments)
+		if fileURL == "" {
+			h.sendTextWithBack(ctx, userID, chatID, messages.MsgSnapshotFileRequired)
+			return
+		}
+		ext := strings.ToLower(filepath.Ext(fileName))
+		if ext == "" {
+			if u, err := url.Parse(fileURL); err == nil {
+				ext = strings.ToLower(filepath.Ext(u.Path))
+			}
+		}
+		var ok bool
+		if format, ok = snapshotFormatFromExt(ext); !ok {
+			h.sendTextWithBack(ctx, userID, chatID, messages.MsgSnapshotFileRequired)
+			return
+		}
+		body, err := downloadFile(ctx, fileURL, maxSnapshotImportSize)
+		if err != nil {
+			h.logger.Error("Failed to download file", "url", fileURL, "error", err)
+			h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgSnapshotImportError, err))
+			return
+		}
+		data = body
+	} else {
+		if text == "" {
+			h.sendTextWithBack(ctx, userID, chatID, messages.MsgSnapshotFileRequired)
+			return
+		}
+		data = []byte(text)
+	}
+
+	snap, err := h.svc.ImportSettingsSnapshot(ctx, chatID, userID, data, format)
+	if err != nil {
+		h.logger.Warn("Failed to import settings snapshot", "chat_id", chatID, "user_id", userID, "error", err)
+		h.sendTextWithBack(ctx, userID, chatID, fmt.Sprintf(messages.MsgSnapshotImportError, err))
+		return
+	}
+	if err := h.userStateRepo.ClearState(userID); err != nil {
+		h.logger.Warn("Failed to clear state after snapshot import", "error", err)
+	}
+	h.logger.Info("Settings snapshot imported", "chat_id", chatID, "user_id", userID, "version", snap.Version)
+	h.sendText(ctx, userID, fmt.Sprintf(messages.MsgSnapshotImported, snap.Version, len(snap.BlockedWords), len(snap.BlockedDomains), len(snap.Schedule), len(snap.StrikeLadder), len(snap.TrustedUsers)))
+	h.callbackHandler.HandleManageGroup(ctx, chatID, userID)
+}
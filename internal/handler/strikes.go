@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/handler/callbacks"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/service"
+	"max-moderation-bot/internal/utils"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func strikeSummary(outcome *service.StrikeOutcome) string {
+	summary := fmt.Sprintf(messages.MsgStrikeCount, outcome.Strikes)
+	if outcome.Next == nil {
+		return summary
+	}
+	return fmt.Sprintf(messages.MsgStrikeNext, summary, callbacks.StrikeStepLabel(outcome.Next.Strikes, outcome.Next.Action, time.Duration(outcome.Next.DurationSeconds)*time.Second))
+}
+
+func (h *Handler) applyStrikeOutcome(ctx context.Context, chatID int64, user schemes.User, format, reason string, outcome *service.StrikeOutcome) {
+	switch outcome.Action {
+	case service.StrikeActionMute:
+		h.logger.Info("Muting user for persistent violations", "user_id", user.UserId, "strikes", outcome.Strikes, "duration", outcome.Duration)
+		if err := h.svc.SystemMuteUser(ctx, chatID, user.UserId, user.Name, reason, service.SanctionSourceStrikeLadder, outcome.Duration); err != nil {
+			h.logger.Error("Failed to system mute user", "error", err)
+		}
+		muted := fmt.Sprintf(messages.MsgStrikeMuted, reason, utils.FormatDuration(outcome.Duration))
+		h.sendMentionNotice(ctx, chatID, user, format, fmt.Sprintf(messages.MsgStrikeReason, muted, strikeSummary(outcome)))
+	case service.StrikeActionKick:
+		h.logger.Info("Kicking user for persistent violations", "user_id", user.UserId, "strikes", outcome.Strikes)
+		if err := h.svc.KickUser(ctx, chatID, 0, user.UserId, user.Name, reason, service.SanctionSourceStrikeLadder); err != nil {
+			h.logger.Error("Failed to kick user", "error", err)
+			h.sendMentionNotice(ctx, chatID, user, format, fmt.Sprintf(messages.MsgStrikeReason, reason, strikeSummary(outcome)))
+			return
+		}
+		h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgStrikeKicked, mentionLink(user), outcome.Strikes))
+	default:
+		h.sendMentionNotice(ctx, chatID, user, format, fmt.Sprintf(messages.MsgStrikeReason, reason, strikeSummary(outcome)))
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/service"
+	"strings"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func (h *Handler) checkCommandAdmin(ctx context.Context, upd *schemes.MessageCreatedUpdate, command string) bool {
+	chatID := upd.Message.Recipient.ChatId
+	adminID := upd.Message.Sender.UserId
+	isAdmin, err := h.svc.IsChatAdmin(ctx, chatID, adminID)
+	if err != nil {
+		h.logger.Error("Failed to check real-time admin status", "command", command, "error", err)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgAdminCheckFailed)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "failed_admin_check_"+command+"_cleanup")
+		return false
+	}
+	if !isAdmin {
+		h.logger.Info("Non-admin user tried to use moderation command", "command", command, "user_id", adminID)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "non_admin_"+command+"_cleanup")
+		return false
+	}
+	return true
+}
+
+func isRoleError(err error) bool {
+	return errors.Is(err, service.ErrInsufficientRole) || errors.Is(err, service.ErrNotBotAdmin)
+}
+
+func (h *Handler) handleWarnCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, args := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.logger.Info("Warn command used without target")
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_warn_command_cleanup")
+		return
+	}
+	if !h.checkCommandAdmin(ctx, upd, "warn") {
+		return
+	}
+
+	reason := strings.Join(args, " ")
+	outcome, err := h.svc.WarnUser(ctx, chatID, upd.Message.Sender.UserId, target.UserId, reason)
+	if err != nil {
+		h.logger.Error("Failed to warn user", "user_id", target.UserId, "error", err)
+		if isRoleError(err) {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
+		} else {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnFailed)
+		}
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "warn_command_cleanup")
+		return
+	}
+	metrics.IncBotAction("warn")
+
+	if reason == "" {
+		reason = messages.MsgWarnNoReason
+	}
+	h.applyStrikeOutcome(ctx, chatID, *target, messages.MsgManualWarning, reason, outcome)
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "warn_command_cleanup")
+}
+
+func (h *Handler) handleWarnsCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, _ := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnsCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_warns_command_cleanup")
+		return
+	}
+	if !h.checkCommandAdmin(ctx, upd, "warns") {
+		return
+	}
+
+	warnings, err := h.svc.GetActiveWarnings(ctx, chatID, target.UserId)
+	if err != nil {
+		h.logger.Error("Failed to get warnings", "user_id", target.UserId, "error", err)
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnsFailed)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "warns_command_cleanup")
+		return
+	}
+
+	text := fmt.Sprintf(messages.MsgNoActiveWarns, target.Name)
+	if len(warnings) > 0 {
+		lines := []string{fmt.Sprintf(messages.MsgWarnsListTitle, target.Name, len(warnings))}
+		for i, w := range warnings {
+			reason := w.Reason
+			if reason == "" {
+				reason = messages.MsgWarnNoReason
+			}
+			lines = append(lines, fmt.Sprintf(messages.MsgWarnsListLine, i+1, w.CreatedAt.Format("02.01.2006 15:04"), reason))
+		}
+		text = strings.Join(lines, "\n")
+	}
+	h.SendAutoDeleteMessage(ctx, chatID, text)
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "warns_command_cleanup")
+}
+
+func (h *Handler) handleUnwarnCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, _ := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnsCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_unwarn_command_cleanup")
+		return
+	}
+	if !h.checkCommandAdmin(ctx, upd, "unwarn") {
+		return
+	}
+
+	removed, err := h.svc.RemoveLatestWarning(ctx, chatID, upd.Message.Sender.UserId, target.UserId)
+	if err != nil {
+		h.logger.Error("Failed to remove warning", "user_id", target.UserId, "error", err)
+		if isRoleError(err) {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
+		} else {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgUnwarnFailed)
+		}
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "unwarn_command_cleanup")
+		return
+	}
+	if removed == nil {
+		h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgUnwarnNothing, target.Name))
+	} else {
+		metrics.IncBotAction("unwarn")
+		h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgUnwarnSuccess, target.Name))
+	}
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "unwarn_command_cleanup")
+}
+
+func (h *Handler) handleForgiveCommand(ctx context.Context, upd *schemes.MessageCreatedUpdate) {
+	chatID := upd.Message.Recipient.ChatId
+	target, _ := h.commandTarget(ctx, upd)
+	if target == nil {
+		h.SendAutoDeleteMessage(ctx, chatID, messages.MsgWarnsCommandInvalid)
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "invalid_forgive_command_cleanup")
+		return
+	}
+	if !h.checkCommandAdmin(ctx, upd, "forgive") {
+		return
+	}
+
+	forgiven, err := h.svc.ForgiveUser(ctx, chatID, upd.Message.Sender.UserId, target.UserId)
+	if err != nil {
+		h.logger.Error("Failed to forgive user", "user_id", target.UserId, "error", err)
+		if isRoleError(err) {
+			h.SendAutoDeleteMessage(ctx, chatID, messages.MsgMuteAdminError)
+		}
+		_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "forgive_command_cleanup")
+		return
+	}
+	if forgiven == 0 {
+		h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgForgiveNothing, target.Name))
+	} else {
+		metrics.IncBotAction("forgive")
+		h.SendAutoDeleteMessage(ctx, chatID, fmt.Sprintf(messages.MsgForgiveSuccess, target.Name, forgiven))
+	}
+	_ = h.deleteMessage(ctx, upd.Message.Body.Mid, "forgive_command_cleanup")
+}
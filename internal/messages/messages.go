@@ -1,73 +1,530 @@
 package messages
 
 const (
-	MsgTokenGenerated            = "Сгенерирован токен: `%s`\n\n1. Добавьте меня в чат.\n2. **Сделайте меня администратором**.\n3. Отправьте эту команду в чате:\n`/link %s`"
-	MsgLinkCommandInvalid        = "Неверный формат команды `/link`. Используйте: `/link <token>`"
-	MsgLinkAdminError            = "⚠️ Я не могу удалять сообщения. Пожалуйста, **сделайте меня администратором** с правом 'Удаление сообщений' и попробуйте снова."
-	MsgLinkGroupFail             = "❌ Не удалось привязать чат: %v"
-	MsgLinkUserNotAdmin          = "⚠️ Только администраторы чата могут выполнять привязку к боту."
-	MsgLinkUserNotOwner          = "⚠️ Только владелец чата может выполнять привязку к боту."
-	MsgGroupLinkedSuccess        = "✅ Чат успешно привязан! Теперь вы можете управлять настройками в личных сообщениях."
-	MsgNoManagedGroups           = "У вас пока нет связанных с ботом групповых чатов."
-	MsgGroupListTitle            = "Выберите чат для управления (страница %d/%d):"
-	MsgFailedToLoadSettings      = "Не удалось загрузить настройки."
-	MsgSettingsForGroup          = "Настройки для чата **%s**:"
-	MsgPromptAddWords            = "Пожалуйста, введите **слова** для блокировки в чате %s, через запятую (текущие/например: `%s`)."
-	MsgPromptAddDomains          = "Пожалуйста, введите **домены** для блокировки в чате %s, через запятую (текущие/например: `%s`)."
-	MsgOnlyTextSupported         = "Пожалуйста, присылайте только текст. Фото и медиа не поддерживаются."
-	MsgNoValidItems              = "Не найдены валидные элементы. Пожалуйста, попробуйте снова через меню."
-	MsgUnknownAction             = "Неизвестное действие."
-	MsgSettingsUpdateFailed      = "Не удалось обновить настройки. Пожалуйста, попробуйте снова."
-	MsgSettingsUpdated           = "Успешно! %s (%d элементов)"
-	MsgAddedBlockedWords         = "Добавлены заблокированные слова."
-	MsgAddedBlockedDomains       = "Добавлены заблокированные домены."
-	MsgMainMenu                  = "Здравствуйте! Это бот модерации групповых чатов. Для работы с ботом выберите необходимую кнопку:"
-	MsgProhibitedContent         = "%s, обнаружен запрещенный контент: %s"
-	MsgReasonProhibitedWord      = "недопустимое слово"
-	MsgReasonProhibitedDomain    = "недопустимая ссылка"
-	MsgReasonImageRestricted     = "изображения запрещены"
-	MsgReasonVideoRestricted     = "видео запрещены"
-	MsgReasonAudioRestricted     = "аудио запрещено"
-	MsgReasonFileRestricted      = "файлы запрещены"
-	MsgReasonPersistentViolation = "Множественные нарушения правил"
-	MsgReasonRateLimit           = "превышен лимит сообщений"
-	MsgReasonUserMuted           = "Пользователь заглушен до %s"
-	MsgGroupDefaultLabel         = "Чат %d"
-	BtnAddGroup                  = "Добавить чат"
-	BtnMyGroups                  = "Мои чаты"
-	BtnBack                      = "🔙 Назад"
-	BtnWordFilter                = "Фильтр слов: %s"
-	BtnLinkFilter                = "Фильтр ссылок: %s"
-	BtnAutoDelete                = "Автоудаление сообщений: %s"
-	BtnRestrictImage             = "Фильтр изображений: %s"
-	BtnRestrictVideo             = "Фильтр видео: %s"
-	BtnRestrictAudio             = "Фильтр аудио: %s"
-	BtnRestrictFile              = "Фильтр файлов: %s"
-	BtnAddWords                  = "Добавить слова"
-	BtnClearWords                = "🗑 Сбросить список слов"
-	BtnAddDomains                = "Добавить домены"
-	BtnClearDomains              = "🗑 Сбросить список доменов"
-	MsgWordsCleared              = "Список запрещенных слов очищен."
-	MsgDomainsCleared            = "Список запрещенных доменов очищен."
-	MsgUserMuted                 = "Пользователь %s заблокирован на %s."
-	MsgMuteCommandInvalid        = "Используйте эту команду в ответ на сообщение пользователя. Вы можете указать время (напр. `/mute 1h`)."
-	MsgMuteDurationInvalid       = "Неверный формат времени. Используйте: 30m, 1h. По умолчанию: 30m."
-	MsgMuteAdminError            = "⚠️ Только администраторы бота могут использовать эту команду."
-	MsgMuteListTitle             = "Список активных мутов в чате **%s** (страница %d/%d):"
-	MsgNoActiveMutes             = "В этом чате нет активных мутов."
-	MsgMuteDetail                = "Информация о муте в чате **%s**:\n\n👤 **Пользователь**: %s (ID: %d)\n⏳ **Заблокирован до**: %s"
-	MsgUnmutedSuccess            = "Пользователь %d разблокирован."
-	BtnMutesManagement           = "🔇 Управление мутами"
-	BtnUnmute                    = "🔈 Разблокировать"
-	BtnNextPage                  = "Вперед ➡️"
-	BtnPrevPage                  = "⬅️ Назад"
-	BtnStatistics                = "📊 Статистика"
-	MsgChatStatistics            = "📊 Статистика чата **%s** (ID: %d)\n_на %s_:\n\nНарушения:\n— по словам: %s\n— по ссылкам: %s\n— по изображениям: %s\n— по видео: %s\n— по аудио: %s\n— по файлам: %s\n\nАктивные муты: %s"
-	BtnImportWords               = "📥 Импорт из TXT"
-	MsgPromptImportWords         = "Пожалуйста, отправьте **.txt файл** со списком слов (каждое слово с новой строки) для чата %s.\n⚠️ В одной строке не должно быть пробелов."
-	MsgImportFileRequired        = "Пожалуйста, отправьте **.txt** файл. Другие форматы не поддерживаются."
-	MsgImportSuccess             = "✅ Успешно добавлено слов: %d."
-	MsgImportPartialSuccess      = "✅ Успешно добавлено слов: %d.\n⚠️ Пропущено строк (с ошибками/пробелами): %d."
-	MsgImportEmpty               = "⚠️ Не найдено валидных слов в файле."
-	MsgImportError               = "❌ Ошибка при чтении файла: %v"
+	MsgTokenGenerated             = "Сгенерирован токен: `%s`\n\n1. Добавьте меня в чат.\n2. **Сделайте меня администратором**.\n3. Отправьте эту команду в чате:\n`/link %s`"
+	MsgLinkCommandInvalid         = "Неверный формат команды `/link`. Используйте: `/link <token>`"
+	MsgLinkAdminError             = "⚠️ Я не могу удалять сообщения. Пожалуйста, **сделайте меня администратором** с правом 'Удаление сообщений' и попробуйте снова."
+	MsgLinkGroupFail              = "❌ Не удалось привязать чат: %v"
+	MsgLinkUserNotAdmin           = "⚠️ Только администраторы чата могут выполнять привязку к боту."
+	MsgLinkUserNotOwner           = "⚠️ Только владелец чата может выполнять привязку к боту."
+	MsgGroupLinkedSuccess         = "✅ Чат успешно привязан! Теперь вы можете управлять настройками в личных сообщениях."
+	MsgNoManagedGroups            = "У вас пока нет связанных с ботом групповых чатов."
+	MsgGroupListTitle             = "Выберите чат для управления (страница %d/%d):"
+	MsgFailedToLoadSettings       = "Не удалось загрузить настройки."
+	MsgSettingsForGroup           = "Настройки для чата **%s**:"
+	MsgPromptAddWords             = "Пожалуйста, введите **слова** для блокировки в чате %s, через запятую (текущие/например: `%s`)."
+	MsgPromptAddDomains           = "Пожалуйста, введите **домены** для блокировки в чате %s, через запятую (текущие/например: `%s`)."
+	MsgOnlyTextSupported          = "Пожалуйста, присылайте только текст. Фото и медиа не поддерживаются."
+	MsgNoValidItems               = "Не найдены валидные элементы. Пожалуйста, попробуйте снова через меню."
+	MsgUnknownAction              = "Неизвестное действие."
+	MsgSettingsUpdateFailed       = "Не удалось обновить настройки. Пожалуйста, попробуйте снова."
+	MsgSettingsUpdated            = "Успешно! %s (%d элементов)"
+	MsgAddedBlockedWords          = "Добавлены заблокированные слова."
+	MsgAddedBlockedDomains        = "Добавлены заблокированные домены."
+	MsgMainMenu                   = "Здравствуйте! Это бот модерации групповых чатов. Для работы с ботом выберите необходимую кнопку:"
+	MsgProhibitedContent          = "%s, обнаружен запрещенный контент: %s"
+	MsgReasonProhibitedWord       = "недопустимое слово"
+	MsgReasonProhibitedDomain     = "недопустимая ссылка"
+	MsgReasonImageRestricted      = "изображения запрещены"
+	MsgReasonVideoRestricted      = "видео запрещены"
+	MsgReasonAudioRestricted      = "аудио запрещено"
+	MsgReasonFileRestricted       = "файлы запрещены"
+	MsgReasonPersistentViolation  = "Множественные нарушения правил"
+	MsgReasonRateLimit            = "превышен лимит сообщений"
+	MsgReasonUserMuted            = "Пользователь заглушен до %s"
+	MsgGroupDefaultLabel          = "Чат %d"
+	BtnAddGroup                   = "Добавить чат"
+	BtnMyGroups                   = "Мои чаты"
+	BtnChatGroups                 = "🔗 Группы чатов"
+	BtnBack                       = "🔙 Назад"
+	BtnWordFilter                 = "Фильтр слов: %s"
+	BtnLinkFilter                 = "Фильтр ссылок: %s"
+	BtnAutoDelete                 = "Автоудаление сообщений: %s"
+	BtnRestrictImage              = "Фильтр изображений: %s"
+	BtnRestrictVideo              = "Фильтр видео: %s"
+	BtnRestrictAudio              = "Фильтр аудио: %s"
+	BtnRestrictFile               = "Фильтр файлов: %s"
+	BtnAddWords                   = "Добавить слова"
+	BtnClearWords                 = "🗑 Сбросить список слов"
+	BtnAddDomains                 = "Добавить домены"
+	BtnClearDomains               = "🗑 Сбросить список доменов"
+	MsgWordsCleared               = "Список запрещенных слов очищен."
+	MsgDomainsCleared             = "Список запрещенных доменов очищен."
+	MsgUserMuted                  = "Пользователь %s заблокирован на %s."
+	MsgUserMutedForever           = "Пользователь %s заблокирован навсегда."
+	MsgMuteCommandInvalid         = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя. Вы можете указать время и причину (напр. `/mute @user 1h флуд`)."
+	MsgMuteDurationInvalid        = "Неверный формат времени. Примеры: `30m`, `1h30m`, `1d`, `2w`, `30мин`, `1ч30м`, `навсегда`. По умолчанию: 30m."
+	MsgMuteAdminError             = "⚠️ Только администраторы бота могут использовать эту команду."
+	MsgAdminCheckFailed           = "❌ Не удалось проверить права администратора. Попробуйте позже."
+	MsgMuteListTitle              = "Список активных мутов в чате **%s** (страница %d/%d):"
+	MsgNoActiveMutes              = "В этом чате нет активных мутов."
+	MsgMuteDetail                 = "Информация о муте в чате **%s**:\n\n👤 **Пользователь**: %s (ID: %d)\n⏳ **Заблокирован до**: %s\n📝 **Причина**: %s"
+	MsgUnmutedSuccess             = "Пользователь %d разблокирован."
+	BtnMutesManagement            = "🔇 Управление мутами"
+	BtnUnmute                     = "🔈 Разблокировать"
+	BtnNextPage                   = "Вперед ➡️"
+	BtnPrevPage                   = "⬅️ Назад"
+	BtnStatistics                 = "📊 Статистика"
+	MsgChatStatistics             = "📊 Статистика чата **%s** (ID: %d)\n_на %s_:\n\nНарушения:\n— по словам: %s\n— по ссылкам: %s\n— по изображениям: %s\n— по видео: %s\n— по аудио: %s\n— по файлам: %s\n\nАктивные муты: %s"
+	BtnImportWords                = "📥 Импорт слов и фраз"
+	MsgPromptImportWords          = "Отправьте файл со словами и фразами для чата %s.\nПоддерживаются:\n• **.txt** — по одной записи в строке, фразы с пробелами допустимы, строки с `#` — комментарии;\n• **.csv** — `шаблон,режим,важность`, где режим `contains`/`word`/`exact`, важность `low`/`medium`/`high` или число 1–10;\n• **.zip** — архив с такими файлами (файлы со словом `domains` в имени попадут в список доменов).\nПеред применением будет показан предпросмотр изменений."
+	MsgImportFileRequired         = "Пожалуйста, отправьте файл **.txt**, **.csv** или **.zip**. Другие форматы не поддерживаются."
+	MsgImportEmpty                = "⚠️ Не найдено валидных записей в файле."
+	MsgImportError                = "❌ Ошибка при чтении файла: %v"
+	MsgReasonReadOnly             = "в чате действует режим только для чтения"
+	MsgQuietHoursStarted          = "🌙 Начались тихие часы. Действуют ограничения по расписанию чата."
+	MsgQuietHoursEnded            = "☀️ Тихие часы закончились. Ограничения по расписанию сняты."
+	BtnSchedule                   = "🕒 Расписание"
+	BtnAddScheduleRule            = "➕ Добавить правило"
+	BtnSetTimezone                = "🌍 Часовой пояс"
+	BtnScheduleNotices            = "Уведомления о тихих часах: %s"
+	BtnDeleteScheduleRule         = "🗑 %s"
+	MsgScheduleTitle              = "Расписание ограничений для чата **%s**\nЧасовой пояс: `%s`\n\n%s"
+	MsgScheduleEmpty              = "Правил пока нет."
+	MsgPromptAddScheduleRule      = "Введите правило расписания для чата %s в формате:\n`<дни> <ЧЧ:ММ-ЧЧ:ММ> <ограничения>`\n\nДни: `пн-пт`, `сб,вс`, `ежедневно`.\nОграничения: `слова`, `ссылки`, `изображения`, `видео`, `аудио`, `файлы`, `медиа`, `чтение`.\n\nНапример: `ежедневно 23:00-08:00 медиа` или `сб,вс 00:00-24:00 чтение`."
+	MsgPromptSetTimezone          = "Введите часовой пояс для чата %s (текущий: `%s`), например `Europe/Moscow` или `UTC+3`."
+	MsgScheduleRuleAdded          = "✅ Правило добавлено: %s"
+	MsgScheduleRuleInvalid        = "❌ Не удалось разобрать правило: %v"
+	MsgTimezoneUpdated            = "✅ Часовой пояс установлен: %s"
+	MsgTimezoneInvalid            = "❌ Неизвестный часовой пояс: %s"
+	MsgReasonSlowMode             = "в чате включен медленный режим, следующее сообщение можно отправить через %d сек."
+	MsgReasonSuspect              = "вы замечены в спаме в связанном чате, ссылки и вложения временно недоступны."
+	MsgNoticeWithMention          = "%s, %s"
+	BtnSlowMode                   = "🐢 Медленный режим: %s"
+	MsgSlowModeOff                = "выкл"
+	MsgSlowModeInterval           = "%d сек."
+	MsgPromptSetSlowMode          = "Введите интервал медленного режима для чата %s в секундах (текущий: %s). Каждый участник сможет отправлять не более одного сообщения за этот интервал. Введите `0`, чтобы выключить."
+	MsgSlowModeUpdated            = "✅ Медленный режим: %s"
+	MsgSlowModeInvalid            = "❌ Неверный интервал. Введите целое число секунд, например `30`."
+	MsgStrikeCount                = "нарушение №%d"
+	MsgStrikeNext                 = "%s, далее %s"
+	MsgStrikeReason               = "%s (%s)"
+	MsgStrikeMuted                = "%s, мут на %s"
+	MsgStrikeKicked               = "%s исключен из чата за многократные нарушения (нарушение №%d)."
+	MsgStrikeStepWarn             = "на %d-м — предупреждение"
+	MsgStrikeStepMute             = "на %d-м — мут на %s"
+	MsgStrikeStepKick             = "на %d-м — исключение из чата"
+	MsgKickCommandInvalid         = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя."
+	MsgBanCommandInvalid          = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя. Вы можете указать срок и причину (напр. `/ban @user 24h спам`), без срока бан бессрочный."
+	MsgBanDurationInvalid         = "Неверный формат времени. Примеры: `1h`, `1d`, `2w`, `3дня`, `навсегда`. Без срока бан бессрочный."
+	MsgKickFailed                 = "❌ Не удалось удалить пользователя из чата. Убедитесь, что у бота есть право удалять участников."
+	MsgBanFailed                  = "❌ Не удалось забанить пользователя. Убедитесь, что у бота есть право удалять участников."
+	MsgUserKicked                 = "Пользователь %s исключен из чата."
+	MsgUserBanned                 = "Пользователь %s забанен на %s."
+	MsgUserBannedPermanent        = "Пользователь %s забанен навсегда."
+	MsgBanListTitle               = "Список активных банов в чате **%s** (страница %d/%d):"
+	MsgNoActiveBans               = "В этом чате нет активных банов."
+	MsgBanDetail                  = "Информация о бане в чате **%s**:\n\n👤 **Пользователь**: %s (ID: %d)\n⏳ **Забанен до**: %s"
+	MsgBanPermanent               = "бессрочно"
+	MsgUnbannedSuccess            = "Пользователь %d разбанен."
+	BtnBansManagement             = "🚫 Управление банами"
+	BtnUnban                      = "✅ Разбанить"
+	MsgUnmuteCommandInvalid       = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя (напр. `/unmute @user`)."
+	MsgUserUnmuted                = "Пользователь %s разблокирован."
+	MsgUnmuteFailed               = "❌ Не удалось снять мут с пользователя."
+	MsgMuteFailed                 = "❌ Не удалось замутить пользователя."
+	MsgSanctionWithReason         = "%s Причина: %s"
+	MsgManualWarning              = "%s, предупреждение от модератора: %s"
+	MsgWarnNoReason               = "без указания причины"
+	MsgWarnCommandInvalid         = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя. Вы можете указать причину (напр. `/warn @user флуд`)."
+	MsgWarnsCommandInvalid        = "Используйте эту команду в ответ на сообщение пользователя или укажите упоминание/ID пользователя."
+	MsgWarnFailed                 = "❌ Не удалось выдать предупреждение."
+	MsgWarnsFailed                = "❌ Не удалось получить предупреждения пользователя."
+	MsgUnwarnFailed               = "❌ Не удалось снять предупреждение."
+	MsgWarnsListTitle             = "Активные предупреждения пользователя %s (%d):"
+	MsgWarnsListLine              = "%d. %s — %s"
+	MsgNoActiveWarns              = "У пользователя %s нет активных предупреждений."
+	MsgUnwarnSuccess              = "Последнее предупреждение пользователя %s снято."
+	MsgUnwarnNothing              = "У пользователя %s нет предупреждений."
+	BtnStrikeLadder               = "🪜 Лестница наказаний"
+	BtnAddStrikeStep              = "➕ Добавить ступень"
+	BtnSetViolationWeights        = "⚖️ Веса нарушений"
+	BtnDeleteStrikeStep           = "🗑 %s"
+	MsgStrikeLadderTitle          = "Лестница наказаний для чата **%s**:\n\n%s\n\nВеса нарушений:\n%s\n\nЗатухание: %s"
+	MsgStrikeLadderDefault        = "_Используется лестница по умолчанию._"
+	MsgStrikeStepWindow           = "%s (окно %s)"
+	MsgViolationWeightsDefault    = "все нарушения весят 1"
+	MsgViolationWeightLine        = "— %s: %d"
+	MsgPromptAddStrikeStep        = "Введите ступень лестницы для чата %s в формате:\n`<нарушение> <warn|mute|kick> [длительность мута] [окно подсчета]`\n\nНапример: `1 warn`, `3 mute 1ч 1д`, `7 kick 1нед`. Окно по умолчанию — 24h."
+	MsgPromptSetViolationWeights  = "Введите веса нарушений для чата %s через запятую в формате `тип=вес`.\nТипы: `слова`, `ссылки`, `вложения`, `предупреждения`. Вес `0` отключает подсчет нарушения.\n\nНапример: `ссылки=2, слова=1`."
+	MsgStrikeStepAdded            = "✅ Ступень добавлена: %s"
+	MsgStrikeStepInvalid          = "❌ Не удалось разобрать ступень: %v"
+	MsgViolationWeightsUpdated    = "✅ Веса нарушений обновлены."
+	MsgViolationWeightsInvalid    = "❌ Не удалось разобрать веса: %s"
+	MsgAppealMenu                 = "У вас есть активные ограничения. Выберите санкцию, чтобы подать апелляцию:"
+	MsgAppealMuteLabel            = "🔇 Мут в «%s» %s"
+	MsgAppealBanLabel             = "🚫 Бан в «%s» %s"
+	MsgPromptAppeal               = "Опишите, почему санкцию в чате **%s** стоит пересмотреть (до 2000 символов):"
+	MsgAppealSent                 = "✅ Апелляция отправлена администраторам чата. Мы сообщим вам о решении."
+	MsgAppealNoSanction           = "У вас нет активной санкции в этом чате."
+	MsgAppealAlreadyOpen          = "⏳ Апелляция по этой санкции уже на рассмотрении."
+	MsgAppealCooldown             = "⏳ Следующую апелляцию можно подать через %s."
+	MsgAppealFailed               = "❌ Не удалось подать апелляцию."
+	MsgAppealNoAdmins             = "⚠️ У чата нет администраторов бота, апелляция сохранена без уведомления."
+	MsgAppealAdminNotice          = "📨 Апелляция #%d в чате **%s**\n\n👤 **Пользователь**: %s (ID: %d)\n⚖️ **Санкция**: %s %s\n\n📝 %s"
+	MsgAppealUntil                = "до %s"
+	MsgAppealSanctionMute         = "мут"
+	MsgAppealSanctionBan          = "бан"
+	MsgAppealAlreadyResolved      = "Апелляция #%d уже рассмотрена."
+	MsgAppealDecisionFailed       = "❌ Не удалось применить решение по апелляции #%d."
+	MsgAppealApprovedAdmin        = "✅ Апелляция #%d одобрена, санкция снята."
+	MsgAppealRejectedAdmin        = "❌ Апелляция #%d отклонена."
+	MsgAppealReducedAdmin         = "➗ Апелляция #%d: санкция сокращена, новый срок — %s."
+	MsgAppealApprovedUser         = "✅ Ваша апелляция по санкции в чате **%s** одобрена, ограничение снято."
+	MsgAppealRejectedUser         = "❌ Ваша апелляция по санкции в чате **%s** отклонена."
+	MsgAppealReducedUser          = "➗ По вашей апелляции санкция в чате **%s** сокращена, новый срок — %s."
+	MsgAppealSanctionExpired      = "санкция уже истекла"
+	BtnAppealApprove              = "✅ Снять"
+	BtnAppealReject               = "❌ Отклонить"
+	BtnAppealReduce               = "➗ Сократить"
+	BtnStrikeDecay                = "⏳ Затухание нарушений"
+	MsgStrikeDecayOff             = "выкл"
+	MsgStrikeDecayInterval        = "одно нарушение за %d дн. без нарушений"
+	MsgPromptSetStrikeDecay       = "Введите число дней без нарушений для чата %s (текущее: %s). За каждый такой период пользователю прощается одно самое старое нарушение. Введите `0`, чтобы выключить."
+	MsgStrikeDecayUpdated         = "✅ Затухание нарушений: %s"
+	MsgStrikeDecayInvalid         = "❌ Неверное значение. Введите целое число дней, например `7`."
+	BtnForgiveUser                = "🕊 Простить пользователя"
+	MsgPromptForgiveUser          = "Введите ID пользователя, которому нужно простить все нарушения в чате %s:"
+	MsgForgiveInvalidID           = "❌ Неверный ID пользователя."
+	MsgForgiveSuccess             = "🕊 Нарушения пользователя %s прощены (%d)."
+	MsgForgiveNothing             = "У пользователя %s нет активных нарушений."
+	MsgChatGroupsTitle            = "Группы чатов позволяют применять муты и баны сразу во всех чатах группы. Нарушитель, замеченный в спаме в одном чате, становится подозреваемым в остальных."
+	MsgNoChatGroups               = "У вас пока нет групп чатов."
+	BtnCreateChatGroup            = "➕ Создать группу"
+	MsgPromptChatGroupName        = "Введите название новой группы чатов:"
+	MsgChatGroupCreated           = "✅ Группа «%s» создана."
+	MsgChatGroupInvalid           = "❌ Не удалось создать группу: %v"
+	MsgChatGroupDetail            = "Группа **%s**\n\nРаспространение санкций: %s\nЧаты (%d):\n%s"
+	MsgChatGroupEmpty             = "_в группе пока нет чатов_"
+	MsgChatGroupOn                = "вкл"
+	MsgChatGroupOff               = "выкл"
+	BtnChatGroupPropagate         = "📣 Распространение: %s"
+	BtnChatGroupAddChat           = "➕ Добавить чат"
+	BtnChatGroupRemoveChat        = "➖ %s"
+	BtnChatGroupDelete            = "🗑 Удалить группу"
+	MsgChatGroupPickChat          = "Выберите чат для добавления в группу **%s**. Чат может состоять только в одной группе."
+	MsgChatGroupNoChatsToAdd      = "Все ваши чаты уже в этой группе."
+	MsgChatGroupChatTaken         = "❌ Этот чат уже состоит в группе другого администратора."
+	MsgChatGroupDeleted           = "Группа удалена."
+	BtnGlobalBans                 = "Глобальный чёрный список: %s"
+	MsgGlobalBansUsage            = "🌐 **Глобальный чёрный список**\n\nЗаписей: %d\n\n`/gban <id> [причина]` — добавить\n`/gunban <id>` — удалить\n`/gimport` — импорт из CSV/JSON\n`/gexport csv` или `/gexport json` — экспорт\n\nПользователи из списка удаляются из чатов при входе и при отправке сообщений. Чат может отключить список в настройках."
+	MsgGlobalBanInvalidID         = "❌ Укажите корректный ID пользователя."
+	MsgGlobalBanAdded             = "✅ Пользователь %d добавлен в глобальный чёрный список."
+	MsgGlobalBanRemoved           = "✅ Пользователь %d удалён из глобального чёрного списка."
+	MsgGlobalBanNotListed         = "Пользователя %d нет в глобальном чёрном списке."
+	MsgGlobalBanFailed            = "❌ Не удалось обновить глобальный чёрный список."
+	MsgPromptGlobalBanImport      = "📥 Отправьте файл **.csv** или **.json** либо вставьте список текстом.\n\nCSV: `user_id,reason`\nJSON: `[{\"user_id\": 123, \"reason\": \"spam\"}]`"
+	MsgGlobalBanImportFileType    = "Поддерживаются только файлы **.csv** и **.json**."
+	MsgGlobalBanImported          = "✅ Импортировано записей: %d."
+	MsgGlobalBanImportError       = "❌ Ошибка импорта: %v"
+	MsgGlobalBanExportEmpty       = "Глобальный чёрный список пуст."
+	MsgGlobalBanExportFailed      = "❌ Не удалось выгрузить глобальный чёрный список."
+	MsgGlobalBanExportCaption     = "🌐 Глобальный чёрный список: %d записей"
+	BtnSanctionHistory            = "📜 История санкций"
+	MsgPromptSanctionHistory      = "Введите ID пользователя, чтобы посмотреть историю его санкций в чате %s:"
+	MsgSanctionHistoryTitle       = "📜 История санкций пользователя **%s** (ID: %d) в чате **%s** (стр. %d/%d):"
+	MsgSanctionHistoryEmpty       = "У пользователя %d нет санкций в чате **%s**."
+	MsgSanctionHistoryEntry       = "**%s** — %s%s\nВыдал: %s\nПричина: %s\n%s"
+	MsgSanctionTypeMute           = "🔇 мут"
+	MsgSanctionTypeBan            = "⛔ бан"
+	MsgSanctionTypeKick           = "👢 исключение"
+	MsgSanctionDuration           = " на %s"
+	MsgSanctionForever            = " навсегда"
+	MsgSanctionIssuerAdmin        = "администратор %d"
+	MsgSanctionIssuerChatGroup    = "администратор %d (группа чатов)"
+	MsgSanctionIssuerAppeal       = "администратор %d (по апелляции)"
+	MsgSanctionIssuerLadder       = "лестница наказаний"
+	MsgSanctionIssuerGlobalBan    = "глобальный чёрный список"
+	MsgSanctionIssuerFilter       = "фильтр %s"
+	MsgSanctionActiveUntil        = "Действует до %s"
+	MsgSanctionActiveForever      = "Действует бессрочно"
+	MsgSanctionEnded              = "Завершена %s: %s"
+	MsgSanctionEndUnmuted         = "мут снят"
+	MsgSanctionEndUnbanned        = "бан снят"
+	MsgSanctionEndExpired         = "срок истёк"
+	MsgSanctionEndReplaced        = "заменена новой санкцией"
+	MsgSanctionEndAppeal          = "снята по апелляции"
+	MsgSanctionEndReduced         = "сокращена по апелляции"
+	MsgSanctionEndBy              = "%s (администратор %d)"
+	MsgSanctionHistoryInvalidID   = "❌ Неверный ID пользователя."
+	BtnAuditLog                   = "🧾 Журнал действий"
+	BtnAuditFilterAction          = "🔎 Тип: %s"
+	BtnAuditFilterUser            = "👤 Фильтр по пользователю"
+	BtnAuditResetUser             = "✖️ Сбросить пользователя %d"
+	MsgAuditLogTitle              = "🧾 Журнал действий в чате **%s** (стр. %d/%d)\nТип: %s, пользователь: %s"
+	MsgAuditLogEmpty              = "🧾 В журнале чата **%s** нет записей.\nТип: %s, пользователь: %s"
+	MsgAuditAll                   = "все"
+	MsgAuditPickAction            = "Выберите тип действий для журнала чата **%s**:"
+	MsgPromptAuditUser            = "Введите ID пользователя, чтобы показать записи журнала чата %s, где он исполнитель или цель (0 — сбросить фильтр):"
+	MsgAuditEntry                 = "**%s** — %s"
+	MsgAuditEntryTarget           = "Пользователь: %d"
+	MsgAuditEntryActor            = "Исполнитель: %s"
+	MsgAuditActorBot              = "бот"
+	MsgAuditActorAdmin            = "администратор %d"
+	MsgAuditEntryFilter           = "Фильтр: %s"
+	MsgAuditEntryReason           = "Причина: %s"
+	MsgAuditEntryMessage          = "Сообщение: `%s`"
+	MsgAuditReasonGlobalBan       = "пользователь в глобальном чёрном списке"
+	MsgAuditActionDelete          = "🗑 удаление сообщения"
+	MsgAuditActionWarn            = "⚠️ предупреждение"
+	MsgAuditActionUnwarn          = "↩️ снятие предупреждения"
+	MsgAuditActionForgive         = "🕊 прощение нарушений"
+	MsgAuditActionMute            = "🔇 мут"
+	MsgAuditActionUnmute          = "🔊 снятие мута"
+	MsgAuditActionBan             = "⛔ бан"
+	MsgAuditActionUnban           = "✅ снятие бана"
+	MsgAuditActionKick            = "👢 исключение"
+	MsgAuditActionSetting         = "⚙️ изменение настроек"
+	MsgAuditActionBlocklist       = "🚫 изменение стоп-листа"
+	MsgAuditActionLink            = "🔗 привязка чата"
+	MsgAuditActionRestore         = "♻️ восстановление сообщения"
+	MsgAuditActionFalsePos        = "⚠️ ложное срабатывание"
+	MsgAuditActionTrust           = "✅ добавление в доверенные"
+	MsgAuditActionUntrust         = "✖️ удаление из доверенных"
+	BtnArchiveToggle              = "Архив удалённых сообщений: %s"
+	BtnArchive                    = "🗄 Архив удалённых"
+	BtnArchiveRetention           = "⏳ Срок хранения: %d дн."
+	BtnArchiveEntry               = "%s %s · %s: %s"
+	BtnArchiveRestore             = "♻️ Восстановить"
+	BtnArchiveFalsePositive       = "⚠️ Ложное срабатывание"
+	BtnArchiveTrust               = "✅ Добавить автора в доверенные"
+	BtnArchiveUntrust             = "✖️ Убрать автора из доверенных"
+	MsgArchiveTitle               = "🗄 Архив удалённых сообщений чата **%s** (стр. %d/%d)\nСрок хранения: %d дн."
+	MsgArchiveEmpty               = "🗄 В архиве чата **%s** нет сообщений.\nСрок хранения: %d дн."
+	MsgArchiveDisabled            = "\n\nАрхив выключен: включите его в настройках чата, чтобы сохранять удалённые сообщения."
+	MsgArchiveDetail              = "🗄 **Удалённое сообщение**\n\nАвтор: %s (ID: %d)%s\nВремя: %s\nФильтр: %s\nПричина: %s\nСтатус: %s\nВложений: %d\n\n%s"
+	MsgArchiveTrustedMark         = ", доверенный"
+	MsgArchiveNoText              = "_без текста_"
+	MsgArchiveStatusPending       = "⏳ не проверено"
+	MsgArchiveStatusRestored      = "♻️ восстановлено"
+	MsgArchiveStatusFalsePositive = "⚠️ ложное срабатывание"
+	MsgArchiveNotFound            = "Сообщение не найдено в архиве: возможно, истёк срок хранения."
+	MsgArchiveRestored            = "♻️ Сообщение восстановлено в чате."
+	MsgArchiveAlreadyRestored     = "Сообщение уже восстановлено."
+	MsgArchiveRestoreFailed       = "❌ Не удалось восстановить сообщение."
+	MsgArchiveMarkedFalsePositive = "Сообщение отмечено как ложное срабатывание."
+	MsgArchiveTrusted             = "✅ Пользователь %d добавлен в доверенные: фильтры контента на него больше не действуют."
+	MsgArchiveUntrusted           = "Пользователь %d убран из доверенных."
+	MsgArchiveActionFailed        = "❌ Не удалось выполнить действие."
+	MsgArchiveRestoredPost        = "♻️ Сообщение от [%s](max://max.ru/%%%d%%) восстановлено администратором:\n\n%s"
+	MsgPromptArchiveDays          = "Введите срок хранения архива удалённых сообщений в чате %s в днях (от 1 до 365). Сейчас: %d."
+	MsgArchiveDaysInvalid         = "❌ Укажите число дней от 1 до 365."
+	MsgArchiveDaysUpdated         = "✅ Срок хранения архива: %d дн."
+	MsgAuditActionReport          = "🚩 жалоба"
+	MsgReportUsage                = "Используйте `/report [причина]` в ответ на сообщение, которое нужно показать модераторам."
+	MsgReportSent                 = "✅ Жалоба отправлена модераторам."
+	MsgReportRateLimited          = "⏳ Слишком много жалоб, попробуйте позже."
+	MsgReportFailed               = "❌ Не удалось отправить жалобу."
+	MsgReportNoAdmins             = "В этом чате нет администраторов, подключивших бота."
+	MsgReportAdminNotice          = "🚩 **Жалоба #%d** в чате **%s**\n\nАвтор сообщения: %s (ID: %d)\nПожаловался: %s (ID: %d)\nПричина: %s\n\n%s"
+	MsgReportNoText               = "_сообщение без текста_"
+	MsgReportDefaultReason        = "жалоба участника"
+	MsgReportResolution           = "\n\n✅ Решение: %s (администратор %d)"
+	MsgReportStatusDeleted        = "сообщение удалено"
+	MsgReportStatusWarned         = "предупреждение"
+	MsgReportStatusMuted          = "мут на 1 час"
+	MsgReportStatusBanned         = "бан"
+	MsgReportStatusDismissed      = "жалоба отклонена"
+	MsgReportResolvedAdmin        = "✅ Жалоба #%d: %s."
+	MsgReportAlreadyResolved      = "Жалоба #%d уже рассмотрена другим администратором."
+	MsgReportDecisionFailed       = "❌ Не удалось обработать жалобу #%d."
+	MsgReportActionFailed         = "❌ Не удалось применить меру по жалобе #%d. Жалоба снова открыта."
+	MsgReportActionForbidden      = "⚠️ Недостаточно прав бота в чате, чтобы применить меру по жалобе #%d. Жалоба снова открыта."
+	BtnReportDelete               = "🗑 Удалить"
+	BtnReportWarn                 = "⚠️ Предупредить"
+	BtnReportMute                 = "🔇 Мут 1ч"
+	BtnReportBan                  = "⛔ Бан"
+	BtnReportDismiss              = "✖️ Отклонить"
+	MsgNotifyHeader               = "🔔 Чат **%s**\n\n"
+	MsgNotifyAutoMute             = "🔇 Автоматический мут: %s (ID: %d) на %s.\nПричина: %s"
+	MsgNotifyRaid                 = "🚨 Возможный рейд: %d новых участников за %d мин."
+	MsgNotifyRightsLost           = "⚠️ Бот лишился прав администратора. Фильтры и санкции не работают, пока права не будут возвращены."
+	MsgNotifyBotRemoved           = "⚠️ Бот удалён из чата."
+	MsgDigestTitleDaily           = "📊 **Сводка за сутки**"
+	MsgDigestTitleWeekly          = "📊 **Сводка за неделю**"
+	MsgDigestEmpty                = "\n\nНарушений не было."
+	MsgDigestDeleted              = "\n\nУдалено сообщений: %d"
+	MsgDigestFilterLine           = "\n• %s: %d"
+	MsgDigestManualFilter         = "вручную"
+	MsgDigestOffendersTitle       = "\n\nЧастые нарушители:"
+	MsgDigestOffenderLine         = "\n%d. ID %d — %d"
+	MsgNotificationsTitle         = "🔔 **Уведомления** для чата **%s**\n\nСобытия приходят в личные сообщения сразу, сводка по фильтрам и нарушителям — раз в сутки или в неделю. Настройки личные для каждого администратора."
+	MsgNotificationsSilenced      = "\n\n🔕 Уведомления по этому чату заглушены."
+	MsgDigestOff                  = "выкл"
+	MsgDigestDaily                = "ежедневно"
+	MsgDigestWeekly               = "еженедельно"
+	BtnNotifications              = "🔔 Уведомления"
+	BtnNotifyAutoMute             = "Автомут: %s"
+	BtnNotifyRaid                 = "Рейды: %s"
+	BtnNotifyRights               = "Потеря прав бота: %s"
+	BtnNotifyDigest               = "📊 Сводка: %s"
+	BtnNotifySilence              = "🔕 Заглушить чат: %s"
+	MsgNotifyDeletion             = "🗑 Удалено сообщение %s (ID: %d)\nФильтр: %s\nПравило: `%s`\nПричина: %s"
+	MsgCorrectionAuditReason      = "исправление ложного срабатывания #%d"
+	MsgCorrectionRuleRemoved      = "✅ Правило `%s` удалено из настроек чата."
+	MsgCorrectionRuleMissing      = "Правило уже удалено или его нельзя удалить автоматически."
+	MsgCorrectionExempted         = "✅ Пользователь %d добавлен в доверенные: фильтры контента на него больше не действуют."
+	MsgCorrectionUndone           = "✅ Пользователь %d: %s."
+	MsgCorrectionStrikeRemoved    = "нарушение снято"
+	MsgCorrectionNoStrike         = "нарушение не найдено"
+	MsgCorrectionUnmuted          = "мут снят"
+	MsgCorrectionNotFound         = "Запись об удалении не найдена."
+	MsgCorrectionFailed           = "❌ Не удалось применить исправление."
+	MsgCorrectionAlreadyUndone    = "Нарушение по этому удалению уже снято."
+	MsgAuditEntryRule             = "Правило: `%s`"
+	MsgArchiveRule                = "\nПравило: `%s`"
+	BtnCorrectionRemoveRule       = "🧹 Удалить это правило"
+	BtnCorrectionExempt           = "🛡 Исключить пользователя"
+	BtnCorrectionUndo             = "↩️ Снять нарушение и мут"
+	BtnNotifyDeletes              = "Удаления сообщений: %s"
+	BtnBlockedWordsList           = "📋 Список слов"
+	BtnBlockedDomainsList         = "📋 Список доменов"
+	BtnBlocklistEntry             = "❌ %s"
+	BtnBlocklistSearch            = "🔍 Поиск по списку"
+	BtnBlocklistResetSearch       = "✖️ Сбросить поиск"
+	MsgBlocklistWordsTitle        = "📋 Запрещённые слова чата **%s** (всего %d, стр. %d/%d).\nНажмите на запись, чтобы удалить её."
+	MsgBlocklistDomainsTitle      = "📋 Запрещённые домены чата **%s** (всего %d, стр. %d/%d).\nНажмите на запись, чтобы удалить её."
+	MsgBlocklistWordsEmpty        = "📋 В списке запрещённых слов чата **%s** нет записей."
+	MsgBlocklistDomainsEmpty      = "📋 В списке запрещённых доменов чата **%s** нет записей."
+	MsgBlocklistSearch            = "\n🔍 Поиск: «%s»"
+	MsgPromptBlocklistSearch      = "Введите часть слова или домена для поиска по списку чата %s:"
+	MsgBlocklistEntryRemoved      = "🗑 «%s» удалено из списка."
+	MsgBlocklistEntryGone         = "Запись уже удалена из списка."
+	BtnExport                     = "📤 Экспорт и импорт настроек"
+	BtnExportWords                = "📄 Слова (.txt)"
+	BtnExportDomains              = "📄 Домены (.txt)"
+	BtnExportSnapshotJSON         = "🗂 Все настройки (JSON)"
+	BtnExportSnapshotYAML         = "🗂 Все настройки (YAML)"
+	BtnImportSnapshot             = "📥 Загрузить настройки из файла"
+	MsgExportTitle                = "📤 Экспорт настроек чата **%s**.\nСписки выгружаются в .txt, полный снимок (настройки, списки, расписание, лестница нарушений, веса и доверенные пользователи) — в JSON или YAML. Снимок можно загрузить обратно в этот или другой чат."
+	MsgExportBlocklistCaption     = "📄 Чат %s: %d записей"
+	MsgExportSnapshotCaption      = "🗂 Снимок настроек чата %s (версия формата %d)"
+	MsgExportEmpty                = "Список пуст — выгружать нечего."
+	MsgExportFailed               = "❌ Не удалось выгрузить файл. Попробуйте позже."
+	MsgPromptImportSnapshot       = "Отправьте файл снимка настроек (**.json** или **.yaml**) для чата %s.\n⚠️ Текущие настройки, списки, расписание, лестница нарушений и доверенные пользователи будут заменены содержимым файла."
+	MsgSnapshotFileRequired       = "Пожалуйста, отправьте файл **.json**, **.yaml** или **.yml**."
+	MsgSnapshotImportError        = "❌ Не удалось загрузить снимок: %v"
+	MsgSnapshotImported           = "✅ Снимок настроек загружен (версия %d): %d слов, %d доменов, %d правил расписания, %d ступеней, %d доверенных пользователей."
+
+	BtnImportDomains        = "📥 Импорт доменов"
+	MsgPromptImportDomains  = "Отправьте файл с доменами для чата %s.\nПоддерживаются **.txt** (по одному домену в строке, `#` — комментарии), **.csv** (домен в первой колонке) и **.zip** с такими файлами.\nПеред применением будет показан предпросмотр изменений."
+	MsgImportPreview        = "📥 **Предпросмотр импорта** для %s\nРежим: %s\n%s\nПодтвердите, чтобы применить изменения."
+	MsgImportModeMerge      = "объединение с текущими списками"
+	MsgImportModeReplace    = "замена затронутых списков"
+	MsgImportWordsTitle     = "Слова и фразы"
+	MsgImportDomainsTitle   = "Домены"
+	MsgImportPreviewList    = "\n**%s**: будет добавлено %d%s\n  дубликатов: %d"
+	MsgImportPreviewRemoved = "\n  будет удалено %d%s"
+	MsgImportPreviewInvalid = "\n\n⚠️ Пропущено некорректных строк: %d%s"
+	MsgImportMore           = " и ещё %d"
+	BtnImportConfirm        = "✅ Применить"
+	BtnImportModeMerge      = "🔀 Режим: объединить"
+	BtnImportModeReplace    = "♻️ Режим: заменить"
+	BtnCancel               = "❌ Отмена"
+	MsgImportApplied        = "✅ Импорт применён.\nСлова: +%d / −%d\nДомены: +%d / −%d\nПропущено строк: %d"
+	MsgImportCancelled      = "Импорт отменён."
+	MsgImportExpired        = "⚠️ Нет ожидающего импорта. Отправьте файл заново."
+
+	BtnCopySettings             = "📋 Копирование настроек"
+	BtnCopyFrom                 = "📥 Скопировать из другого чата"
+	BtnCopyTo                   = "📤 Применить к другим чатам"
+	BtnCopyAllChats             = "☑️ Все мои чаты"
+	BtnCopyPreview              = "👀 Предпросмотр изменений"
+	MsgCopyMenu                 = "📋 **Копирование настроек** для %s\nМожно перенести настройки в этот чат из другого или применить настройки этого чата сразу к нескольким вашим чатам."
+	MsgCopyPickSource           = "Выберите чат, из которого скопировать настройки в %s:"
+	MsgCopyNoOtherChats         = "У вас нет других чатов с ботом."
+	MsgCopyDraftFrom            = "📥 Копирование из **%s** в **%s**.\nОтметьте разделы, которые нужно перенести:"
+	MsgCopyDraftBulk            = "📤 Применение настроек **%s** к другим чатам.\nОтметьте разделы и чаты (выбрано чатов: %d):"
+	MsgCopySectionFilters       = "Фильтры и медленный режим"
+	MsgCopySectionBlocklists    = "Списки слов и доменов"
+	MsgCopySectionAttachments   = "Ограничения вложений"
+	MsgCopySectionSchedules     = "Расписание и часовой пояс"
+	MsgCopyFieldAutoDelete      = "Автоудаление"
+	MsgCopyFieldWordFilter      = "Фильтр слов"
+	MsgCopyFieldLinkFilter      = "Фильтр ссылок"
+	MsgCopyFieldMute            = "Автомут"
+	MsgCopyFieldGlobalBans      = "Глобальный чёрный список"
+	MsgCopyFieldSlowMode        = "Медленный режим"
+	MsgCopyFieldRestrictImage   = "Запрет изображений"
+	MsgCopyFieldRestrictVideo   = "Запрет видео"
+	MsgCopyFieldRestrictAudio   = "Запрет аудио"
+	MsgCopyFieldRestrictFile    = "Запрет файлов"
+	MsgCopyFieldTimezone        = "Часовой пояс"
+	MsgCopyFieldScheduleNotices = "Уведомления расписания"
+	MsgCopyFieldBlockedWords    = "Запрещённые слова"
+	MsgCopyFieldBlockedDomains  = "Запрещённые домены"
+	MsgCopyFieldWordRules       = "Режимы совпадения слов"
+	MsgCopyFieldSchedule        = "Правила расписания"
+	MsgCopyChangeValue          = "%s: %s → %s"
+	MsgCopyChangeList           = "%s: +%d / −%d"
+	MsgCopyPreview              = "👀 **Предпросмотр копирования** из %s\n%s\n\nПодтвердите, чтобы применить изменения."
+	MsgCopyPreviewChat          = "\n\n**%s**"
+	MsgCopyPreviewNoChanges     = "\nбез изменений"
+	MsgCopyNothingSelected      = "⚠️ Выберите хотя бы один раздел и один чат."
+	MsgCopyApplied              = "✅ Настройки применены к чатам: %d."
+	MsgCopyFailed               = "❌ Не удалось применить настройки. Успешно обновлено чатов: %d."
+	MsgCopyCancelled            = "Копирование настроек отменено."
+	MsgCopyExpired              = "⚠️ Черновик копирования не найден. Начните заново."
+
+	BtnPresets                  = "🧩 Пресеты настроек"
+	BtnPresetSavePersonal       = "💾 Сохранить как личный пресет"
+	BtnPresetSaveShared         = "🌐 Сохранить как общий пресет"
+	BtnPresetApply              = "▶️ Применить один раз"
+	BtnPresetSubscribe          = "🔗 Применить и подписаться"
+	BtnPresetUpdate             = "🔄 Обновить пресет из этого чата"
+	BtnPresetDelete             = "🗑 Удалить пресет"
+	BtnPresetReset              = "↩️ Сбросить локальные изменения"
+	BtnPresetUnsubscribe        = "🔕 Отписаться от пресета"
+	MsgPresetsTitle             = "🧩 **Пресеты настроек** для %s\n%s\n\n🌐 — общие пресеты операторов, 👤 — ваши личные."
+	MsgPresetsEmpty             = "\nПресетов пока нет: сохраните настройки этого чата как пресет."
+	MsgPresetNotSubscribed      = "Чат не подписан на пресет."
+	MsgPresetSubscribed         = "Подписка на пресет «%s».\n%s"
+	MsgPresetOverrides          = "Изменено локально (не обновляется из пресета): %s"
+	MsgPresetNoOverrides        = "Локальных изменений нет."
+	MsgPresetScopeShared        = "общий"
+	MsgPresetScopePersonal      = "личный"
+	MsgPresetDetail             = "🧩 **%s** (%s)\n\nФильтр слов: %s\nФильтр ссылок: %s\nАвтоудаление: %s\nМедленный режим: %s\nИзображения/видео/аудио/файлы запрещены: %s %s %s %s\nЗапрещённых слов: %d, доменов: %d\nЧасовой пояс: %s\n\nПодписанные чаты получают изменения пресета, кроме полей, изменённых в чате вручную."
+	MsgPresetFieldStrikeDecay   = "Сгорание нарушений"
+	MsgPresetFieldArchive       = "Архив сообщений"
+	MsgPresetFieldArchiveDays   = "Срок хранения архива"
+	MsgPromptPresetName         = "Введите название пресета для настроек чата %s (до 64 символов), например «Строгий публичный чат»."
+	MsgPresetInvalidName        = "⚠️ Название пресета должно быть непустым и не длиннее 64 символов."
+	MsgPresetSaved              = "✅ Пресет «%s» сохранён."
+	MsgPresetApplied            = "✅ Пресет применён."
+	MsgPresetSubscribedDone     = "✅ Пресет применён, чат подписан на его изменения."
+	MsgPresetUnsubscribed       = "Чат отписан от пресета, текущие настройки сохранены."
+	MsgPresetUpdated            = "✅ Пресет обновлён. Изменения получили подписанные чаты: %d."
+	MsgPresetDeleted            = "Пресет удалён, подписанные чаты отписаны."
+	MsgPresetNotFound           = "⚠️ Пресет не найден."
+	MsgPresetReadOnly           = "⚠️ Общие пресеты создают и изменяют только операторы бота, личные — только их владелец."
+	MsgTokenPresetHint          = "\n\nЧтобы сразу применить к чату пресет настроек и подписать его на изменения, выберите пресет ниже — будет выдан новый токен."
+	MsgTokenGeneratedWithPreset = "Сгенерирован токен с пресетом «%s»: `%s`\n\n1. Добавьте меня в чат.\n2. **Сделайте меня администратором**.\n3. Отправьте эту команду в чате:\n`/link %s`\n\nПосле привязки настройки пресета применятся к чату автоматически."
+
+	BtnCoAdmins                = "👥 Соадминистраторы"
+	BtnCoAdminInvite           = "➕ Пригласить: %s"
+	BtnCoAdminRemove           = "🗑 Удалить %d"
+	MsgRoleViewer              = "наблюдатель"
+	MsgRoleModerator           = "модератор"
+	MsgRoleManager             = "менеджер"
+	MsgRoleOwner               = "владелец"
+	MsgYourRole                = "\nВаша роль: **%s**"
+	MsgRoleInsufficient        = "⛔ Недостаточно прав для этого действия."
+	MsgCoAdminsTitle           = "👥 **Администраторы чата %s**\n\n"
+	MsgCoAdminEntry            = "• `%d` — %s\n"
+	MsgCoAdminsHint            = "\nНаблюдатель видит только статистику, модератор управляет мутами, банами и жалобами, менеджер — настройками. Приглашение одноразовое и действует 24 часа."
+	MsgCoAdminInvite           = "Приглашение с ролью **%s** в чат **%s**.\n\nПерешлите эту команду соадминистратору — её нужно отправить мне в личные сообщения:\n`/join %s`"
+	MsgCoAdminInviteInvalid    = "⚠️ Приглашение недействительно или истекло."
+	MsgCoAdminJoinUsage        = "Неверный формат команды `/join`. Используйте: `/join <token>`"
+	MsgCoAdminAlreadyOwner     = "Вы уже владелец этого чата."
+	MsgCoAdminJoined           = "✅ Вы стали администратором чата **%s** с ролью **%s**."
+	MsgCoAdminAccepted         = "👥 Пользователь `%d` принял приглашение с ролью **%s** в чат **%s**."
+	MsgCoAdminRemoved          = "Соадминистратор `%d` удалён."
+	MsgCoAdminRevoked          = "Ваш доступ к управлению чатом **%s** отозван."
+	MsgCoAdminOwnerRemove      = "⚠️ Владельца чата удалить нельзя."
+	MsgCoAdminFailed           = "❌ Не удалось выполнить действие с администраторами."
+	MsgAdminSyncPending        = "⚠️ Вы больше не администратор этого чата. Доступ к панели бота будет отозван %s, если права не вернут."
+	MsgAdminSyncRevoked        = "🔒 Доступ к управлению чатом отозван: вы больше не администратор этого чата."
+	MsgAdminSyncInviterRevoked = "🔒 Доступ к управлению чатом отозван: пригласивший вас владелец больше не администратор чата."
+	MsgAdminSyncRestored       = "✅ Права администратора подтверждены, доступ к панели бота сохранён."
+	MsgAdminSyncGranted        = "✅ Вы владелец этого чата, поэтому вам открыт доступ к панели бота. Откройте её командой /menu."
 )
@@ -6,12 +6,16 @@ import (
 )
 
 type Result struct {
-	IsAllowed    bool
-	Reason       string
-	FilterName   string
-	ShouldDelete bool
-	ShouldMute   bool
-	MuteDuration time.Duration
+	IsAllowed     bool
+	Reason        string
+	FilterName    string
+	MatchedRule   string
+	Severity      int
+	ShouldDelete  bool
+	ShouldMute    bool
+	MuteDuration  time.Duration
+	Silent        bool
+	SkipViolation bool
 }
 type Filter interface {
 	Name() string
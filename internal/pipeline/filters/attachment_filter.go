@@ -34,25 +34,25 @@ func (f *AttachmentFilter) Process(_ context.Context, payload pipeline.Payload)
 			go func(chatID int64) {
 				_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "image_violations")
 			}(payload.ChatID)
-			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonImageRestricted, FilterName: "image_filter"}, nil
+			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonImageRestricted, FilterName: "image_filter", MatchedRule: "image"}, nil
 		}
 		if attType == "video" && settings.RestrictVideo {
 			go func(chatID int64) {
 				_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "video_violations")
 			}(payload.ChatID)
-			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonVideoRestricted, FilterName: "video_filter"}, nil
+			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonVideoRestricted, FilterName: "video_filter", MatchedRule: "video"}, nil
 		}
 		if attType == "audio" && settings.RestrictAudio {
 			go func(chatID int64) {
 				_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "audio_violations")
 			}(payload.ChatID)
-			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonAudioRestricted, FilterName: "audio_filter"}, nil
+			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonAudioRestricted, FilterName: "audio_filter", MatchedRule: "audio"}, nil
 		}
 		if (attType == "file" || attType == "document") && settings.RestrictFile {
 			go func(chatID int64) {
 				_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "file_violations")
 			}(payload.ChatID)
-			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonFileRestricted, FilterName: "file_filter"}, nil
+			return &pipeline.Result{IsAllowed: false, Reason: messages.MsgReasonFileRestricted, FilterName: "file_filter", MatchedRule: "file"}, nil
 		}
 	}
 	return &pipeline.Result{IsAllowed: true}, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"max-moderation-bot/internal/pipeline"
 	"max-moderation-bot/internal/repository"
+	"strings"
 	"testing"
 )
 
@@ -89,6 +90,9 @@ func TestAttachmentFilter_Process(t *testing.T) {
 			if !tt.wantAllowed && res.FilterName != tt.wantFilter {
 				t.Errorf("Process() filter = %v, want %v", res.FilterName, tt.wantFilter)
 			}
+			if !tt.wantAllowed && res.MatchedRule != strings.TrimSuffix(tt.wantFilter, "_filter") {
+				t.Errorf("Process() matched rule = %v, want %v", res.MatchedRule, strings.TrimSuffix(tt.wantFilter, "_filter"))
+			}
 		})
 	}
 }
@@ -51,9 +51,10 @@ func (f *LinkFilter) Process(_ context.Context, payload pipeline.Payload) (*pipe
 					_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "link_violations")
 				}(payload.ChatID)
 				return &pipeline.Result{
-					IsAllowed:  false,
-					Reason:     messages.MsgReasonProhibitedDomain,
-					FilterName: f.Name(),
+					IsAllowed:   false,
+					Reason:      messages.MsgReasonProhibitedDomain,
+					FilterName:  f.Name(),
+					MatchedRule: domain,
 				}, nil
 			}
 		}
@@ -40,7 +40,7 @@ type mockMuteRepo struct {
 	muteErr                     error
 	activeMutes                 int64
 	IsMutedFunc                 func(_, _ int64) (bool, time.Time, error)
-	MuteUserFunc                func(_, _ int64, _ string, _ time.Duration) error
+	MuteUserFunc                func(_, _ int64, _, _ string, _ time.Duration) error
 	UnmuteUserFunc              func(_, _ int64) error
 	GetActiveMutesFunc          func(_ int64) ([]repository.Mute, error)
 	GetActiveMutesPaginatedFunc func(_ int64, _, _ int) ([]repository.Mute, int64, error)
@@ -53,9 +53,9 @@ func (m *mockMuteRepo) IsMuted(chatID, userID int64) (bool, time.Time, error) {
 	}
 	return m.isMuted, m.expiresAt, m.err
 }
-func (m *mockMuteRepo) MuteUser(chatID, userID int64, userName string, duration time.Duration) error {
+func (m *mockMuteRepo) MuteUser(chatID, userID int64, userName, reason string, duration time.Duration) error {
 	if m.MuteUserFunc != nil {
-		return m.MuteUserFunc(chatID, userID, userName, duration)
+		return m.MuteUserFunc(chatID, userID, userName, reason, duration)
 	}
 	return m.muteErr
 }
@@ -86,12 +86,19 @@ func (m *mockMuteRepo) GetActiveMutesPaginated(chatID int64, offset, limit int)
 func (m *mockMuteRepo) CountActiveMutes() (int64, error) {
 	return m.activeMutes, m.err
 }
+func (m *mockMuteRepo) GetActiveMutesByUser(userID int64) ([]repository.Mute, error) {
+	return nil, nil
+}
+func (m *mockMuteRepo) SetExpiry(chatID, userID int64, expiresAt time.Time) error {
+	return nil
+}
 
 type mockViolationRepo struct {
 	IncrementChatStatFunc func(ctx context.Context, chatID int64, field string) error
 	GetChatTotalStatsFunc func(ctx context.Context, chatID int64) (*repository.ChatStats, error)
-	AddViolationFunc      func(ctx context.Context, chatID, userID int64, violationType string) error
+	AddViolationFunc      func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error
 	CountViolationsFunc   func(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
+	SumWeightsFunc        func(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
 }
 
 func (m *mockViolationRepo) IncrementChatStat(ctx context.Context, chatID int64, field string) error {
@@ -108,9 +115,9 @@ func (m *mockViolationRepo) GetChatTotalStats(ctx context.Context, chatID int64)
 	return &repository.ChatStats{ChatID: chatID}, nil
 }
 
-func (m *mockViolationRepo) AddViolation(ctx context.Context, chatID, userID int64, violationType string) error {
+func (m *mockViolationRepo) AddViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
 	if m.AddViolationFunc != nil {
-		return m.AddViolationFunc(ctx, chatID, userID, violationType)
+		return m.AddViolationFunc(ctx, chatID, userID, violationType, weight, messageID)
 	}
 	return nil
 }
@@ -121,3 +128,51 @@ func (m *mockViolationRepo) CountViolationsSince(ctx context.Context, chatID, us
 	}
 	return 0, nil
 }
+
+func (m *mockViolationRepo) SumViolationWeightsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+	if m.SumWeightsFunc != nil {
+		return m.SumWeightsFunc(ctx, chatID, userID, since)
+	}
+	return 0, nil
+}
+
+func (m *mockViolationRepo) AddWarning(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+	return nil
+}
+
+func (m *mockViolationRepo) GetViolationsSince(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]repository.UserViolation, error) {
+	return nil, nil
+}
+
+func (m *mockViolationRepo) DeleteLatestViolation(ctx context.Context, chatID, userID int64, violationType string) (*repository.UserViolation, error) {
+	return nil, nil
+}
+
+func (m *mockViolationRepo) DeleteViolationByMessage(ctx context.Context, chatID, userID int64, violationType, messageID string) (*repository.UserViolation, error) {
+	return nil, nil
+}
+
+func (m *mockViolationRepo) ForgiveViolations(ctx context.Context, chatID, userID, moderatorID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockViolationRepo) DecayViolations(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+type mockScheduleRepo struct {
+	rules []repository.ScheduleRule
+}
+
+func (m *mockScheduleRepo) GetRules(chatID int64) ([]repository.ScheduleRule, error) {
+	return m.rules, nil
+}
+func (m *mockScheduleRepo) AddRule(rule *repository.ScheduleRule) error {
+	return nil
+}
+func (m *mockScheduleRepo) DeleteRule(chatID int64, ruleID uint) error {
+	return nil
+}
+func (m *mockScheduleRepo) GetScheduledChatIDs() ([]int64, error) {
+	return nil, nil
+}
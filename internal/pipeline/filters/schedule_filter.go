@@ -0,0 +1,56 @@
+package filters
+
+import (
+	"context"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/schedule"
+	"time"
+)
+
+type AdminCheckFunc func(ctx context.Context, chatID, userID int64) (bool, error)
+
+type ScheduleFilter struct {
+	settingsRepo repository.SettingsRepository
+	scheduleRepo repository.ScheduleRepository
+	isAdmin      AdminCheckFunc
+}
+
+func NewScheduleFilter(settingsRepo repository.SettingsRepository, scheduleRepo repository.ScheduleRepository, isAdmin AdminCheckFunc) *ScheduleFilter {
+	return &ScheduleFilter{
+		settingsRepo: settingsRepo,
+		scheduleRepo: scheduleRepo,
+		isAdmin:      isAdmin,
+	}
+}
+
+func (f *ScheduleFilter) Name() string {
+	return "schedule_filter"
+}
+
+func (f *ScheduleFilter) Process(ctx context.Context, payload pipeline.Payload) (*pipeline.Result, error) {
+	rules, err := f.scheduleRepo.GetRules(payload.ChatID)
+	if err != nil || len(rules) == 0 {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	settings, err := f.settingsRepo.GetSettings(payload.ChatID)
+	if err != nil {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	if !schedule.Active(rules, settings.Timezone, time.Now()).ReadOnly {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	if f.isAdmin != nil {
+		if admin, err := f.isAdmin(ctx, payload.ChatID, payload.SenderID); err != nil || admin {
+			return &pipeline.Result{IsAllowed: true}, nil
+		}
+	}
+	return &pipeline.Result{
+		IsAllowed:    false,
+		Reason:       messages.MsgReasonReadOnly,
+		FilterName:   f.Name(),
+		ShouldDelete: true,
+		Silent:       true,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/repository"
+	"testing"
+)
+
+func TestScheduleFilter_Process(t *testing.T) {
+	readOnly := []repository.ScheduleRule{{Weekdays: 0x7f, StartMinute: 0, EndMinute: 24 * 60, ReadOnly: true}}
+	tests := []struct {
+		name        string
+		isAdmin     AdminCheckFunc
+		wantAllowed bool
+	}{
+		{
+			name:        "Member is blocked",
+			isAdmin:     func(ctx context.Context, chatID, userID int64) (bool, error) { return false, nil },
+			wantAllowed: false,
+		},
+		{
+			name:        "Admin is allowed",
+			isAdmin:     func(ctx context.Context, chatID, userID int64) (bool, error) { return true, nil },
+			wantAllowed: true,
+		},
+		{
+			name:        "Admin check error allows",
+			isAdmin:     func(ctx context.Context, chatID, userID int64) (bool, error) { return false, errors.New("api down") },
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsRepo := &mockSettingsRepo{settings: &repository.ChatSettings{Timezone: "UTC"}}
+			filter := NewScheduleFilter(settingsRepo, &mockScheduleRepo{rules: readOnly}, tt.isAdmin)
+			result, err := filter.Process(context.Background(), pipeline.Payload{ChatID: 1, SenderID: 2, Text: "hi"})
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if result.IsAllowed != tt.wantAllowed {
+				t.Errorf("Process() allowed = %v, want %v", result.IsAllowed, tt.wantAllowed)
+			}
+		})
+	}
+}
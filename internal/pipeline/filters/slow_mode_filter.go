@@ -0,0 +1,94 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/repository"
+	"sync"
+	"time"
+)
+
+const slowModePruneInterval = time.Minute
+
+type slowModeEntry struct {
+	lastPost time.Time
+	interval time.Duration
+	notified bool
+}
+
+type SlowModeFilter struct {
+	mu           sync.Mutex
+	entries      map[string]*slowModeEntry
+	lastPrune    time.Time
+	settingsRepo repository.SettingsRepository
+	isAdmin      AdminCheckFunc
+	now          func() time.Time
+}
+
+func NewSlowModeFilter(settingsRepo repository.SettingsRepository, isAdmin AdminCheckFunc) *SlowModeFilter {
+	return &SlowModeFilter{
+		entries:      make(map[string]*slowModeEntry),
+		settingsRepo: settingsRepo,
+		isAdmin:      isAdmin,
+		now:          time.Now,
+	}
+}
+
+func (f *SlowModeFilter) Name() string {
+	return "slow_mode_filter"
+}
+
+func (f *SlowModeFilter) Process(ctx context.Context, payload pipeline.Payload) (*pipeline.Result, error) {
+	settings, err := f.settingsRepo.GetSettings(payload.ChatID)
+	if err != nil || settings.SlowModeSeconds <= 0 {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	interval := time.Duration(settings.SlowModeSeconds) * time.Second
+
+	if f.isAdmin != nil {
+		if admin, err := f.isAdmin(ctx, payload.ChatID, payload.SenderID); err == nil && admin {
+			return &pipeline.Result{IsAllowed: true}, nil
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := payload.SenderIDUserKey(payload.ChatID)
+	now := f.now()
+	f.prune(now)
+
+	entry, ok := f.entries[key]
+	if !ok || now.Sub(entry.lastPost) >= interval {
+		f.entries[key] = &slowModeEntry{lastPost: now, interval: interval}
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+
+	wait := int(math.Ceil((interval - now.Sub(entry.lastPost)).Seconds()))
+	silent := entry.notified
+	entry.notified = true
+
+	return &pipeline.Result{
+		IsAllowed:     false,
+		Reason:        fmt.Sprintf(messages.MsgReasonSlowMode, wait),
+		FilterName:    f.Name(),
+		ShouldDelete:  true,
+		SkipViolation: true,
+		Silent:        silent,
+	}, nil
+}
+
+func (f *SlowModeFilter) prune(now time.Time) {
+	if now.Sub(f.lastPrune) < slowModePruneInterval {
+		return
+	}
+	f.lastPrune = now
+	for key, entry := range f.entries {
+		if now.Sub(entry.lastPost) >= entry.interval {
+			delete(f.entries, key)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package filters
+
+import (
+	"context"
+	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowModeFilter_Process(t *testing.T) {
+	settingsRepo := &mockSettingsRepo{settings: &repository.ChatSettings{ChatID: -100, SlowModeSeconds: 1}}
+	filter := NewSlowModeFilter(settingsRepo, nil)
+	now := time.Now()
+	filter.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	payload := pipeline.Payload{ChatID: -100, SenderID: 123, Text: "text"}
+
+	res, err := filter.Process(ctx, payload)
+	assert.NoError(t, err)
+	assert.True(t, res.IsAllowed, "First message should be allowed")
+
+	res, err = filter.Process(ctx, payload)
+	assert.NoError(t, err)
+	assert.False(t, res.IsAllowed, "Second message should be blocked")
+	assert.True(t, res.ShouldDelete)
+	assert.True(t, res.SkipViolation)
+	assert.False(t, res.Silent, "First blocked message should produce a notice")
+
+	res, err = filter.Process(ctx, payload)
+	assert.NoError(t, err)
+	assert.False(t, res.IsAllowed, "Third message should be blocked")
+	assert.True(t, res.Silent, "Repeated blocked messages should not produce a notice")
+
+	other := pipeline.Payload{ChatID: -100, SenderID: 456, Text: "text"}
+	res, err = filter.Process(ctx, other)
+	assert.NoError(t, err)
+	assert.True(t, res.IsAllowed, "Other users should not be affected")
+
+	now = now.Add(time.Second)
+
+	res, err = filter.Process(ctx, payload)
+	assert.NoError(t, err)
+	assert.True(t, res.IsAllowed, "Message after the interval should be allowed")
+}
+
+func TestSlowModeFilter_PrunesExpiredEntries(t *testing.T) {
+	settingsRepo := &mockSettingsRepo{settings: &repository.ChatSettings{ChatID: -100, SlowModeSeconds: 30}}
+	filter := NewSlowModeFilter(settingsRepo, nil)
+	now := time.Now()
+	filter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for userID := int64(1); userID <= 3; userID++ {
+		_, err := filter.Process(ctx, pipeline.Payload{ChatID: -100, SenderID: userID, Text: "text"})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, filter.entries, 3)
+
+	now = now.Add(2 * time.Minute)
+	_, err := filter.Process(ctx, pipeline.Payload{ChatID: -100, SenderID: 4, Text: "text"})
+	assert.NoError(t, err)
+	assert.Len(t, filter.entries, 1, "Entries older than the interval should be evicted")
+}
+
+func TestSlowModeFilter_Exemptions(t *testing.T) {
+	ctx := context.Background()
+	payload := pipeline.Payload{ChatID: -100, SenderID: 123, Text: "text"}
+
+	disabled := NewSlowModeFilter(&mockSettingsRepo{settings: &repository.ChatSettings{ChatID: -100}}, nil)
+	for i := 0; i < 3; i++ {
+		res, err := disabled.Process(ctx, payload)
+		assert.NoError(t, err)
+		assert.True(t, res.IsAllowed, "Slow mode disabled should allow every message")
+	}
+
+	isAdmin := func(_ context.Context, _, userID int64) (bool, error) {
+		return userID == 123, nil
+	}
+	admins := NewSlowModeFilter(&mockSettingsRepo{settings: &repository.ChatSettings{ChatID: -100, SlowModeSeconds: 60}}, isAdmin)
+	for i := 0; i < 3; i++ {
+		res, err := admins.Process(ctx, payload)
+		assert.NoError(t, err)
+		assert.True(t, res.IsAllowed, "Admins should be exempt from slow mode")
+	}
+}
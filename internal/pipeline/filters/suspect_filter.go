@@ -0,0 +1,38 @@
+package filters
+
+import (
+	"context"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/pipeline"
+)
+
+type SuspectCheckFunc func(ctx context.Context, chatID, userID int64) (bool, error)
+
+type SuspectFilter struct {
+	isSuspect SuspectCheckFunc
+}
+
+func NewSuspectFilter(isSuspect SuspectCheckFunc) *SuspectFilter {
+	return &SuspectFilter{isSuspect: isSuspect}
+}
+
+func (f *SuspectFilter) Name() string {
+	return "suspect_filter"
+}
+
+func (f *SuspectFilter) Process(ctx context.Context, payload pipeline.Payload) (*pipeline.Result, error) {
+	if len(payload.AttachmentTypes) == 0 && !urlRegex.MatchString(payload.Text) {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	suspect, err := f.isSuspect(ctx, payload.ChatID, payload.SenderID)
+	if err != nil || !suspect {
+		return &pipeline.Result{IsAllowed: true}, nil
+	}
+	return &pipeline.Result{
+		IsAllowed:     false,
+		Reason:        messages.MsgReasonSuspect,
+		FilterName:    f.Name(),
+		ShouldDelete:  true,
+		SkipViolation: true,
+	}, nil
+}
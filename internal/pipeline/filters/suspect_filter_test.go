@@ -0,0 +1,69 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"max-moderation-bot/internal/pipeline"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspectFilter_Process(t *testing.T) {
+	suspects := func(_ context.Context, chatID, userID int64) (bool, error) {
+		return userID == 123, nil
+	}
+
+	tests := []struct {
+		name        string
+		check       SuspectCheckFunc
+		payload     pipeline.Payload
+		wantAllowed bool
+	}{
+		{
+			name:        "Suspect posting link",
+			check:       suspects,
+			payload:     pipeline.Payload{ChatID: -100, SenderID: 123, Text: "join spam.example.com"},
+			wantAllowed: false,
+		},
+		{
+			name:        "Suspect posting attachment",
+			check:       suspects,
+			payload:     pipeline.Payload{ChatID: -100, SenderID: 123, AttachmentTypes: []string{"image"}},
+			wantAllowed: false,
+		},
+		{
+			name:        "Suspect posting plain text",
+			check:       suspects,
+			payload:     pipeline.Payload{ChatID: -100, SenderID: 123, Text: "hello"},
+			wantAllowed: true,
+		},
+		{
+			name:        "Regular user posting link",
+			check:       suspects,
+			payload:     pipeline.Payload{ChatID: -100, SenderID: 456, Text: "see example.com"},
+			wantAllowed: true,
+		},
+		{
+			name: "Lookup error allows message",
+			check: func(_ context.Context, _, _ int64) (bool, error) {
+				return false, errors.New("db error")
+			},
+			payload:     pipeline.Payload{ChatID: -100, SenderID: 123, Text: "spam.example.com"},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewSuspectFilter(tt.check)
+			res, err := filter.Process(context.Background(), tt.payload)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, res.IsAllowed)
+			if !tt.wantAllowed {
+				assert.True(t, res.ShouldDelete)
+				assert.True(t, res.SkipViolation)
+			}
+		})
+	}
+}
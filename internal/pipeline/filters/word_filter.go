@@ -6,6 +6,8 @@ import (
 	"max-moderation-bot/internal/pipeline"
 	"max-moderation-bot/internal/repository"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type WordFilter struct {
@@ -32,16 +34,48 @@ func (f *WordFilter) Process(_ context.Context, payload pipeline.Payload) (*pipe
 	}
 	lowerMsg := strings.ToLower(payload.Text)
 	for _, word := range settings.BlockedWords {
-		if strings.Contains(lowerMsg, word) {
+		rule := settings.WordRules[word]
+		if matchesWord(lowerMsg, word, rule.Mode) {
 			go func(chatID int64) {
 				_ = f.violationRepo.IncrementChatStat(context.Background(), chatID, "word_violations")
 			}(payload.ChatID)
 			return &pipeline.Result{
-				IsAllowed:  false,
-				Reason:     messages.MsgReasonProhibitedWord,
-				FilterName: f.Name(),
+				IsAllowed:   false,
+				Reason:      messages.MsgReasonProhibitedWord,
+				FilterName:  f.Name(),
+				MatchedRule: word,
+				Severity:    rule.Severity,
 			}, nil
 		}
 	}
 	return &pipeline.Result{IsAllowed: true}, nil
 }
+
+func matchesWord(text, word, mode string) bool {
+	switch mode {
+	case repository.MatchExact:
+		return strings.TrimSpace(text) == word
+	case repository.MatchWord:
+		for offset := 0; offset < len(text); {
+			i := strings.Index(text[offset:], word)
+			if i < 0 {
+				return false
+			}
+			start := offset + i
+			end := start + len(word)
+			before, _ := utf8.DecodeLastRuneInString(text[:start])
+			after, _ := utf8.DecodeRuneInString(text[end:])
+			if !isWordRune(before) && !isWordRune(after) {
+				return true
+			}
+			_, size := utf8.DecodeRuneInString(text[start:])
+			offset = start + size
+		}
+		return false
+	}
+	return strings.Contains(text, word)
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -88,6 +88,49 @@ func TestWordFilter_Process(t *testing.T) {
 				if res.Reason != messages.MsgReasonProhibitedWord {
 					t.Errorf("Process() reason = %q, want %q", res.Reason, messages.MsgReasonProhibitedWord)
 				}
+				if res.MatchedRule != tt.wantReason {
+					t.Errorf("Process() matched rule = %q, want %q", res.MatchedRule, tt.wantReason)
+				}
+			}
+		})
+	}
+}
+
+func TestWordFilter_MatchModes(t *testing.T) {
+	mockRepo := &mockSettingsRepo{
+		settings: &repository.ChatSettings{
+			BlockedWords: []string{"bad", "кот", "free money"},
+			WordRules: repository.WordRules{
+				"кот":        {Mode: repository.MatchWord, Severity: 3},
+				"free money": {Mode: repository.MatchExact},
+			},
+			EnableWordFilter: true,
+		},
+	}
+	f := NewWordFilter(mockRepo, &mockViolationRepo{})
+	tests := []struct {
+		name         string
+		message      string
+		wantAllowed  bool
+		wantSeverity int
+	}{
+		{name: "Contains by default", message: "badword", wantAllowed: false},
+		{name: "Whole word matches", message: "Мой КОТ, серый", wantAllowed: false, wantSeverity: 3},
+		{name: "Whole word ignores substrings", message: "котлета", wantAllowed: true},
+		{name: "Exact matches whole message", message: "  Free Money ", wantAllowed: false},
+		{name: "Exact ignores longer messages", message: "get free money now", wantAllowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := f.Process(context.Background(), pipeline.Payload{ChatID: 123, Text: tt.message})
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if res.IsAllowed != tt.wantAllowed {
+				t.Errorf("Process() allowed = %v, want %v", res.IsAllowed, tt.wantAllowed)
+			}
+			if res.Severity != tt.wantSeverity {
+				t.Errorf("Process() severity = %d, want %d", res.Severity, tt.wantSeverity)
 			}
 		})
 	}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	AppealStatusOpen     = "open"
+	AppealStatusApproved = "approved"
+	AppealStatusRejected = "rejected"
+	AppealStatusReduced  = "reduced"
+	AppealStatusClosed   = "closed"
+)
+
+type AppealRepository interface {
+	Create(appeal *Appeal) (bool, error)
+	Get(id uint) (*Appeal, error)
+	GetOpen(chatID, userID int64, sanctionType string) (*Appeal, error)
+	GetLatestByUser(userID int64) (*Appeal, error)
+	Resolve(id uint, status string, resolvedBy int64) (bool, error)
+	CloseOpen(chatID, userID int64, sanctionType string) error
+	CloseExpired(now time.Time) (int64, error)
+}
+
+type PostgresAppealRepository struct {
+	db *gorm.DB
+}
+
+func NewAppealRepository(db *gorm.DB) AppealRepository {
+	return &PostgresAppealRepository{db: db}
+}
+
+func (r *PostgresAppealRepository) Create(appeal *Appeal) (bool, error) {
+	if appeal.Status == "" {
+		appeal.Status = AppealStatusOpen
+	}
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "chat_id"}, {Name: "user_id"}, {Name: "sanction_type"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Name: "status"}, Value: AppealStatusOpen}}},
+		DoNothing:   true,
+	}).Create(appeal)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create appeal: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresAppealRepository) Get(id uint) (*Appeal, error) {
+	var appeal Appeal
+	if err := r.db.First(&appeal, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+	return &appeal, nil
+}
+
+func (r *PostgresAppealRepository) GetOpen(chatID, userID int64, sanctionType string) (*Appeal, error) {
+	var appeal Appeal
+	err := r.db.Where("chat_id = ? AND user_id = ? AND sanction_type = ? AND status = ?", chatID, userID, sanctionType, AppealStatusOpen).
+		First(&appeal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open appeal: %w", err)
+	}
+	return &appeal, nil
+}
+
+func (r *PostgresAppealRepository) GetLatestByUser(userID int64) (*Appeal, error) {
+	var appeal Appeal
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&appeal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest appeal: %w", err)
+	}
+	return &appeal, nil
+}
+
+func (r *PostgresAppealRepository) Resolve(id uint, status string, resolvedBy int64) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&Appeal{}).
+		Where("id = ? AND status = ?", id, AppealStatusOpen).
+		Updates(map[string]interface{}{"status": status, "resolved_by": resolvedBy, "resolved_at": now})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to resolve appeal: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresAppealRepository) CloseOpen(chatID, userID int64, sanctionType string) error {
+	err := r.db.Model(&Appeal{}).
+		Where("chat_id = ? AND user_id = ? AND sanction_type = ? AND status = ?", chatID, userID, sanctionType, AppealStatusOpen).
+		Updates(map[string]interface{}{"status": AppealStatusClosed, "resolved_at": time.Now()}).Error
+	if err != nil {
+		return fmt.Errorf("failed to close open appeals: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAppealRepository) CloseExpired(now time.Time) (int64, error) {
+	result := r.db.Model(&Appeal{}).
+		Where("status = ?", AppealStatusOpen).
+		Where(`(sanction_type = 'mute' AND NOT EXISTS (SELECT 1 FROM mutes WHERE mutes.chat_id = appeals.chat_id AND mutes.user_id = appeals.user_id AND mutes.expires_at > ?))
+			OR (sanction_type = 'ban' AND NOT EXISTS (SELECT 1 FROM bans WHERE bans.chat_id = appeals.chat_id AND bans.user_id = appeals.user_id AND bans.expires_at > ?))`, now, now).
+		Updates(map[string]interface{}{"status": AppealStatusClosed, "resolved_at": now})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to close expired appeals: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
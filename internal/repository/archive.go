@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ArchiveStatusPending       = "pending"
+	ArchiveStatusRestored      = "restored"
+	ArchiveStatusFalsePositive = "false_positive"
+)
+
+type ArchiveRepository interface {
+	Add(message *ArchivedMessage) error
+	Get(id uint) (*ArchivedMessage, error)
+	GetPaginated(chatID int64, offset, limit int) ([]ArchivedMessage, int64, error)
+	SetStatus(id uint, status string, reviewedBy int64) error
+	MarkRestored(id uint, reviewedBy int64) (bool, error)
+	DeleteExpired(now time.Time) (int64, error)
+	AddTrusted(chatID, userID, addedBy int64) error
+	RemoveTrusted(chatID, userID int64) error
+	IsTrusted(chatID, userID int64) (bool, error)
+	GetTrusted(chatID int64) ([]TrustedUser, error)
+}
+
+type PostgresArchiveRepository struct {
+	db *gorm.DB
+}
+
+func NewArchiveRepository(db *gorm.DB) ArchiveRepository {
+	return &PostgresArchiveRepository{db: db}
+}
+
+func (r *PostgresArchiveRepository) Add(message *ArchivedMessage) error {
+	if err := r.db.Create(message).Error; err != nil {
+		return fmt.Errorf("failed to archive message: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresArchiveRepository) Get(id uint) (*ArchivedMessage, error) {
+	var message ArchivedMessage
+	if err := r.db.First(&message, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get archived message: %w", err)
+	}
+	return &message, nil
+}
+
+func (r *PostgresArchiveRepository) GetPaginated(chatID int64, offset, limit int) ([]ArchivedMessage, int64, error) {
+	var messages []ArchivedMessage
+	var total int64
+
+	query := r.db.Model(&ArchivedMessage{}).Where("chat_id = ?", chatID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count archived messages: %w", err)
+	}
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&messages).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get archived messages: %w", err)
+	}
+	return messages, total, nil
+}
+
+func (r *PostgresArchiveRepository) SetStatus(id uint, status string, reviewedBy int64) error {
+	err := r.db.Model(&ArchivedMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewedBy,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update archived message status: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresArchiveRepository) MarkRestored(id uint, reviewedBy int64) (bool, error) {
+	result := r.db.Model(&ArchivedMessage{}).
+		Where("id = ? AND status <> ?", id, ArchiveStatusRestored).
+		Updates(map[string]interface{}{
+			"status":      ArchiveStatusRestored,
+			"reviewed_by": reviewedBy,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark archived message restored: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresArchiveRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&ArchivedMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired archived messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *PostgresArchiveRepository) AddTrusted(chatID, userID, addedBy int64) error {
+	trusted := TrustedUser{ChatID: chatID, UserID: userID, AddedBy: addedBy, CreatedAt: time.Now()}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&trusted).Error; err != nil {
+		return fmt.Errorf("failed to add trusted user: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresArchiveRepository) RemoveTrusted(chatID, userID int64) error {
+	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).Delete(&TrustedUser{}).Error; err != nil {
+		return fmt.Errorf("failed to remove trusted user: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresArchiveRepository) IsTrusted(chatID, userID int64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&TrustedUser{}).Where("chat_id = ? AND user_id = ?", chatID, userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check trusted user: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresArchiveRepository) GetTrusted(chatID int64) ([]TrustedUser, error) {
+	var trusted []TrustedUser
+	if err := r.db.Where("chat_id = ?", chatID).Order("user_id").Find(&trusted).Error; err != nil {
+		return nil, fmt.Errorf("failed to get trusted users: %w", err)
+	}
+	return trusted, nil
+}
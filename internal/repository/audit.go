@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionDelete    = "delete"
+	AuditActionWarn      = "warn"
+	AuditActionUnwarn    = "unwarn"
+	AuditActionForgive   = "forgive"
+	AuditActionMute      = "mute"
+	AuditActionUnmute    = "unmute"
+	AuditActionBan       = "ban"
+	AuditActionUnban     = "unban"
+	AuditActionKick      = "kick"
+	AuditActionSetting   = "setting"
+	AuditActionBlocklist = "blocklist"
+	AuditActionLink      = "link"
+	AuditActionRestore   = "restore"
+	AuditActionFalsePos  = "false_positive"
+	AuditActionTrust     = "trust"
+	AuditActionUntrust   = "untrust"
+	AuditActionReport    = "report"
+)
+
+var AuditActions = []string{
+	AuditActionDelete,
+	AuditActionWarn,
+	AuditActionUnwarn,
+	AuditActionForgive,
+	AuditActionMute,
+	AuditActionUnmute,
+	AuditActionBan,
+	AuditActionUnban,
+	AuditActionKick,
+	AuditActionSetting,
+	AuditActionBlocklist,
+	AuditActionLink,
+	AuditActionRestore,
+	AuditActionFalsePos,
+	AuditActionTrust,
+	AuditActionUntrust,
+	AuditActionReport,
+}
+
+type AuditFilter struct {
+	Action string
+	UserID int64
+}
+
+type AuditFilterCount struct {
+	Filter string
+	Count  int64
+}
+
+type AuditOffenderCount struct {
+	UserID int64
+	Count  int64
+}
+
+type AuditRepository interface {
+	Add(entry *AuditEntry) error
+	Get(id uint) (*AuditEntry, error)
+	MarkUndone(id uint) (bool, error)
+	GetPaginated(chatID int64, filter AuditFilter, offset, limit int) ([]AuditEntry, int64, error)
+	CountDeletesByFilter(chatID int64, since time.Time) ([]AuditFilterCount, error)
+	TopOffenders(chatID int64, since time.Time, limit int) ([]AuditOffenderCount, error)
+}
+
+type PostgresAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &PostgresAuditRepository{db: db}
+}
+
+func (r *PostgresAuditRepository) Add(entry *AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if err := r.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to add audit entry: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuditRepository) Get(id uint) (*AuditEntry, error) {
+	var entry AuditEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get audit entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *PostgresAuditRepository) MarkUndone(id uint) (bool, error) {
+	result := r.db.Model(&AuditEntry{}).Where("id = ? AND undone_at IS NULL", id).Update("undone_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark audit entry undone: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresAuditRepository) GetPaginated(chatID int64, filter AuditFilter, offset, limit int) ([]AuditEntry, int64, error) {
+	var entries []AuditEntry
+	var total int64
+
+	query := r.db.Model(&AuditEntry{}).Where("chat_id = ?", chatID)
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("(actor_id = ? OR target_user_id = ?)", filter.UserID, filter.UserID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	return entries, total, nil
+}
+
+func (r *PostgresAuditRepository) CountDeletesByFilter(chatID int64, since time.Time) ([]AuditFilterCount, error) {
+	var counts []AuditFilterCount
+	err := r.db.Model(&AuditEntry{}).
+		Select("filter, COUNT(*) AS count").
+		Where("chat_id = ? AND action = ? AND created_at >= ?", chatID, AuditActionDelete, since).
+		Group("filter").
+		Order("count DESC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deletions by filter: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *PostgresAuditRepository) TopOffenders(chatID int64, since time.Time, limit int) ([]AuditOffenderCount, error) {
+	var offenders []AuditOffenderCount
+	err := r.db.Model(&AuditEntry{}).
+		Select("target_user_id AS user_id, COUNT(*) AS count").
+		Where("chat_id = ? AND target_user_id <> 0 AND created_at >= ?", chatID, since).
+		Where("action IN ?", []string{AuditActionDelete, AuditActionWarn, AuditActionMute, AuditActionBan, AuditActionKick}).
+		Group("target_user_id").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&offenders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top offenders: %w", err)
+	}
+	return offenders, nil
+}
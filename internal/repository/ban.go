@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var PermanentExpiry = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+func ExpiryAfter(duration time.Duration) time.Time {
+	if duration == time.Duration(math.MaxInt64) {
+		return PermanentExpiry
+	}
+	return time.Now().Add(duration)
+}
+
+type BanRepository interface {
+	BanUser(chatID, userID int64, userName string, duration time.Duration) error
+	UnbanUser(chatID, userID int64) error
+	IsBanned(chatID, userID int64) (bool, time.Time, error)
+	GetActiveBansPaginated(chatID int64, offset, limit int) ([]Ban, int64, error)
+	GetBan(chatID, userID int64) (*Ban, error)
+	GetActiveBansByUser(userID int64) ([]Ban, error)
+	SetExpiry(chatID, userID int64, expiresAt time.Time) error
+}
+
+type PostgresBanRepository struct {
+	db *gorm.DB
+}
+
+func NewBanRepository(db *gorm.DB) BanRepository {
+	return &PostgresBanRepository{db: db}
+}
+
+func (r *PostgresBanRepository) BanUser(chatID, userID int64, userName string, duration time.Duration) error {
+	expiresAt := PermanentExpiry
+	if duration > 0 {
+		expiresAt = ExpiryAfter(duration)
+	}
+	var existing Ban
+	err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&existing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ban := Ban{
+				ChatID:    chatID,
+				UserID:    userID,
+				UserName:  userName,
+				ExpiresAt: expiresAt,
+			}
+			if err := r.db.Create(&ban).Error; err != nil {
+				return fmt.Errorf("failed to create ban: %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to check existing ban: %w", err)
+	}
+
+	updates := map[string]interface{}{"expires_at": expiresAt}
+	if userName != "" {
+		updates["user_name"] = userName
+	}
+	if err := r.db.Model(&existing).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update ban: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresBanRepository) UnbanUser(chatID, userID int64) error {
+	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).Delete(&Ban{}).Error; err != nil {
+		return fmt.Errorf("failed to unban user: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresBanRepository) IsBanned(chatID, userID int64) (bool, time.Time, error) {
+	var ban Ban
+	err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).
+		Where("expires_at > ?", time.Now()).
+		First(&ban).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, time.Time{}, nil
+		}
+		return false, time.Time{}, fmt.Errorf("failed to check ban status: %w", err)
+	}
+	return true, ban.ExpiresAt, nil
+}
+
+func (r *PostgresBanRepository) GetBan(chatID, userID int64) (*Ban, error) {
+	var ban Ban
+	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&ban).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ban: %w", err)
+	}
+	return &ban, nil
+}
+
+func (r *PostgresBanRepository) GetActiveBansPaginated(chatID int64, offset, limit int) ([]Ban, int64, error) {
+	var bans []Ban
+	var total int64
+	query := r.db.Model(&Ban{}).Where("chat_id = ? AND expires_at > ?", chatID, time.Now())
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count active bans: %w", err)
+	}
+	if err := query.Offset(offset).Limit(limit).Order("expires_at ASC").Find(&bans).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get active bans: %w", err)
+	}
+	return bans, total, nil
+}
+
+func (r *PostgresBanRepository) GetActiveBansByUser(userID int64) ([]Ban, error) {
+	var bans []Ban
+	if err := r.db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).Order("expires_at ASC").Find(&bans).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user bans: %w", err)
+	}
+	return bans, nil
+}
+
+func (r *PostgresBanRepository) SetExpiry(chatID, userID int64, expiresAt time.Time) error {
+	if err := r.db.Model(&Ban{}).Where("chat_id = ? AND user_id = ?", chatID, userID).Update("expires_at", expiresAt).Error; err != nil {
+		return fmt.Errorf("failed to update ban expiry: %w", err)
+	}
+	return nil
+}
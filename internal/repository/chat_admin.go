@@ -1,17 +1,27 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ChatAdminRepository interface {
 	IsAdmin(chatID, userID int64) (bool, error)
 	AddAdmin(chatID, userID int64) error
+	AddAdminWithRole(chatID, userID int64, role string, invitedBy int64) error
+	GetRole(chatID, userID int64) (string, error)
+	GetChatAdmins(chatID int64) ([]ChatAdmin, error)
+	MarkDemoted(chatID, userID int64, at time.Time) error
+	ClearDemoted(chatID, userID int64) error
 	RemoveAdmin(chatID, userID int64) error
 	GetManagedChats(userID int64) ([]int64, error)
 	GetManagedChatsPaginated(userID int64, offset, limit int) ([]int64, int64, error)
+	GetAdmins(chatID int64) ([]int64, error)
+	GetLinkedChats() ([]int64, error)
 }
 type PostgresChatAdminRepository struct {
 	db *gorm.DB
@@ -29,15 +39,63 @@ func (r *PostgresChatAdminRepository) IsAdmin(chatID, userID int64) (bool, error
 	return count > 0, nil
 }
 func (r *PostgresChatAdminRepository) AddAdmin(chatID, userID int64) error {
+	return r.AddAdminWithRole(chatID, userID, RoleOwner, 0)
+}
+func (r *PostgresChatAdminRepository) AddAdminWithRole(chatID, userID int64, role string, invitedBy int64) error {
 	admin := ChatAdmin{
-		ChatID: chatID,
-		UserID: userID,
+		ChatID:    chatID,
+		UserID:    userID,
+		Role:      role,
+		InvitedBy: invitedBy,
+		CreatedAt: time.Now(),
 	}
-	if err := r.db.Where(ChatAdmin{ChatID: chatID, UserID: userID}).FirstOrCreate(&admin).Error; err != nil {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "invited_by", "demoted_at"}),
+	}).Create(&admin).Error
+	if err != nil {
 		return fmt.Errorf("failed to add admin: %w", err)
 	}
 	return nil
 }
+func (r *PostgresChatAdminRepository) GetRole(chatID, userID int64) (string, error) {
+	var admin ChatAdmin
+	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&admin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get admin role: %w", err)
+	}
+	if admin.Role == "" {
+		return RoleOwner, nil
+	}
+	return admin.Role, nil
+}
+func (r *PostgresChatAdminRepository) GetChatAdmins(chatID int64) ([]ChatAdmin, error) {
+	var admins []ChatAdmin
+	if err := r.db.Where("chat_id = ?", chatID).Order("created_at").Find(&admins).Error; err != nil {
+		return nil, fmt.Errorf("failed to get chat admins: %w", err)
+	}
+	return admins, nil
+}
+func (r *PostgresChatAdminRepository) MarkDemoted(chatID, userID int64, at time.Time) error {
+	err := r.db.Model(&ChatAdmin{}).
+		Where("chat_id = ? AND user_id = ? AND demoted_at IS NULL", chatID, userID).
+		Update("demoted_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark admin demoted: %w", err)
+	}
+	return nil
+}
+func (r *PostgresChatAdminRepository) ClearDemoted(chatID, userID int64) error {
+	err := r.db.Model(&ChatAdmin{}).
+		Where("chat_id = ? AND user_id = ?", chatID, userID).
+		Update("demoted_at", nil).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear admin demotion: %w", err)
+	}
+	return nil
+}
 func (r *PostgresChatAdminRepository) RemoveAdmin(chatID, userID int64) error {
 	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).Delete(&ChatAdmin{}).Error; err != nil {
 		return fmt.Errorf("failed to remove admin: %w", err)
@@ -76,3 +134,23 @@ func (r *PostgresChatAdminRepository) GetManagedChatsPaginated(userID int64, off
 	}
 	return chatIDs, total, nil
 }
+
+func (r *PostgresChatAdminRepository) GetAdmins(chatID int64) ([]int64, error) {
+	var admins []ChatAdmin
+	if err := r.db.Where("chat_id = ?", chatID).Find(&admins).Error; err != nil {
+		return nil, fmt.Errorf("failed to get chat admins: %w", err)
+	}
+	userIDs := make([]int64, len(admins))
+	for i, admin := range admins {
+		userIDs[i] = admin.UserID
+	}
+	return userIDs, nil
+}
+
+func (r *PostgresChatAdminRepository) GetLinkedChats() ([]int64, error) {
+	var chatIDs []int64
+	if err := r.db.Model(&ChatAdmin{}).Distinct().Pluck("chat_id", &chatIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get linked chats: %w", err)
+	}
+	return chatIDs, nil
+}
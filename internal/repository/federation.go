@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FederationRepository interface {
+	CreateGroup(ownerID int64, name string) (*ChatGroup, error)
+	GetGroup(groupID uint) (*ChatGroup, error)
+	GetGroups(ownerID int64) ([]ChatGroup, error)
+	DeleteGroup(groupID uint) error
+	SetPropagate(groupID uint, propagate bool) error
+	AddChat(groupID uint, chatID int64) error
+	RemoveChat(groupID uint, chatID int64) error
+	GetGroupChats(groupID uint) ([]int64, error)
+	GetChatGroup(chatID int64) (*ChatGroup, error)
+	AddSuspect(chatID, userID, sourceChatID int64, reason string, expiresAt time.Time) error
+	IsSuspect(chatID, userID int64) (bool, error)
+}
+
+type PostgresFederationRepository struct {
+	db *gorm.DB
+}
+
+func NewFederationRepository(db *gorm.DB) FederationRepository {
+	return &PostgresFederationRepository{db: db}
+}
+
+func (r *PostgresFederationRepository) CreateGroup(ownerID int64, name string) (*ChatGroup, error) {
+	group := ChatGroup{
+		OwnerID:   ownerID,
+		Name:      name,
+		Propagate: true,
+	}
+	if err := r.db.Create(&group).Error; err != nil {
+		return nil, fmt.Errorf("failed to create chat group: %w", err)
+	}
+	return &group, nil
+}
+
+func (r *PostgresFederationRepository) GetGroup(groupID uint) (*ChatGroup, error) {
+	var group ChatGroup
+	if err := r.db.First(&group, groupID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chat group: %w", err)
+	}
+	return &group, nil
+}
+
+func (r *PostgresFederationRepository) GetGroups(ownerID int64) ([]ChatGroup, error) {
+	var groups []ChatGroup
+	if err := r.db.Where("owner_id = ?", ownerID).Order("created_at ASC").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get chat groups: %w", err)
+	}
+	return groups, nil
+}
+
+func (r *PostgresFederationRepository) DeleteGroup(groupID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", groupID).Delete(&ChatGroupMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete chat group members: %w", err)
+		}
+		if err := tx.Delete(&ChatGroup{}, groupID).Error; err != nil {
+			return fmt.Errorf("failed to delete chat group: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PostgresFederationRepository) SetPropagate(groupID uint, propagate bool) error {
+	if err := r.db.Model(&ChatGroup{}).Where("id = ?", groupID).Update("propagate", propagate).Error; err != nil {
+		return fmt.Errorf("failed to update chat group propagation: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresFederationRepository) AddChat(groupID uint, chatID int64) error {
+	member := ChatGroupMember{ChatID: chatID, GroupID: groupID, CreatedAt: time.Now()}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"group_id", "created_at"}),
+	}).Create(&member).Error
+	if err != nil {
+		return fmt.Errorf("failed to add chat to group: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresFederationRepository) RemoveChat(groupID uint, chatID int64) error {
+	if err := r.db.Where("group_id = ? AND chat_id = ?", groupID, chatID).Delete(&ChatGroupMember{}).Error; err != nil {
+		return fmt.Errorf("failed to remove chat from group: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresFederationRepository) GetGroupChats(groupID uint) ([]int64, error) {
+	var members []ChatGroupMember
+	if err := r.db.Where("group_id = ?", groupID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to get group chats: %w", err)
+	}
+	chatIDs := make([]int64, len(members))
+	for i, member := range members {
+		chatIDs[i] = member.ChatID
+	}
+	return chatIDs, nil
+}
+
+func (r *PostgresFederationRepository) GetChatGroup(chatID int64) (*ChatGroup, error) {
+	var member ChatGroupMember
+	if err := r.db.Where("chat_id = ?", chatID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chat group membership: %w", err)
+	}
+	return r.GetGroup(member.GroupID)
+}
+
+func (r *PostgresFederationRepository) AddSuspect(chatID, userID, sourceChatID int64, reason string, expiresAt time.Time) error {
+	suspect := Suspect{
+		ChatID:       chatID,
+		UserID:       userID,
+		SourceChatID: sourceChatID,
+		Reason:       reason,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    time.Now(),
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"source_chat_id", "reason", "expires_at"}),
+	}).Create(&suspect).Error
+	if err != nil {
+		return fmt.Errorf("failed to add suspect: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresFederationRepository) IsSuspect(chatID, userID int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&Suspect{}).
+		Where("chat_id = ? AND user_id = ? AND expires_at > ?", chatID, userID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check suspect status: %w", err)
+	}
+	return count > 0, nil
+}
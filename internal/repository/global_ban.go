@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GlobalBanRepository interface {
+	Add(ban GlobalBan) error
+	AddMany(bans []GlobalBan) (int, error)
+	Remove(userID int64) (bool, error)
+	IsListed(userID int64) (bool, error)
+	GetAll() ([]GlobalBan, error)
+	Count() (int64, error)
+	LogEnforcement(chatID, userID int64, trigger string) error
+}
+
+type PostgresGlobalBanRepository struct {
+	db *gorm.DB
+}
+
+func NewGlobalBanRepository(db *gorm.DB) GlobalBanRepository {
+	return &PostgresGlobalBanRepository{db: db}
+}
+
+func (r *PostgresGlobalBanRepository) upsert(db *gorm.DB, bans []GlobalBan) *gorm.DB {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "added_by"}),
+	}).CreateInBatches(&bans, 500)
+}
+
+func (r *PostgresGlobalBanRepository) Add(ban GlobalBan) error {
+	if ban.CreatedAt.IsZero() {
+		ban.CreatedAt = time.Now()
+	}
+	if err := r.upsert(r.db, []GlobalBan{ban}).Error; err != nil {
+		return fmt.Errorf("failed to add global ban: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresGlobalBanRepository) AddMany(bans []GlobalBan) (int, error) {
+	if len(bans) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	for i := range bans {
+		if bans[i].CreatedAt.IsZero() {
+			bans[i].CreatedAt = now
+		}
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return r.upsert(tx, bans).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to import global bans: %w", err)
+	}
+	return len(bans), nil
+}
+
+func (r *PostgresGlobalBanRepository) Remove(userID int64) (bool, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&GlobalBan{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to remove global ban: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresGlobalBanRepository) IsListed(userID int64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&GlobalBan{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check global ban: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *PostgresGlobalBanRepository) GetAll() ([]GlobalBan, error) {
+	var bans []GlobalBan
+	if err := r.db.Order("created_at ASC, user_id ASC").Find(&bans).Error; err != nil {
+		return nil, fmt.Errorf("failed to get global bans: %w", err)
+	}
+	return bans, nil
+}
+
+func (r *PostgresGlobalBanRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Model(&GlobalBan{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count global bans: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PostgresGlobalBanRepository) LogEnforcement(chatID, userID int64, trigger string) error {
+	entry := GlobalBanEnforcement{
+		ChatID:    chatID,
+		UserID:    userID,
+		Trigger:   trigger,
+		CreatedAt: time.Now(),
+	}
+	if err := r.db.Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to log global ban enforcement: %w", err)
+	}
+	return nil
+}
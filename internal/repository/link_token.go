@@ -11,6 +11,8 @@ import (
 
 type LinkTokenRepository interface {
 	Create(userID int64, ttl time.Duration) (string, error)
+	CreateWithPreset(userID int64, presetID *uint, ttl time.Duration) (string, error)
+	CreateInvite(userID, chatID int64, role string, ttl time.Duration) (string, error)
 	Get(token string) (*LinkToken, error)
 	Delete(token string) error
 	DeleteExpired() error
@@ -23,10 +25,14 @@ func NewLinkTokenRepository(db *gorm.DB) LinkTokenRepository {
 	return &PostgresLinkTokenRepository{db: db}
 }
 func (r *PostgresLinkTokenRepository) Create(userID int64, ttl time.Duration) (string, error) {
+	return r.CreateWithPreset(userID, nil, ttl)
+}
+func (r *PostgresLinkTokenRepository) CreateWithPreset(userID int64, presetID *uint, ttl time.Duration) (string, error) {
 	token := uuid.New().String()
 	linkToken := LinkToken{
 		Token:     token,
 		UserID:    userID,
+		PresetID:  presetID,
 		ExpiresAt: time.Now().Add(ttl),
 	}
 	if err := r.db.Create(&linkToken).Error; err != nil {
@@ -34,6 +40,20 @@ func (r *PostgresLinkTokenRepository) Create(userID int64, ttl time.Duration) (s
 	}
 	return token, nil
 }
+func (r *PostgresLinkTokenRepository) CreateInvite(userID, chatID int64, role string, ttl time.Duration) (string, error) {
+	token := uuid.New().String()
+	linkToken := LinkToken{
+		Token:     token,
+		UserID:    userID,
+		ChatID:    chatID,
+		Role:      role,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := r.db.Create(&linkToken).Error; err != nil {
+		return "", fmt.Errorf("failed to create invite token: %w", err)
+	}
+	return token, nil
+}
 func (r *PostgresLinkTokenRepository) Get(token string) (*LinkToken, error) {
 	var linkToken LinkToken
 	if err := r.db.First(&linkToken, "token = ?", token).Error; err != nil {
@@ -18,14 +18,38 @@ type ChatSettings struct {
 	EnableLinkFilter bool           `gorm:"default:true"`
 	EnableMute       bool           `gorm:"default:false"`
 	EnableAutoDelete bool           `gorm:"default:true"`
+	Timezone         string         `gorm:"size:64;default:'UTC'"`
+	ScheduleNotices  bool           `gorm:"default:false"`
+	SlowModeSeconds  int            `gorm:"default:0"`
+	StrikeDecayDays  int            `gorm:"default:0"`
+	UseGlobalBans    bool           `gorm:"default:true"`
+	EnableArchive    bool           `gorm:"default:false"`
+	ArchiveDays      int            `gorm:"default:7"`
+	WordRules        WordRules      `gorm:"type:jsonb;serializer:json"`
+	PresetID         *uint          `gorm:"index"`
+	PresetBaseline   string         `gorm:"type:text"`
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
 
+const (
+	MatchContains = "contains"
+	MatchWord     = "word"
+	MatchExact    = "exact"
+)
+
+type WordRule struct {
+	Mode     string `json:"mode,omitempty"`
+	Severity int    `json:"severity,omitempty"`
+}
+
+type WordRules map[string]WordRule
+
 type UserState struct {
 	UserID    int64  `gorm:"primaryKey"`
 	ChatID    int64  `gorm:"not null"`
 	Action    string `gorm:"not null"`
+	Payload   string `gorm:"type:text"`
 	CreatedAt time.Time
 }
 type Mute struct {
@@ -33,17 +57,40 @@ type Mute struct {
 	ChatID    int64     `gorm:"index"`
 	UserID    int64     `gorm:"index"`
 	UserName  string    `gorm:"size:255"`
+	Reason    string    `gorm:"size:500"`
 	ExpiresAt time.Time `gorm:"index"`
 }
-type LinkToken struct {
-	Token     string    `gorm:"primaryKey"`
+type Ban struct {
+	ID        uint      `gorm:"primaryKey"`
+	ChatID    int64     `gorm:"index"`
 	UserID    int64     `gorm:"index"`
+	UserName  string    `gorm:"size:255"`
 	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time
 }
+type LinkToken struct {
+	Token     string `gorm:"primaryKey"`
+	UserID    int64  `gorm:"index"`
+	PresetID  *uint
+	ChatID    int64     `gorm:"default:0"`
+	Role      string    `gorm:"size:20"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+const (
+	RoleViewer    = "viewer"
+	RoleModerator = "moderator"
+	RoleManager   = "manager"
+	RoleOwner     = "owner"
+)
+
 type ChatAdmin struct {
-	ID        uint  `gorm:"primaryKey"`
-	ChatID    int64 `gorm:"index:idx_chat_user,unique"`
-	UserID    int64 `gorm:"index:idx_chat_user,unique"`
+	ID        uint   `gorm:"primaryKey"`
+	ChatID    int64  `gorm:"index:idx_chat_user,unique"`
+	UserID    int64  `gorm:"index:idx_chat_user,unique"`
+	Role      string `gorm:"size:20;not null;default:'owner'"`
+	InvitedBy int64  `gorm:"not null;default:0"`
+	DemotedAt *time.Time
 	CreatedAt time.Time
 }
 
@@ -58,3 +105,183 @@ type ChatStats struct {
 	FileViolations  int64     `gorm:"default:0"`
 	MuteCount       int64     `gorm:"default:0"`
 }
+
+type ScheduleRule struct {
+	ID            uint  `gorm:"primaryKey"`
+	ChatID        int64 `gorm:"index;not null"`
+	Weekdays      int   `gorm:"not null"`
+	StartMinute   int   `gorm:"not null"`
+	EndMinute     int   `gorm:"not null"`
+	WordFilter    bool  `gorm:"default:false"`
+	LinkFilter    bool  `gorm:"default:false"`
+	RestrictImage bool  `gorm:"default:false"`
+	RestrictVideo bool  `gorm:"default:false"`
+	RestrictAudio bool  `gorm:"default:false"`
+	RestrictFile  bool  `gorm:"default:false"`
+	ReadOnly      bool  `gorm:"default:false"`
+	CreatedAt     time.Time
+}
+
+type StrikeStep struct {
+	ID              uint   `gorm:"primaryKey"`
+	ChatID          int64  `gorm:"index;not null"`
+	Strikes         int    `gorm:"not null"`
+	Action          string `gorm:"size:20;not null"`
+	DurationSeconds int64  `gorm:"default:0"`
+	WindowSeconds   int64  `gorm:"not null"`
+	CreatedAt       time.Time
+}
+
+type ViolationWeight struct {
+	ChatID        int64  `gorm:"primaryKey;autoIncrement:false"`
+	ViolationType string `gorm:"primaryKey;size:50"`
+	Weight        int    `gorm:"not null;default:1"`
+}
+
+type Appeal struct {
+	ID           uint   `gorm:"primaryKey"`
+	ChatID       int64  `gorm:"index;uniqueIndex:idx_appeals_open_sanction,where:status = 'open';not null"`
+	UserID       int64  `gorm:"index;uniqueIndex:idx_appeals_open_sanction;not null"`
+	UserName     string `gorm:"size:255"`
+	SanctionType string `gorm:"size:20;uniqueIndex:idx_appeals_open_sanction;not null"`
+	Text         string `gorm:"size:2000"`
+	Status       string `gorm:"size:20;index;not null"`
+	ResolvedBy   int64
+	CreatedAt    time.Time
+	ResolvedAt   *time.Time
+}
+
+type ChatGroup struct {
+	ID        uint   `gorm:"primaryKey"`
+	OwnerID   int64  `gorm:"index;not null"`
+	Name      string `gorm:"size:100;not null"`
+	Propagate bool   `gorm:"default:true"`
+	CreatedAt time.Time
+}
+
+type ChatGroupMember struct {
+	ChatID    int64 `gorm:"primaryKey;autoIncrement:false"`
+	GroupID   uint  `gorm:"index;not null"`
+	CreatedAt time.Time
+}
+
+type Suspect struct {
+	ID           uint      `gorm:"primaryKey"`
+	ChatID       int64     `gorm:"index:idx_suspect_chat_user,unique"`
+	UserID       int64     `gorm:"index:idx_suspect_chat_user,unique"`
+	SourceChatID int64     `gorm:"not null"`
+	Reason       string    `gorm:"size:500"`
+	ExpiresAt    time.Time `gorm:"index"`
+	CreatedAt    time.Time
+}
+
+type GlobalBan struct {
+	UserID    int64  `gorm:"primaryKey;autoIncrement:false"`
+	Reason    string `gorm:"size:500"`
+	AddedBy   int64  `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+type GlobalBanEnforcement struct {
+	ID        uint   `gorm:"primaryKey"`
+	ChatID    int64  `gorm:"index"`
+	UserID    int64  `gorm:"index"`
+	Trigger   string `gorm:"size:20"`
+	CreatedAt time.Time
+}
+
+type SanctionRecord struct {
+	ID              uint   `gorm:"primaryKey"`
+	ChatID          int64  `gorm:"index:idx_sanction_chat_user"`
+	UserID          int64  `gorm:"index:idx_sanction_chat_user"`
+	UserName        string `gorm:"size:255"`
+	Type            string `gorm:"size:20;not null"`
+	DurationSeconds int64
+	Reason          string `gorm:"size:500"`
+	IssuedBy        int64
+	Source          string `gorm:"size:50"`
+	ExpiresAt       *time.Time
+	EndedAt         *time.Time `gorm:"index"`
+	EndedBy         int64
+	EndReason       string `gorm:"size:20"`
+	CreatedAt       time.Time
+}
+
+type AuditEntry struct {
+	ID           uint      `gorm:"primaryKey"`
+	ChatID       int64     `gorm:"index:idx_audit_chat_created"`
+	ActorID      int64     `gorm:"index"`
+	TargetUserID int64     `gorm:"index"`
+	Action       string    `gorm:"size:30;index;not null"`
+	Filter       string    `gorm:"size:50"`
+	Rule         string    `gorm:"size:255"`
+	Reason       string    `gorm:"size:500"`
+	MessageID    string    `gorm:"size:100"`
+	CreatedAt    time.Time `gorm:"index:idx_audit_chat_created"`
+	UndoneAt     *time.Time
+}
+
+type ArchivedMessage struct {
+	ID          uint           `gorm:"primaryKey"`
+	ChatID      int64          `gorm:"index:idx_archive_chat_created"`
+	UserID      int64          `gorm:"index"`
+	UserName    string         `gorm:"size:255"`
+	MessageID   string         `gorm:"size:100"`
+	Text        string         `gorm:"type:text"`
+	Attachments pq.StringArray `gorm:"type:text[]"`
+	Filter      string         `gorm:"size:50"`
+	Rule        string         `gorm:"size:255"`
+	AuditID     uint
+	Reason      string `gorm:"size:500"`
+	Status      string `gorm:"size:20;default:'pending'"`
+	ReviewedBy  int64
+	ExpiresAt   time.Time `gorm:"index"`
+	CreatedAt   time.Time `gorm:"index:idx_archive_chat_created"`
+}
+
+type TrustedUser struct {
+	ChatID    int64 `gorm:"primaryKey;autoIncrement:false"`
+	UserID    int64 `gorm:"primaryKey;autoIncrement:false"`
+	AddedBy   int64
+	CreatedAt time.Time
+}
+
+type Report struct {
+	ID           uint   `gorm:"primaryKey"`
+	ChatID       int64  `gorm:"index;not null"`
+	ReporterID   int64  `gorm:"index:idx_report_reporter_created;not null"`
+	ReporterName string `gorm:"size:255"`
+	TargetUserID int64  `gorm:"index"`
+	TargetName   string `gorm:"size:255"`
+	MessageID    string `gorm:"size:100"`
+	Text         string `gorm:"size:4000"`
+	Reason       string `gorm:"size:500"`
+	Status       string `gorm:"size:20;index;not null"`
+	ResolvedBy   int64
+	NoticeIDs    pq.StringArray `gorm:"type:text[]"`
+	CreatedAt    time.Time      `gorm:"index:idx_report_reporter_created"`
+	ResolvedAt   *time.Time
+}
+
+type NotificationPref struct {
+	ChatID         int64 `gorm:"primaryKey;autoIncrement:false"`
+	UserID         int64 `gorm:"primaryKey;autoIncrement:false;index"`
+	NotifyAutoMute bool
+	NotifyRaid     bool
+	NotifyRights   bool
+	NotifyDeletes  bool
+	Digest         string `gorm:"size:10;default:'off'"`
+	Silenced       bool   `gorm:"default:false"`
+	LastDigestAt   *time.Time
+	UpdatedAt      time.Time
+}
+
+type SettingsPreset struct {
+	ID        uint   `gorm:"primaryKey"`
+	OwnerID   int64  `gorm:"index;not null;default:0"`
+	CreatedBy int64  `gorm:"not null"`
+	Name      string `gorm:"size:64;not null"`
+	Data      string `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -9,13 +9,15 @@ import (
 )
 
 type MuteRepository interface {
-	MuteUser(chatID, userID int64, userName string, duration time.Duration) error
+	MuteUser(chatID, userID int64, userName, reason string, duration time.Duration) error
 	UnmuteUser(chatID, userID int64) error
 	IsMuted(chatID, userID int64) (bool, time.Time, error)
 	GetActiveMutesPaginated(chatID int64, offset, limit int) ([]Mute, int64, error)
 	GetMute(chatID, userID int64) (*Mute, error)
 	GetActiveMutes(chatID int64) ([]Mute, error)
 	CountActiveMutes() (int64, error)
+	GetActiveMutesByUser(userID int64) ([]Mute, error)
+	SetExpiry(chatID, userID int64, expiresAt time.Time) error
 }
 type PostgresMuteRepository struct {
 	db *gorm.DB
@@ -24,8 +26,8 @@ type PostgresMuteRepository struct {
 func NewMuteRepository(db *gorm.DB) MuteRepository {
 	return &PostgresMuteRepository{db: db}
 }
-func (r *PostgresMuteRepository) MuteUser(chatID, userID int64, userName string, duration time.Duration) error {
-	expiresAt := time.Now().Add(duration)
+func (r *PostgresMuteRepository) MuteUser(chatID, userID int64, userName, reason string, duration time.Duration) error {
+	expiresAt := ExpiryAfter(duration)
 	var existing Mute
 	err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&existing).Error
 	if err != nil {
@@ -34,6 +36,7 @@ func (r *PostgresMuteRepository) MuteUser(chatID, userID int64, userName string,
 				ChatID:    chatID,
 				UserID:    userID,
 				UserName:  userName,
+				Reason:    reason,
 				ExpiresAt: expiresAt,
 			}
 			if err := r.db.Create(&mute).Error; err != nil {
@@ -51,6 +54,9 @@ func (r *PostgresMuteRepository) MuteUser(chatID, userID int64, userName string,
 	if userName != "" && userName != existing.UserName {
 		updates["user_name"] = userName
 	}
+	if reason != "" && reason != existing.Reason {
+		updates["reason"] = reason
+	}
 
 	if len(updates) > 0 {
 		if err := r.db.Model(&existing).Updates(updates).Error; err != nil {
@@ -115,3 +121,16 @@ func (r *PostgresMuteRepository) CountActiveMutes() (int64, error) {
 	}
 	return count, nil
 }
+func (r *PostgresMuteRepository) GetActiveMutesByUser(userID int64) ([]Mute, error) {
+	var mutes []Mute
+	if err := r.db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).Order("expires_at ASC").Find(&mutes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user mutes: %w", err)
+	}
+	return mutes, nil
+}
+func (r *PostgresMuteRepository) SetExpiry(chatID, userID int64, expiresAt time.Time) error {
+	if err := r.db.Model(&Mute{}).Where("chat_id = ? AND user_id = ?", chatID, userID).Update("expires_at", expiresAt).Error; err != nil {
+		return fmt.Errorf("failed to update mute expiry: %w", err)
+	}
+	return nil
+}
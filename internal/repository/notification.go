@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+type NotificationRepository interface {
+	Get(chatID, userID int64) (*NotificationPref, error)
+	Save(pref *NotificationPref) error
+	GetDigestSubscribers() ([]NotificationPref, error)
+	MarkDigestSent(chatID, userID int64, at time.Time) error
+}
+
+type PostgresNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+func (r *PostgresNotificationRepository) Get(chatID, userID int64) (*NotificationPref, error) {
+	var pref NotificationPref
+	if err := r.db.Where("chat_id = ? AND user_id = ?", chatID, userID).First(&pref).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return &pref, nil
+}
+
+func (r *PostgresNotificationRepository) Save(pref *NotificationPref) error {
+	pref.UpdatedAt = time.Now()
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"notify_auto_mute", "notify_raid", "notify_rights", "notify_deletes", "digest", "silenced", "last_digest_at", "updated_at"}),
+	}).Create(pref).Error
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresNotificationRepository) GetDigestSubscribers() ([]NotificationPref, error) {
+	var prefs []NotificationPref
+	if err := r.db.Where("digest <> ? AND silenced = ?", DigestOff, false).Find(&prefs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+	return prefs, nil
+}
+
+func (r *PostgresNotificationRepository) MarkDigestSent(chatID, userID int64, at time.Time) error {
+	err := r.db.Model(&NotificationPref{}).Where("chat_id = ? AND user_id = ?", chatID, userID).Update("last_digest_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type PresetRepository interface {
+	Create(preset *SettingsPreset) error
+	Update(preset *SettingsPreset) error
+	Delete(id uint) error
+	Get(id uint) (*SettingsPreset, error)
+	GetAvailable(ownerID int64) ([]SettingsPreset, error)
+	GetSubscribedChats(id uint) ([]int64, error)
+}
+
+type PostgresPresetRepository struct {
+	db *gorm.DB
+}
+
+func NewPresetRepository(db *gorm.DB) PresetRepository {
+	return &PostgresPresetRepository{db: db}
+}
+
+func (r *PostgresPresetRepository) Create(preset *SettingsPreset) error {
+	if err := r.db.Create(preset).Error; err != nil {
+		return fmt.Errorf("failed to create preset: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPresetRepository) Update(preset *SettingsPreset) error {
+	if err := r.db.Save(preset).Error; err != nil {
+		return fmt.Errorf("failed to update preset: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPresetRepository) Delete(id uint) error {
+	if err := r.db.Delete(&SettingsPreset{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPresetRepository) Get(id uint) (*SettingsPreset, error) {
+	var preset SettingsPreset
+	if err := r.db.First(&preset, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get preset: %w", err)
+	}
+	return &preset, nil
+}
+
+func (r *PostgresPresetRepository) GetAvailable(ownerID int64) ([]SettingsPreset, error) {
+	var presets []SettingsPreset
+	if err := r.db.Where("owner_id = 0 OR owner_id = ?", ownerID).Order("owner_id, name").Find(&presets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get presets: %w", err)
+	}
+	return presets, nil
+}
+
+func (r *PostgresPresetRepository) GetSubscribedChats(id uint) ([]int64, error) {
+	var chatIDs []int64
+	if err := r.db.Model(&ChatSettings{}).Where("preset_id = ?", id).Pluck("chat_id", &chatIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get preset subscribers: %w", err)
+	}
+	return chatIDs, nil
+}
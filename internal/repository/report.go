@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusDeleted   = "deleted"
+	ReportStatusWarned    = "warned"
+	ReportStatusMuted     = "muted"
+	ReportStatusBanned    = "banned"
+	ReportStatusDismissed = "dismissed"
+)
+
+type ReportRepository interface {
+	Create(report *Report) error
+	Get(id uint) (*Report, error)
+	CountByReporterSince(chatID, reporterID int64, since time.Time) (int64, error)
+	SetNoticeIDs(id uint, noticeIDs []string) error
+	Resolve(id uint, status string, resolvedBy int64) (bool, error)
+	Reopen(id uint, status string) error
+}
+
+type PostgresReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &PostgresReportRepository{db: db}
+}
+
+func (r *PostgresReportRepository) Create(report *Report) error {
+	if report.Status == "" {
+		report.Status = ReportStatusOpen
+	}
+	if err := r.db.Create(report).Error; err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresReportRepository) Get(id uint) (*Report, error) {
+	var report Report
+	if err := r.db.First(&report, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return &report, nil
+}
+
+func (r *PostgresReportRepository) CountByReporterSince(chatID, reporterID int64, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&Report{}).
+		Where("chat_id = ? AND reporter_id = ? AND created_at >= ?", chatID, reporterID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PostgresReportRepository) SetNoticeIDs(id uint, noticeIDs []string) error {
+	if err := r.db.Model(&Report{}).Where("id = ?", id).Update("notice_ids", pq.StringArray(noticeIDs)).Error; err != nil {
+		return fmt.Errorf("failed to save report notices: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresReportRepository) Resolve(id uint, status string, resolvedBy int64) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&Report{}).
+		Where("id = ? AND status = ?", id, ReportStatusOpen).
+		Updates(map[string]interface{}{"status": status, "resolved_by": resolvedBy, "resolved_at": now})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to resolve report: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgresReportRepository) Reopen(id uint, status string) error {
+	err := r.db.Model(&Report{}).
+		Where("id = ? AND status = ?", id, status).
+		Updates(map[string]interface{}{"status": ReportStatusOpen, "resolved_by": 0, "resolved_at": nil}).Error
+	if err != nil {
+		return fmt.Errorf("failed to reopen report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SanctionEndUnmuted  = "unmuted"
+	SanctionEndUnbanned = "unbanned"
+	SanctionEndExpired  = "expired"
+	SanctionEndReplaced = "replaced"
+	SanctionEndAppeal   = "appeal"
+	SanctionEndReduced  = "reduced"
+)
+
+type SanctionHistoryRepository interface {
+	Add(record *SanctionRecord) error
+	End(chatID, userID int64, sanctionType string, endedBy int64, reason string) error
+	EndExpired(now time.Time) (int64, error)
+	GetActive(chatID, userID int64, sanctionType string) (*SanctionRecord, error)
+	GetUserHistory(chatID, userID int64, offset, limit int) ([]SanctionRecord, int64, error)
+}
+
+type PostgresSanctionHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewSanctionHistoryRepository(db *gorm.DB) SanctionHistoryRepository {
+	return &PostgresSanctionHistoryRepository{db: db}
+}
+
+func (r *PostgresSanctionHistoryRepository) Add(record *SanctionRecord) error {
+	if err := r.db.Create(record).Error; err != nil {
+		return fmt.Errorf("failed to add sanction record: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresSanctionHistoryRepository) End(chatID, userID int64, sanctionType string, endedBy int64, reason string) error {
+	err := r.db.Model(&SanctionRecord{}).
+		Where("chat_id = ? AND user_id = ? AND type = ? AND ended_at IS NULL", chatID, userID, sanctionType).
+		Updates(map[string]interface{}{
+			"ended_at":   time.Now(),
+			"ended_by":   endedBy,
+			"end_reason": reason,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to end sanction record: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresSanctionHistoryRepository) EndExpired(now time.Time) (int64, error) {
+	result := r.db.Model(&SanctionRecord{}).
+		Where("ended_at IS NULL AND expires_at IS NOT NULL AND expires_at <= ?", now).
+		Updates(map[string]interface{}{
+			"ended_at":   gorm.Expr("expires_at"),
+			"end_reason": SanctionEndExpired,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to end expired sanction records: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *PostgresSanctionHistoryRepository) GetActive(chatID, userID int64, sanctionType string) (*SanctionRecord, error) {
+	var record SanctionRecord
+	err := r.db.Where("chat_id = ? AND user_id = ? AND type = ? AND ended_at IS NULL", chatID, userID, sanctionType).
+		Order("created_at DESC").
+		First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get active sanction record: %w", err)
+	}
+	return &record, nil
+}
+
+func (r *PostgresSanctionHistoryRepository) GetUserHistory(chatID, userID int64, offset, limit int) ([]SanctionRecord, int64, error) {
+	var records []SanctionRecord
+	var total int64
+
+	query := r.db.Model(&SanctionRecord{}).Where("chat_id = ? AND user_id = ?", chatID, userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count sanction records: %w", err)
+	}
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get sanction records: %w", err)
+	}
+	return records, total, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleRepository interface {
+	GetRules(chatID int64) ([]ScheduleRule, error)
+	AddRule(rule *ScheduleRule) error
+	DeleteRule(chatID int64, ruleID uint) error
+	GetScheduledChatIDs() ([]int64, error)
+}
+
+type CachedScheduleRepository struct {
+	db          *gorm.DB
+	cache       sync.Map
+	enableCache bool
+}
+
+type cachedRules struct {
+	rules     []ScheduleRule
+	expiresAt time.Time
+}
+
+func NewScheduleRepository(db *gorm.DB, enableCache bool) ScheduleRepository {
+	return &CachedScheduleRepository{
+		db:          db,
+		enableCache: enableCache,
+	}
+}
+
+func (r *CachedScheduleRepository) GetRules(chatID int64) ([]ScheduleRule, error) {
+	if r.enableCache {
+		if val, ok := r.cache.Load(chatID); ok {
+			entry := val.(*cachedRules)
+			if time.Now().Before(entry.expiresAt) {
+				return entry.rules, nil
+			}
+			r.cache.Delete(chatID)
+		}
+	}
+	var rules []ScheduleRule
+	if err := r.db.Where("chat_id = ?", chatID).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get schedule rules: %w", err)
+	}
+	if r.enableCache {
+		r.cache.Store(chatID, &cachedRules{
+			rules:     rules,
+			expiresAt: time.Now().Add(cacheTTL),
+		})
+	}
+	return rules, nil
+}
+
+func (r *CachedScheduleRepository) AddRule(rule *ScheduleRule) error {
+	if err := r.db.Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to add schedule rule: %w", err)
+	}
+	r.cache.Delete(rule.ChatID)
+	return nil
+}
+
+func (r *CachedScheduleRepository) DeleteRule(chatID int64, ruleID uint) error {
+	if err := r.db.Where("chat_id = ? AND id = ?", chatID, ruleID).Delete(&ScheduleRule{}).Error; err != nil {
+		return fmt.Errorf("failed to delete schedule rule: %w", err)
+	}
+	r.cache.Delete(chatID)
+	return nil
+}
+
+func (r *CachedScheduleRepository) GetScheduledChatIDs() ([]int64, error) {
+	var chatIDs []int64
+	if err := r.db.Model(&ScheduleRule{}).Distinct("chat_id").Pluck("chat_id", &chatIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get scheduled chats: %w", err)
+	}
+	return chatIDs, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StrikeLadderRepository interface {
+	GetSteps(chatID int64) ([]StrikeStep, error)
+	AddStep(step *StrikeStep) error
+	DeleteStep(chatID int64, stepID uint) error
+	GetWeights(chatID int64) (map[string]int, error)
+	SetWeight(chatID int64, violationType string, weight int) error
+}
+
+type PostgresStrikeLadderRepository struct {
+	db *gorm.DB
+}
+
+func NewStrikeLadderRepository(db *gorm.DB) StrikeLadderRepository {
+	return &PostgresStrikeLadderRepository{db: db}
+}
+
+func (r *PostgresStrikeLadderRepository) GetSteps(chatID int64) ([]StrikeStep, error) {
+	var steps []StrikeStep
+	if err := r.db.Where("chat_id = ?", chatID).Order("strikes ASC, id ASC").Find(&steps).Error; err != nil {
+		return nil, fmt.Errorf("failed to get strike steps: %w", err)
+	}
+	return steps, nil
+}
+
+func (r *PostgresStrikeLadderRepository) AddStep(step *StrikeStep) error {
+	if err := r.db.Create(step).Error; err != nil {
+		return fmt.Errorf("failed to add strike step: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresStrikeLadderRepository) DeleteStep(chatID int64, stepID uint) error {
+	if err := r.db.Where("chat_id = ? AND id = ?", chatID, stepID).Delete(&StrikeStep{}).Error; err != nil {
+		return fmt.Errorf("failed to delete strike step: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresStrikeLadderRepository) GetWeights(chatID int64) (map[string]int, error) {
+	var weights []ViolationWeight
+	if err := r.db.Where("chat_id = ?", chatID).Find(&weights).Error; err != nil {
+		return nil, fmt.Errorf("failed to get violation weights: %w", err)
+	}
+	result := make(map[string]int, len(weights))
+	for _, w := range weights {
+		result[w.ViolationType] = w.Weight
+	}
+	return result, nil
+}
+
+func (r *PostgresStrikeLadderRepository) SetWeight(chatID int64, violationType string, weight int) error {
+	if weight == 1 {
+		if err := r.db.Where("chat_id = ? AND violation_type = ?", chatID, violationType).Delete(&ViolationWeight{}).Error; err != nil {
+			return fmt.Errorf("failed to reset violation weight: %w", err)
+		}
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}, {Name: "violation_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"weight"}),
+	}).Create(&ViolationWeight{ChatID: chatID, ViolationType: violationType, Weight: weight}).Error
+	if err != nil {
+		return fmt.Errorf("failed to set violation weight: %w", err)
+	}
+	return nil
+}
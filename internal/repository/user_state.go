@@ -10,6 +10,7 @@ import (
 
 type UserStateRepository interface {
 	SetState(userID, chatID int64, action string) error
+	SetStateWithPayload(userID, chatID int64, action, payload string) error
 	GetState(userID int64) (*UserState, error)
 	ClearState(userID int64) error
 }
@@ -21,10 +22,14 @@ func NewUserStateRepository(db *gorm.DB) UserStateRepository {
 	return &PostgresUserStateRepository{db: db}
 }
 func (r *PostgresUserStateRepository) SetState(userID, chatID int64, action string) error {
+	return r.SetStateWithPayload(userID, chatID, action, "")
+}
+func (r *PostgresUserStateRepository) SetStateWithPayload(userID, chatID int64, action, payload string) error {
 	state := UserState{
 		UserID:    userID,
 		ChatID:    chatID,
 		Action:    action,
+		Payload:   payload,
 		CreatedAt: time.Now(),
 	}
 	err := r.db.Save(&state).Error
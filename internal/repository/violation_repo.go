@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -10,12 +12,21 @@ import (
 )
 
 type ViolationRepository interface {
-	AddViolation(ctx context.Context, chatID, userID int64, violationType string) error
+	AddViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error
 	CountViolationsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
+	SumViolationWeightsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
+	AddWarning(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error
+	GetViolationsSince(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]UserViolation, error)
+	DeleteLatestViolation(ctx context.Context, chatID, userID int64, violationType string) (*UserViolation, error)
+	DeleteViolationByMessage(ctx context.Context, chatID, userID int64, violationType, messageID string) (*UserViolation, error)
+	ForgiveViolations(ctx context.Context, chatID, userID, moderatorID int64) (int, error)
+	DecayViolations(ctx context.Context, now time.Time) (int, error)
 	IncrementChatStat(ctx context.Context, chatID int64, field string) error
 	GetChatTotalStats(ctx context.Context, chatID int64) (*ChatStats, error)
 }
 
+const ManualWarningType = "manual_warn"
+
 type PostgresViolationRepository struct {
 	db *gorm.DB
 }
@@ -25,18 +36,26 @@ type UserViolation struct {
 	ChatID        int64     `gorm:"not null;index:idx_user_violations_chat_user_created,priority:1"`
 	UserID        int64     `gorm:"not null;index:idx_user_violations_chat_user_created,priority:2"`
 	ViolationType string    `gorm:"size:50"`
+	Weight        int       `gorm:"not null;default:1"`
+	Reason        string    `gorm:"size:500"`
+	ModeratorID   int64     `gorm:"default:0"`
+	MessageID     string    `gorm:"size:100;index"`
 	CreatedAt     time.Time `gorm:"not null;default:now();index:idx_user_violations_chat_user_created,priority:3"`
+	ForgivenAt    *time.Time
+	ForgivenBy    int64 `gorm:"default:0"`
 }
 
 func NewViolationRepository(db *gorm.DB) ViolationRepository {
 	return &PostgresViolationRepository{db: db}
 }
 
-func (r *PostgresViolationRepository) AddViolation(ctx context.Context, chatID, userID int64, violationType string) error {
+func (r *PostgresViolationRepository) AddViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
 	violation := UserViolation{
 		ChatID:        chatID,
 		UserID:        userID,
 		ViolationType: violationType,
+		Weight:        weight,
+		MessageID:     messageID,
 		CreatedAt:     time.Now(),
 	}
 	return r.db.WithContext(ctx).Create(&violation).Error
@@ -45,11 +64,124 @@ func (r *PostgresViolationRepository) AddViolation(ctx context.Context, chatID,
 func (r *PostgresViolationRepository) CountViolationsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&UserViolation{}).
-		Where("chat_id = ? AND user_id = ? AND created_at >= ?", chatID, userID, since).
+		Where("chat_id = ? AND user_id = ? AND created_at >= ? AND forgiven_at IS NULL", chatID, userID, since).
 		Count(&count).Error
 	return int(count), err
 }
 
+func (r *PostgresViolationRepository) SumViolationWeightsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+	var sum int64
+	err := r.db.WithContext(ctx).Model(&UserViolation{}).
+		Select("COALESCE(SUM(weight), 0)").
+		Where("chat_id = ? AND user_id = ? AND created_at >= ? AND forgiven_at IS NULL", chatID, userID, since).
+		Scan(&sum).Error
+	return int(sum), err
+}
+
+func (r *PostgresViolationRepository) AddWarning(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+	violation := UserViolation{
+		ChatID:        chatID,
+		UserID:        userID,
+		ViolationType: ManualWarningType,
+		Weight:        weight,
+		Reason:        reason,
+		ModeratorID:   moderatorID,
+		CreatedAt:     time.Now(),
+	}
+	return r.db.WithContext(ctx).Create(&violation).Error
+}
+
+func (r *PostgresViolationRepository) GetViolationsSince(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]UserViolation, error) {
+	var violations []UserViolation
+	err := r.db.WithContext(ctx).
+		Where("chat_id = ? AND user_id = ? AND violation_type = ? AND created_at >= ? AND forgiven_at IS NULL", chatID, userID, violationType, since).
+		Order("created_at ASC").
+		Find(&violations).Error
+	return violations, err
+}
+
+func (r *PostgresViolationRepository) DeleteLatestViolation(ctx context.Context, chatID, userID int64, violationType string) (*UserViolation, error) {
+	var violation UserViolation
+	err := r.db.WithContext(ctx).
+		Where("chat_id = ? AND user_id = ? AND violation_type = ? AND forgiven_at IS NULL", chatID, userID, violationType).
+		Order("created_at DESC").
+		First(&violation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find latest violation: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Delete(&violation).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete violation: %w", err)
+	}
+	return &violation, nil
+}
+
+func (r *PostgresViolationRepository) DeleteViolationByMessage(ctx context.Context, chatID, userID int64, violationType, messageID string) (*UserViolation, error) {
+	var violation UserViolation
+	err := r.db.WithContext(ctx).
+		Where("chat_id = ? AND user_id = ? AND violation_type = ? AND message_id = ? AND forgiven_at IS NULL", chatID, userID, violationType, messageID).
+		First(&violation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find violation: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Delete(&violation).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete violation: %w", err)
+	}
+	return &violation, nil
+}
+
+func (r *PostgresViolationRepository) ForgiveViolations(ctx context.Context, chatID, userID, moderatorID int64) (int, error) {
+	result := r.db.WithContext(ctx).Model(&UserViolation{}).
+		Where("chat_id = ? AND user_id = ? AND forgiven_at IS NULL", chatID, userID).
+		Updates(map[string]interface{}{"forgiven_at": time.Now(), "forgiven_by": moderatorID})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to forgive violations: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *PostgresViolationRepository) DecayViolations(ctx context.Context, now time.Time) (int, error) {
+	type decayCandidate struct {
+		ChatID int64
+		UserID int64
+	}
+	var candidates []decayCandidate
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT v.chat_id, v.user_id
+		FROM user_violations v
+		JOIN chat_settings s ON s.chat_id = v.chat_id
+		WHERE s.strike_decay_days > 0
+		GROUP BY v.chat_id, v.user_id, s.strike_decay_days
+		HAVING COUNT(*) FILTER (WHERE v.forgiven_at IS NULL) > 0
+			AND GREATEST(MAX(v.created_at), COALESCE(MAX(v.forgiven_at), MAX(v.created_at))) <= ? - make_interval(days => s.strike_decay_days)`, now).
+		Scan(&candidates).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to find decaying violations: %w", err)
+	}
+
+	forgiven := 0
+	for _, c := range candidates {
+		result := r.db.WithContext(ctx).Exec(`
+			UPDATE user_violations SET forgiven_at = ?, forgiven_by = 0
+			WHERE id = (
+				SELECT id FROM user_violations
+				WHERE chat_id = ? AND user_id = ? AND forgiven_at IS NULL
+				ORDER BY created_at ASC
+				LIMIT 1
+			)`, now, c.ChatID, c.UserID)
+		if result.Error != nil {
+			return forgiven, fmt.Errorf("failed to decay violation: %w", result.Error)
+		}
+		forgiven += int(result.RowsAffected)
+	}
+	return forgiven, nil
+}
+
 func (r *PostgresViolationRepository) IncrementChatStat(ctx context.Context, chatID int64, field string) error {
 	slog.Debug("Incrementing chat stat", "chat_id", chatID, "field", field)
 	now := time.Now().Truncate(24 * time.Hour)
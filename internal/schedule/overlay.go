@@ -0,0 +1,30 @@
+package schedule
+
+import (
+	"max-moderation-bot/internal/repository"
+	"time"
+)
+
+type SettingsOverlay struct {
+	repository.SettingsRepository
+	rules repository.ScheduleRepository
+}
+
+func NewSettingsOverlay(settings repository.SettingsRepository, rules repository.ScheduleRepository) *SettingsOverlay {
+	return &SettingsOverlay{
+		SettingsRepository: settings,
+		rules:              rules,
+	}
+}
+
+func (o *SettingsOverlay) GetSettings(chatID int64) (*repository.ChatSettings, error) {
+	settings, err := o.SettingsRepository.GetSettings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := o.rules.GetRules(chatID)
+	if err != nil || len(rules) == 0 {
+		return settings, nil
+	}
+	return Apply(settings, Active(rules, settings.Timezone, time.Now())), nil
+}
@@ -0,0 +1,322 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDays         = errors.New("invalid weekdays")
+	ErrInvalidWindow       = errors.New("invalid time window")
+	ErrInvalidRestrictions = errors.New("invalid restrictions")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+)
+
+const allDays = 1<<7 - 1
+
+var weekdayNames = map[string]time.Weekday{
+	"вс": time.Sunday, "пн": time.Monday, "вт": time.Tuesday, "ср": time.Wednesday,
+	"чт": time.Thursday, "пт": time.Friday, "сб": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var weekdayLabels = [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"}
+
+type Restrictions struct {
+	WordFilter    bool
+	LinkFilter    bool
+	RestrictImage bool
+	RestrictVideo bool
+	RestrictAudio bool
+	RestrictFile  bool
+	ReadOnly      bool
+}
+
+func (r Restrictions) Any() bool {
+	return r.WordFilter || r.LinkFilter || r.RestrictImage || r.RestrictVideo || r.RestrictAudio || r.RestrictFile || r.ReadOnly
+}
+
+func LoadLocation(tz string) (*time.Location, error) {
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		return time.UTC, nil
+	}
+	offset := strings.TrimPrefix(strings.TrimPrefix(strings.ToUpper(tz), "UTC"), "GMT")
+	if strings.HasPrefix(offset, "+") || strings.HasPrefix(offset, "-") {
+		return parseOffset(tz, offset)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, tz)
+	}
+	return loc, nil
+}
+
+func parseOffset(name, offset string) (*time.Location, error) {
+	sign := 1
+	if offset[0] == '-' {
+		sign = -1
+	}
+	offset = offset[1:]
+	if offset == "" || offset[0] == '+' || offset[0] == '-' {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	var hours, minutes int
+	var err error
+	if strings.Contains(offset, ":") {
+		hours, minutes, err = parseClock(offset)
+	} else {
+		hours, err = strconv.Atoi(offset)
+	}
+	if err != nil || hours < 0 || hours > 14 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	return time.FixedZone(name, sign*(hours*3600+minutes*60)), nil
+}
+
+func ParseRule(text string) (repository.ScheduleRule, error) {
+	var rule repository.ScheduleRule
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) < 3 {
+		return rule, fmt.Errorf("%w: expected \"<days> <HH:MM-HH:MM> <restrictions>\"", ErrInvalidRestrictions)
+	}
+	days, err := ParseWeekdays(fields[0])
+	if err != nil {
+		return rule, err
+	}
+	start, end, err := ParseWindow(fields[1])
+	if err != nil {
+		return rule, err
+	}
+	rule.Weekdays = days
+	rule.StartMinute = start
+	rule.EndMinute = end
+
+	tokens := strings.FieldsFunc(strings.Join(fields[2:], ","), func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+	for _, token := range tokens {
+		switch strings.TrimSpace(token) {
+		case "words", "слова":
+			rule.WordFilter = true
+		case "links", "ссылки":
+			rule.LinkFilter = true
+		case "image", "images", "фото", "изображения":
+			rule.RestrictImage = true
+		case "video", "видео":
+			rule.RestrictVideo = true
+		case "audio", "аудио":
+			rule.RestrictAudio = true
+		case "file", "files", "файлы":
+			rule.RestrictFile = true
+		case "media", "медиа":
+			rule.RestrictImage = true
+			rule.RestrictVideo = true
+			rule.RestrictAudio = true
+			rule.RestrictFile = true
+		case "readonly", "read-only", "чтение":
+			rule.ReadOnly = true
+		case "":
+		default:
+			return rule, fmt.Errorf("%w: %s", ErrInvalidRestrictions, token)
+		}
+	}
+	if !ruleRestrictions(rule).Any() {
+		return rule, ErrInvalidRestrictions
+	}
+	return rule, nil
+}
+
+func ParseWeekdays(s string) (int, error) {
+	switch s {
+	case "*", "ежедневно", "daily", "all":
+		return allDays, nil
+	case "будни", "weekdays":
+		return weekdayRange(time.Monday, time.Friday), nil
+	case "выходные", "weekends":
+		return 1<<time.Saturday | 1<<time.Sunday, nil
+	}
+	mask := 0
+	for _, part := range strings.Split(s, ",") {
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			start, okStart := weekdayNames[from]
+			end, okEnd := weekdayNames[to]
+			if !okStart || !okEnd {
+				return 0, fmt.Errorf("%w: %s", ErrInvalidDays, part)
+			}
+			mask |= weekdayRange(start, end)
+			continue
+		}
+		day, ok := weekdayNames[part]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidDays, part)
+		}
+		mask |= 1 << day
+	}
+	return mask, nil
+}
+
+func weekdayRange(from, to time.Weekday) int {
+	mask := 0
+	for d := from; ; d = (d + 1) % 7 {
+		mask |= 1 << d
+		if d == to {
+			break
+		}
+	}
+	return mask
+}
+
+func ParseWindow(s string) (int, int, error) {
+	from, to, ok := strings.Cut(strings.ReplaceAll(s, "–", "-"), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidWindow, s)
+	}
+	startH, startM, err := parseClock(from)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidWindow, s)
+	}
+	endH, endM, err := parseClock(to)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidWindow, s)
+	}
+	start := startH*60 + startM
+	end := endH*60 + endM
+	if start == end || start >= 24*60 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidWindow, s)
+	}
+	return start, end, nil
+}
+
+func parseClock(s string) (int, int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, ErrInvalidWindow
+	}
+	hours, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, ErrInvalidWindow
+	}
+	minutes, err := strconv.Atoi(m)
+	if err != nil {
+		return 0, 0, ErrInvalidWindow
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || hours == 24 && minutes != 0 {
+		return 0, 0, ErrInvalidWindow
+	}
+	return hours, minutes, nil
+}
+
+func IsActive(rule repository.ScheduleRule, now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+	today := now.Weekday()
+	yesterday := (today + 6) % 7
+	hasDay := func(d time.Weekday) bool { return rule.Weekdays&(1<<d) != 0 }
+
+	if rule.StartMinute < rule.EndMinute {
+		return hasDay(today) && minute >= rule.StartMinute && minute < rule.EndMinute
+	}
+	return hasDay(today) && minute >= rule.StartMinute || hasDay(yesterday) && minute < rule.EndMinute
+}
+
+func Active(rules []repository.ScheduleRule, tz string, now time.Time) Restrictions {
+	loc, err := LoadLocation(tz)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	var merged Restrictions
+	for _, rule := range rules {
+		if !IsActive(rule, local) {
+			continue
+		}
+		r := ruleRestrictions(rule)
+		merged.WordFilter = merged.WordFilter || r.WordFilter
+		merged.LinkFilter = merged.LinkFilter || r.LinkFilter
+		merged.RestrictImage = merged.RestrictImage || r.RestrictImage
+		merged.RestrictVideo = merged.RestrictVideo || r.RestrictVideo
+		merged.RestrictAudio = merged.RestrictAudio || r.RestrictAudio
+		merged.RestrictFile = merged.RestrictFile || r.RestrictFile
+		merged.ReadOnly = merged.ReadOnly || r.ReadOnly
+	}
+	return merged
+}
+
+func Apply(settings *repository.ChatSettings, r Restrictions) *repository.ChatSettings {
+	if !r.Any() {
+		return settings
+	}
+	effective := *settings
+	effective.EnableWordFilter = effective.EnableWordFilter || r.WordFilter
+	effective.EnableLinkFilter = effective.EnableLinkFilter || r.LinkFilter
+	effective.RestrictImage = effective.RestrictImage || r.RestrictImage
+	effective.RestrictVideo = effective.RestrictVideo || r.RestrictVideo
+	effective.RestrictAudio = effective.RestrictAudio || r.RestrictAudio
+	effective.RestrictFile = effective.RestrictFile || r.RestrictFile
+	return &effective
+}
+
+func ruleRestrictions(rule repository.ScheduleRule) Restrictions {
+	return Restrictions{
+		WordFilter:    rule.WordFilter,
+		LinkFilter:    rule.LinkFilter,
+		RestrictImage: rule.RestrictImage,
+		RestrictVideo: rule.RestrictVideo,
+		RestrictAudio: rule.RestrictAudio,
+		RestrictFile:  rule.RestrictFile,
+		ReadOnly:      rule.ReadOnly,
+	}
+}
+
+func FormatRule(rule repository.ScheduleRule) string {
+	var items []string
+	if rule.ReadOnly {
+		items = append(items, "только чтение")
+	}
+	if rule.WordFilter {
+		items = append(items, "фильтр слов")
+	}
+	if rule.LinkFilter {
+		items = append(items, "фильтр ссылок")
+	}
+	if rule.RestrictImage {
+		items = append(items, "изображения")
+	}
+	if rule.RestrictVideo {
+		items = append(items, "видео")
+	}
+	if rule.RestrictAudio {
+		items = append(items, "аудио")
+	}
+	if rule.RestrictFile {
+		items = append(items, "файлы")
+	}
+	return fmt.Sprintf("%s %s–%s: %s", FormatWeekdays(rule.Weekdays), formatMinute(rule.StartMinute), formatMinute(rule.EndMinute), strings.Join(items, ", "))
+}
+
+func FormatWeekdays(mask int) string {
+	switch mask & allDays {
+	case allDays:
+		return "ежедневно"
+	case weekdayRange(time.Monday, time.Friday):
+		return "пн-пт"
+	case 1<<time.Saturday | 1<<time.Sunday:
+		return "сб,вс"
+	}
+	var days []string
+	for _, d := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if mask&(1<<d) != 0 {
+			days = append(days, weekdayLabels[d])
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+func formatMinute(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
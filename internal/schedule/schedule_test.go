@@ -0,0 +1,143 @@
+package schedule
+
+import (
+	"max-moderation-bot/internal/repository"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    repository.ScheduleRule
+		wantErr bool
+	}{
+		{
+			name:  "Overnight media restriction",
+			input: "ежедневно 23:00-08:00 медиа",
+			want: repository.ScheduleRule{
+				Weekdays: allDays, StartMinute: 23 * 60, EndMinute: 8 * 60,
+				RestrictImage: true, RestrictVideo: true, RestrictAudio: true, RestrictFile: true,
+			},
+		},
+		{
+			name:  "Weekend read-only",
+			input: "сб,вс 00:00-24:00 чтение",
+			want: repository.ScheduleRule{
+				Weekdays: 1<<time.Saturday | 1<<time.Sunday, StartMinute: 0, EndMinute: 24 * 60, ReadOnly: true,
+			},
+		},
+		{
+			name:  "Weekday range with several restrictions",
+			input: "пн-пт 09:00-18:00 links, image",
+			want: repository.ScheduleRule{
+				Weekdays: weekdayRange(time.Monday, time.Friday), StartMinute: 9 * 60, EndMinute: 18 * 60,
+				LinkFilter: true, RestrictImage: true,
+			},
+		},
+		{name: "Unknown restriction", input: "пн 09:00-10:00 stickers", wantErr: true},
+		{name: "Bad window", input: "пн 25:00-10:00 медиа", wantErr: true},
+		{name: "Bad day", input: "xx 09:00-10:00 медиа", wantErr: true},
+		{name: "Too short", input: "пн 09:00-10:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRule(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseRule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsActive(t *testing.T) {
+	overnight := repository.ScheduleRule{Weekdays: 1 << time.Friday, StartMinute: 23 * 60, EndMinute: 8 * 60}
+	weekend := repository.ScheduleRule{Weekdays: 1<<time.Saturday | 1<<time.Sunday, StartMinute: 0, EndMinute: 24 * 60}
+
+	tests := []struct {
+		name string
+		rule repository.ScheduleRule
+		at   time.Time
+		want bool
+	}{
+		{"Before overnight window", overnight, time.Date(2025, 12, 19, 22, 59, 0, 0, time.UTC), false},
+		{"Inside overnight window on start day", overnight, time.Date(2025, 12, 19, 23, 30, 0, 0, time.UTC), true},
+		{"Overnight window spills into next day", overnight, time.Date(2025, 12, 20, 7, 59, 0, 0, time.UTC), true},
+		{"After overnight window", overnight, time.Date(2025, 12, 20, 8, 0, 0, 0, time.UTC), false},
+		{"Overnight window on unlisted day", overnight, time.Date(2025, 12, 18, 23, 30, 0, 0, time.UTC), false},
+		{"Whole weekend day", weekend, time.Date(2025, 12, 21, 12, 0, 0, 0, time.UTC), true},
+		{"Monday after weekend", weekend, time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsActive(tt.rule, tt.at); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveUsesTimezone(t *testing.T) {
+	rules := []repository.ScheduleRule{
+		{Weekdays: allDays, StartMinute: 23 * 60, EndMinute: 8 * 60, RestrictImage: true},
+	}
+	now := time.Date(2025, 12, 19, 21, 0, 0, 0, time.UTC)
+
+	if got := Active(rules, "UTC", now); got.Any() {
+		t.Errorf("Active() in UTC = %+v, want no restrictions", got)
+	}
+	if got := Active(rules, "UTC+3", now); !got.RestrictImage {
+		t.Errorf("Active() in UTC+3 = %+v, want image restriction", got)
+	}
+	if got := Active(rules, "Europe/Moscow", now); !got.RestrictImage {
+		t.Errorf("Active() in Europe/Moscow = %+v, want image restriction", got)
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		tz         string
+		wantOffset int
+		wantErr    bool
+	}{
+		{tz: "", wantOffset: 0},
+		{tz: "UTC+3", wantOffset: 3 * 3600},
+		{tz: "GMT-5:30", wantOffset: -(5*3600 + 30*60)},
+		{tz: "UTC+-3", wantErr: true},
+		{tz: "UTC-+3", wantErr: true},
+		{tz: "UTC+", wantErr: true},
+		{tz: "UTC+15", wantErr: true},
+		{tz: "UTC+3:-30", wantErr: true},
+		{tz: "Mars/Olympus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tz, func(t *testing.T) {
+			loc, err := LoadLocation(tt.tz)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLocation(%q) error = %v, wantErr %v", tt.tz, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, offset := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone(); offset != tt.wantOffset {
+				t.Errorf("LoadLocation(%q) offset = %d, want %d", tt.tz, offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	settings := &repository.ChatSettings{ChatID: 1, EnableWordFilter: false}
+	effective := Apply(settings, Restrictions{WordFilter: true, RestrictVideo: true})
+
+	if !effective.EnableWordFilter || !effective.RestrictVideo {
+		t.Errorf("Apply() = %+v, want word filter and video restriction", effective)
+	}
+	if settings.EnableWordFilter || settings.RestrictVideo {
+		t.Errorf("Apply() modified original settings")
+	}
+}
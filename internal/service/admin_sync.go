@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"sync"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+var ErrNoChatAdmins = errors.New("chat admin list is empty")
+
+const adminSyncDebounce = 30 * time.Second
+
+type adminSyncTracker struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+type AdminSyncResult struct {
+	Granted  []int64
+	Pending  []int64
+	Restored []int64
+	Revoked  []int64
+}
+
+func (s *ModerationService) SyncChatAdmins(ctx context.Context, chatID int64, grace time.Duration) (*AdminSyncResult, error) {
+	ctx, span := s.tracer.Start(ctx, "SyncChatAdmins")
+	defer span.End()
+
+	if s.bot == nil {
+		return nil, fmt.Errorf("bot client not initialized in service")
+	}
+	list, err := s.bot.Chats.GetChatAdmins(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat admins: %w", err)
+	}
+	return s.syncChatAdmins(ctx, chatID, list.Members, grace, time.Now())
+}
+
+func (s *ModerationService) SyncChatAdminsOnChange(ctx context.Context, chatID int64, grace time.Duration) error {
+	ctx, span := s.tracer.Start(ctx, "SyncChatAdminsOnChange")
+	defer span.End()
+
+	rows, err := s.chatAdminRepo.GetChatAdmins(chatID)
+	if err != nil {
+		return fmt.Errorf("failed to get chat admins: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	wait, ok := s.claimAdminSync(chatID, time.Now())
+	if !ok {
+		return nil
+	}
+	if wait > 0 {
+		time.AfterFunc(wait, func() {
+			if _, err := s.SyncChatAdmins(context.Background(), chatID, grace); err != nil {
+				s.logger.Warn("Failed to run deferred chat admin sync", "chat_id", chatID, "error", err)
+			}
+		})
+		return nil
+	}
+	_, err = s.SyncChatAdmins(ctx, chatID, grace)
+	return err
+}
+
+func (s *ModerationService) claimAdminSync(chatID int64, now time.Time) (time.Duration, bool) {
+	value, _ := s.adminSyncState.LoadOrStore(chatID, &adminSyncTracker{})
+	tracker := value.(*adminSyncTracker)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if now.Before(tracker.next) {
+		return 0, false
+	}
+	if tracker.next.IsZero() || now.Sub(tracker.next) >= adminSyncDebounce {
+		tracker.next = now
+		return 0, true
+	}
+	tracker.next = tracker.next.Add(adminSyncDebounce)
+	return tracker.next.Sub(now), true
+}
+
+func (s *ModerationService) syncChatAdmins(ctx context.Context, chatID int64, members []schemes.ChatMember, grace time.Duration, now time.Time) (*AdminSyncResult, error) {
+	chatAdmins := make(map[int64]bool, len(members))
+	var chatOwner int64
+	for _, member := range members {
+		if member.IsBot || !(member.IsAdmin || member.IsOwner) {
+			continue
+		}
+		chatAdmins[member.UserId] = true
+		if member.IsOwner {
+			chatOwner = member.UserId
+		}
+	}
+	if len(chatAdmins) == 0 {
+		return nil, ErrNoChatAdmins
+	}
+
+	rows, err := s.chatAdminRepo.GetChatAdmins(chatID)
+	if err != nil {
+		return nil, err
+	}
+	result := &AdminSyncResult{}
+	linked := make(map[int64]repository.ChatAdmin, len(rows))
+	for _, row := range rows {
+		linked[row.UserID] = row
+	}
+
+	for _, row := range rows {
+		if row.Role != repository.RoleOwner {
+			continue
+		}
+		if chatAdmins[row.UserID] {
+			if row.DemotedAt != nil {
+				if err := s.chatAdminRepo.ClearDemoted(chatID, row.UserID); err != nil {
+					return result, err
+				}
+				result.Restored = append(result.Restored, row.UserID)
+				s.notifyAdminSync(ctx, chatID, row.UserID, messages.MsgAdminSyncRestored)
+			}
+			continue
+		}
+		if row.DemotedAt == nil && grace > 0 {
+			if err := s.chatAdminRepo.MarkDemoted(chatID, row.UserID, now); err != nil {
+				return result, err
+			}
+			result.Pending = append(result.Pending, row.UserID)
+			s.notifyAdminSync(ctx, chatID, row.UserID, fmt.Sprintf(messages.MsgAdminSyncPending, now.Add(grace).Format("02.01.2006 15:04")))
+			continue
+		}
+		if row.DemotedAt != nil && now.Sub(*row.DemotedAt) < grace {
+			continue
+		}
+		revoked, err := s.revokeSyncedAdmin(ctx, chatID, row.UserID, rows)
+		result.Revoked = append(result.Revoked, revoked...)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if chatOwner != 0 && linked[chatOwner].Role != repository.RoleOwner {
+		if err := s.chatAdminRepo.AddAdminWithRole(chatID, chatOwner, repository.RoleOwner, 0); err != nil {
+			return result, err
+		}
+		result.Granted = append(result.Granted, chatOwner)
+		s.audit(repository.AuditEntry{ChatID: chatID, TargetUserID: chatOwner, Action: repository.AuditActionLink, Reason: "admin sync: granted"})
+		s.notifyAdminSync(ctx, chatID, chatOwner, messages.MsgAdminSyncGranted)
+	}
+	return result, nil
+}
+
+func (s *ModerationService) revokeSyncedAdmin(ctx context.Context, chatID, userID int64, rows []repository.ChatAdmin) ([]int64, error) {
+	if err := s.chatAdminRepo.RemoveAdmin(chatID, userID); err != nil {
+		return nil, err
+	}
+	revoked := []int64{userID}
+	s.audit(repository.AuditEntry{ChatID: chatID, TargetUserID: userID, Action: repository.AuditActionLink, Reason: "admin sync: revoked"})
+	s.notifyAdminSync(ctx, chatID, userID, messages.MsgAdminSyncRevoked)
+
+	for _, row := range rows {
+		if row.InvitedBy != userID || row.Role == repository.RoleOwner {
+			continue
+		}
+		if err := s.chatAdminRepo.RemoveAdmin(chatID, row.UserID); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, row.UserID)
+		s.audit(repository.AuditEntry{ChatID: chatID, TargetUserID: row.UserID, Action: repository.AuditActionLink, Reason: "admin sync: inviter revoked"})
+		s.notifyAdminSync(ctx, chatID, row.UserID, messages.MsgAdminSyncInviterRevoked)
+	}
+	return revoked, nil
+}
+
+func (s *ModerationService) notifyAdminSync(ctx context.Context, chatID, userID int64, text string) {
+	if s.notifier == nil {
+		return
+	}
+	header := fmt.Sprintf(messages.MsgNotifyHeader, s.chatTitle(ctx, chatID))
+	if err := s.notifier.Notify(ctx, userID, header+text, nil); err != nil {
+		s.logger.Warn("Failed to send admin sync notification", "chat_id", chatID, "user_id", userID, "error", err)
+	}
+}
+
+func (s *ModerationService) syncAllChatAdmins(ctx context.Context, grace time.Duration) {
+	chatIDs, err := s.chatAdminRepo.GetLinkedChats()
+	if err != nil {
+		s.logger.Error("Failed to get linked chats for admin sync", "error", err)
+		return
+	}
+	for _, chatID := range chatIDs {
+		result, err := s.SyncChatAdmins(ctx, chatID, grace)
+		if err != nil {
+			s.logger.Warn("Failed to sync chat admins", "chat_id", chatID, "error", err)
+			continue
+		}
+		if len(result.Granted)+len(result.Pending)+len(result.Restored)+len(result.Revoked) > 0 {
+			s.logger.Info("Chat admins synced", "chat_id", chatID,
+				"granted", result.Granted, "pending", result.Pending, "restored", result.Restored, "revoked", result.Revoked)
+		}
+	}
+}
+
+func (s *ModerationService) StartAdminSyncTask(ctx context.Context, interval, grace time.Duration) {
+	if interval <= 0 || s.bot == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		s.syncAllChatAdmins(ctx, grace)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.syncAllChatAdmins(ctx, grace)
+			}
+		}
+	}()
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+func adminRowsRepo(rows map[int64]*repository.ChatAdmin) *MockChatAdminRepository {
+	return &MockChatAdminRepository{
+		GetRoleFunc: func(chatID, userID int64) (string, error) {
+			if row, ok := rows[userID]; ok {
+				return row.Role, nil
+			}
+			return "", nil
+		},
+		GetChatAdminsFunc: func(chatID int64) ([]repository.ChatAdmin, error) {
+			var admins []repository.ChatAdmin
+			for _, row := range rows {
+				admins = append(admins, *row)
+			}
+			sort.Slice(admins, func(i, j int) bool { return admins[i].UserID < admins[j].UserID })
+			return admins, nil
+		},
+		MarkDemotedFunc: func(chatID, userID int64, at time.Time) error {
+			rows[userID].DemotedAt = &at
+			return nil
+		},
+		ClearDemotedFunc: func(chatID, userID int64) error {
+			rows[userID].DemotedAt = nil
+			return nil
+		},
+		RemoveAdminFunc: func(chatID, userID int64) error {
+			delete(rows, userID)
+			return nil
+		},
+		AddAdminWithRoleFunc: func(chatID, userID int64, role string, invitedBy int64) error {
+			rows[userID] = &repository.ChatAdmin{ChatID: chatID, UserID: userID, Role: role, InvitedBy: invitedBy}
+			return nil
+		},
+	}
+}
+
+func TestModerationService_SyncChatAdmins(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	now := time.Date(2025, 12, 23, 12, 0, 0, 0, time.UTC)
+	grace := 24 * time.Hour
+	expired := now.Add(-25 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		rows     map[int64]*repository.ChatAdmin
+		members  []schemes.ChatMember
+		grace    time.Duration
+		want     AdminSyncResult
+		wantRows []int64
+		notified []int64
+	}{
+		{
+			name: "Still admin",
+			rows: map[int64]*repository.ChatAdmin{1: {UserID: 1, Role: repository.RoleOwner}},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			grace:    grace,
+			wantRows: []int64{1},
+		},
+		{
+			name: "Demoted starts grace period",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner},
+				2: {UserID: 2, Role: repository.RoleOwner},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			grace:    grace,
+			want:     AdminSyncResult{Pending: []int64{2}},
+			wantRows: []int64{1, 2},
+			notified: []int64{2},
+		},
+		{
+			name: "Grace period not over",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner},
+				2: {UserID: 2, Role: repository.RoleOwner, DemotedAt: &recent},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			grace:    grace,
+			wantRows: []int64{1, 2},
+		},
+		{
+			name: "Grace period over revokes delegates",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner},
+				2: {UserID: 2, Role: repository.RoleOwner, DemotedAt: &expired},
+				3: {UserID: 3, Role: repository.RoleModerator, InvitedBy: 2},
+				4: {UserID: 4, Role: repository.RoleViewer, InvitedBy: 1},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			grace:    grace,
+			want:     AdminSyncResult{Revoked: []int64{2, 3}},
+			wantRows: []int64{1, 4},
+			notified: []int64{2, 3},
+		},
+		{
+			name: "Zero grace revokes immediately",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner},
+				2: {UserID: 2, Role: repository.RoleOwner},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			want:     AdminSyncResult{Revoked: []int64{2}},
+			wantRows: []int64{1},
+			notified: []int64{2},
+		},
+		{
+			name: "Promoted again restores",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner, DemotedAt: &recent},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 1, IsOwner: true, IsAdmin: true},
+			},
+			grace:    grace,
+			want:     AdminSyncResult{Restored: []int64{1}},
+			wantRows: []int64{1},
+			notified: []int64{1},
+		},
+		{
+			name: "New chat owner granted",
+			rows: map[int64]*repository.ChatAdmin{
+				1: {UserID: 1, Role: repository.RoleOwner, DemotedAt: &expired},
+				5: {UserID: 5, Role: repository.RoleViewer, InvitedBy: 9},
+			},
+			members: []schemes.ChatMember{
+				{UserId: 5, IsOwner: true, IsAdmin: true},
+				{UserId: 6, IsAdmin: true},
+				{UserId: 7, IsAdmin: true, IsBot: true},
+			},
+			grace:    grace,
+			want:     AdminSyncResult{Granted: []int64{5}, Revoked: []int64{1}},
+			wantRows: []int64{5},
+			notified: []int64{1, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewModerationService(logger, nil, adminRowsRepo(tt.rows), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+			notifier := &fakeNotifier{}
+			svc.notifier = notifier
+
+			result, err := svc.syncChatAdmins(context.Background(), 100, tt.members, tt.grace, now)
+			if err != nil {
+				t.Fatalf("syncChatAdmins() error = %v", err)
+			}
+			if !reflect.DeepEqual(*result, tt.want) {
+				t.Errorf("result = %+v, want %+v", *result, tt.want)
+			}
+			var rows []int64
+			for userID := range tt.rows {
+				rows = append(rows, userID)
+			}
+			sort.Slice(rows, func(i, j int) bool { return rows[i] < rows[j] })
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", rows, tt.wantRows)
+			}
+			var notified []int64
+			for userID := range notifier.sent {
+				notified = append(notified, userID)
+			}
+			sort.Slice(notified, func(i, j int) bool { return notified[i] < notified[j] })
+			if !reflect.DeepEqual(notified, tt.notified) {
+				t.Errorf("notified = %v, want %v", notified, tt.notified)
+			}
+		})
+	}
+}
+
+func TestModerationService_SyncChatAdmins_EmptyList(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	rows := map[int64]*repository.ChatAdmin{1: {UserID: 1, Role: repository.RoleOwner}}
+	svc := NewModerationService(logger, nil, adminRowsRepo(rows), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+
+	members := []schemes.ChatMember{{UserId: 7, IsAdmin: true, IsBot: true}}
+	if _, err := svc.syncChatAdmins(context.Background(), 100, members, 0, time.Now()); !errors.Is(err, ErrNoChatAdmins) {
+		t.Errorf("syncChatAdmins() error = %v, want ErrNoChatAdmins", err)
+	}
+	if len(rows) != 1 {
+		t.Error("empty admin list must not revoke access")
+	}
+}
+
+func TestModerationService_SyncChatAdminsOnChange(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	rows := map[int64]*repository.ChatAdmin{}
+	svc := NewModerationService(logger, nil, adminRowsRepo(rows), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.SyncChatAdminsOnChange(context.Background(), 100, time.Hour); err != nil {
+		t.Errorf("unlinked chat sync error = %v, want nil", err)
+	}
+	rows[1] = &repository.ChatAdmin{UserID: 1, Role: repository.RoleModerator}
+	if err := svc.SyncChatAdminsOnChange(context.Background(), 100, time.Hour); err == nil {
+		t.Error("linked chat sync should query the chat admins")
+	}
+}
+
+func TestModerationService_ClaimAdminSync(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+	start := time.Date(2025, 12, 23, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name     string
+		at       time.Duration
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{name: "First change syncs now", at: 0, wantOK: true},
+		{name: "Change in window is deferred", at: 5 * time.Second, wantWait: 25 * time.Second, wantOK: true},
+		{name: "Deferred sync already pending", at: 10 * time.Second, wantOK: false},
+		{name: "Change after deferred sync is deferred again", at: 40 * time.Second, wantWait: 20 * time.Second, wantOK: true},
+		{name: "Quiet chat syncs now", at: 2 * time.Minute, wantOK: true},
+	}
+	for _, step := range steps {
+		wait, ok := svc.claimAdminSync(100, start.Add(step.at))
+		if wait != step.wantWait || ok != step.wantOK {
+			t.Errorf("%s: claimAdminSync() = %v, %v, want %v, %v", step.name, wait, ok, step.wantWait, step.wantOK)
+		}
+	}
+	if _, ok := svc.claimAdminSync(200, start.Add(5*time.Second)); !ok {
+		t.Error("debounce must be tracked per chat")
+	}
+}
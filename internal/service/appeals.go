@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"sort"
+	"time"
+)
+
+const (
+	SanctionMute = "mute"
+	SanctionBan  = "ban"
+	SanctionKick = "kick"
+)
+
+const permanentReduction = 7 * 24 * time.Hour
+
+var (
+	ErrAppealNoSanction  = errors.New("user has no such active sanction")
+	ErrAppealAlreadyOpen = errors.New("appeal for this sanction is already open")
+	ErrAppealResolved    = errors.New("appeal is already resolved")
+)
+
+type AppealCooldownError struct {
+	Remaining time.Duration
+}
+
+func (e *AppealCooldownError) Error() string {
+	return fmt.Sprintf("appeal cooldown active for %s", e.Remaining)
+}
+
+type Sanction struct {
+	ChatID    int64
+	Type      string
+	Reason    string
+	ExpiresAt time.Time
+}
+
+func (s *ModerationService) GetUserSanctions(ctx context.Context, userID int64) ([]Sanction, error) {
+	_, span := s.tracer.Start(ctx, "GetUserSanctions")
+	defer span.End()
+
+	mutes, err := s.muteRepo.GetActiveMutesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	bans, err := s.banRepo.GetActiveBansByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sanctions := make([]Sanction, 0, len(mutes)+len(bans))
+	for _, m := range mutes {
+		sanctions = append(sanctions, Sanction{ChatID: m.ChatID, Type: SanctionMute, Reason: m.Reason, ExpiresAt: m.ExpiresAt})
+	}
+	for _, b := range bans {
+		sanctions = append(sanctions, Sanction{ChatID: b.ChatID, Type: SanctionBan, ExpiresAt: b.ExpiresAt})
+	}
+	sort.SliceStable(sanctions, func(i, j int) bool {
+		return sanctions[i].ExpiresAt.Before(sanctions[j].ExpiresAt)
+	})
+	return sanctions, nil
+}
+
+func (s *ModerationService) CheckAppeal(ctx context.Context, chatID, userID int64, sanctionType string, cooldown time.Duration) error {
+	_, span := s.tracer.Start(ctx, "CheckAppeal")
+	defer span.End()
+
+	active, _, err := s.sanctionExpiry(chatID, userID, sanctionType)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return ErrAppealNoSanction
+	}
+
+	open, err := s.appealRepo.GetOpen(chatID, userID, sanctionType)
+	if err != nil {
+		return err
+	}
+	if open != nil {
+		return ErrAppealAlreadyOpen
+	}
+
+	latest, err := s.appealRepo.GetLatestByUser(userID)
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		if elapsed := time.Since(latest.CreatedAt); elapsed < cooldown {
+			return &AppealCooldownError{Remaining: cooldown - elapsed}
+		}
+	}
+	return nil
+}
+
+func (s *ModerationService) FileAppeal(ctx context.Context, chatID, userID int64, userName, sanctionType, text string, cooldown time.Duration) (*repository.Appeal, []int64, error) {
+	ctx, span := s.tracer.Start(ctx, "FileAppeal")
+	defer span.End()
+
+	if err := s.CheckAppeal(ctx, chatID, userID, sanctionType, cooldown); err != nil {
+		return nil, nil, err
+	}
+
+	runes := []rune(text)
+	if len(runes) > 2000 {
+		text = string(runes[:2000])
+	}
+
+	appeal := &repository.Appeal{
+		ChatID:       chatID,
+		UserID:       userID,
+		UserName:     userName,
+		SanctionType: sanctionType,
+		Text:         text,
+		Status:       repository.AppealStatusOpen,
+	}
+	created, err := s.appealRepo.Create(appeal)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !created {
+		return nil, nil, ErrAppealAlreadyOpen
+	}
+
+	admins, err := s.adminsWithRole(chatID, repository.RoleModerator)
+	if err != nil {
+		return appeal, nil, err
+	}
+	return appeal, admins, nil
+}
+
+func (s *ModerationService) GetAppeal(ctx context.Context, appealID uint) (*repository.Appeal, error) {
+	_, span := s.tracer.Start(ctx, "GetAppeal")
+	defer span.End()
+	return s.appealRepo.Get(appealID)
+}
+
+func (s *ModerationService) ResolveAppeal(ctx context.Context, appealID uint, adminID int64, decision string) (*repository.Appeal, time.Time, error) {
+	ctx, span := s.tracer.Start(ctx, "ResolveAppeal")
+	defer span.End()
+
+	switch decision {
+	case repository.AppealStatusApproved, repository.AppealStatusRejected, repository.AppealStatusReduced:
+	default:
+		return nil, time.Time{}, fmt.Errorf("unknown appeal decision: %s", decision)
+	}
+
+	appeal, err := s.appealRepo.Get(appealID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if appeal == nil {
+		return nil, time.Time{}, fmt.Errorf("appeal %d not found", appealID)
+	}
+
+	if err := s.requireRole(appeal.ChatID, adminID, repository.RoleModerator); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	resolved, err := s.appealRepo.Resolve(appealID, decision, adminID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !resolved {
+		return appeal, time.Time{}, ErrAppealResolved
+	}
+	appeal.Status = decision
+	appeal.ResolvedBy = adminID
+
+	switch decision {
+	case repository.AppealStatusApproved:
+		if appeal.SanctionType == SanctionBan {
+			return appeal, time.Time{}, s.liftBan(appeal.ChatID, appeal.UserID, adminID, repository.SanctionEndAppeal)
+		}
+		return appeal, time.Time{}, s.liftMute(ctx, appeal.ChatID, appeal.UserID, adminID, repository.SanctionEndAppeal)
+	case repository.AppealStatusReduced:
+		active, expiresAt, err := s.sanctionExpiry(appeal.ChatID, appeal.UserID, appeal.SanctionType)
+		if err != nil || !active {
+			return appeal, time.Time{}, err
+		}
+		now := time.Now()
+		newExpiry := reducedExpiry(expiresAt, now)
+		if appeal.SanctionType == SanctionBan {
+			err = s.banRepo.SetExpiry(appeal.ChatID, appeal.UserID, newExpiry)
+		} else {
+			err = s.muteRepo.SetExpiry(appeal.ChatID, appeal.UserID, newExpiry)
+		}
+		if err != nil {
+			return appeal, newExpiry, err
+		}
+		s.endSanction(appeal.ChatID, appeal.UserID, appeal.SanctionType, adminID, repository.SanctionEndReduced)
+		s.recordSanction(appeal.ChatID, appeal.UserID, appeal.UserName, appeal.SanctionType, "", SanctionSourceAppeal, adminID, newExpiry.Sub(now))
+		return appeal, newExpiry, nil
+	}
+	return appeal, time.Time{}, nil
+}
+
+func (s *ModerationService) closeAppeals(chatID, userID int64, sanctionType string) {
+	if s.appealRepo == nil {
+		return
+	}
+	if err := s.appealRepo.CloseOpen(chatID, userID, sanctionType); err != nil {
+		s.logger.Error("Failed to close open appeals", "chat_id", chatID, "user_id", userID, "type", sanctionType, "error", err)
+	}
+}
+
+func (s *ModerationService) sanctionExpiry(chatID, userID int64, sanctionType string) (bool, time.Time, error) {
+	switch sanctionType {
+	case SanctionMute:
+		return s.muteRepo.IsMuted(chatID, userID)
+	case SanctionBan:
+		return s.banRepo.IsBanned(chatID, userID)
+	}
+	return false, time.Time{}, nil
+}
+
+func reducedExpiry(expiresAt, now time.Time) time.Time {
+	if expiresAt.Equal(repository.PermanentExpiry) {
+		return now.Add(permanentReduction)
+	}
+	remaining := expiresAt.Sub(now)
+	if remaining <= 0 {
+		return expiresAt
+	}
+	return now.Add(remaining / 2)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestModerationService_CheckAppeal(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name         string
+		muted        bool
+		open         *repository.Appeal
+		latest       *repository.Appeal
+		wantErr      error
+		wantCooldown bool
+	}{
+		{name: "Allowed", muted: true},
+		{name: "No sanction", muted: false, wantErr: ErrAppealNoSanction},
+		{name: "Already open", muted: true, open: &repository.Appeal{ID: 1}, wantErr: ErrAppealAlreadyOpen},
+		{name: "Cooldown", muted: true, latest: &repository.Appeal{ID: 2, CreatedAt: time.Now().Add(-10 * time.Minute)}, wantCooldown: true},
+		{name: "Cooldown passed", muted: true, latest: &repository.Appeal{ID: 3, CreatedAt: time.Now().Add(-2 * time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			muteRepo := &MockMuteRepository{
+				IsMutedFunc: func(chatID, userID int64) (bool, time.Time, error) {
+					return tt.muted, time.Now().Add(time.Hour), nil
+				},
+			}
+			appealRepo := &MockAppealRepository{
+				GetOpenFunc: func(chatID, userID int64, sanctionType string) (*repository.Appeal, error) {
+					return tt.open, nil
+				},
+				GetLatestByUserFunc: func(userID int64) (*repository.Appeal, error) {
+					return tt.latest, nil
+				},
+			}
+			svc := NewModerationService(logger, nil, nil, nil, muteRepo, nil, &MockViolationRepository{}, nil, nil, &MockBanRepository{}, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			err := svc.CheckAppeal(context.Background(), 100, 456, SanctionMute, time.Hour)
+
+			var cooldownErr *AppealCooldownError
+			if tt.wantCooldown {
+				if !errors.As(err, &cooldownErr) || cooldownErr.Remaining <= 0 {
+					t.Errorf("CheckAppeal() error = %v, want cooldown error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckAppeal() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModerationService_ResolveAppeal(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Approve lifts ban", func(t *testing.T) {
+		unbanned := false
+		banRepo := &MockBanRepository{
+			UnbanUserFunc: func(chatID, userID int64) error {
+				unbanned = true
+				return nil
+			},
+		}
+		appealRepo := &MockAppealRepository{
+			GetFunc: func(id uint) (*repository.Appeal, error) {
+				return &repository.Appeal{ID: id, ChatID: 100, UserID: 456, SanctionType: SanctionBan}, nil
+			},
+		}
+		adminRepo := &MockChatAdminRepository{
+			IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+		}
+		svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		if _, _, err := svc.ResolveAppeal(context.Background(), 1, 7, repository.AppealStatusApproved); err != nil {
+			t.Fatalf("ResolveAppeal() error = %v", err)
+		}
+		if !unbanned {
+			t.Error("ResolveAppeal() did not lift the ban")
+		}
+	})
+
+	t.Run("Second decision is rejected", func(t *testing.T) {
+		appealRepo := &MockAppealRepository{
+			GetFunc: func(id uint) (*repository.Appeal, error) {
+				return &repository.Appeal{ID: id, ChatID: 100, UserID: 456, SanctionType: SanctionMute}, nil
+			},
+			ResolveFunc: func(id uint, status string, resolvedBy int64) (bool, error) {
+				return false, nil
+			},
+		}
+		adminRepo := &MockChatAdminRepository{
+			IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+		}
+		svc := NewModerationService(logger, nil, adminRepo, nil, &MockMuteRepository{}, nil, &MockViolationRepository{}, nil, nil, nil, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, _, err := svc.ResolveAppeal(context.Background(), 1, 7, repository.AppealStatusRejected)
+		if !errors.Is(err, ErrAppealResolved) {
+			t.Errorf("ResolveAppeal() error = %v, want %v", err, ErrAppealResolved)
+		}
+	})
+
+	t.Run("Not admin", func(t *testing.T) {
+		appealRepo := &MockAppealRepository{
+			GetFunc: func(id uint) (*repository.Appeal, error) {
+				return &repository.Appeal{ID: id, ChatID: 100, UserID: 456, SanctionType: SanctionMute}, nil
+			},
+			ResolveFunc: func(id uint, status string, resolvedBy int64) (bool, error) {
+				t.Error("Resolve should not be called for non-admins")
+				return true, nil
+			},
+		}
+		svc := NewModerationService(logger, nil, &MockChatAdminRepository{}, nil, nil, nil, &MockViolationRepository{}, nil, nil, nil, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		if _, _, err := svc.ResolveAppeal(context.Background(), 1, 7, repository.AppealStatusApproved); err == nil {
+			t.Error("ResolveAppeal() expected error for non-admin")
+		}
+	})
+}
+
+func TestModerationService_FileAppeal_ConcurrentOpen(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	muteRepo := &MockMuteRepository{
+		IsMutedFunc: func(chatID, userID int64) (bool, time.Time, error) {
+			return true, time.Now().Add(time.Hour), nil
+		},
+	}
+	appealRepo := &MockAppealRepository{
+		CreateFunc: func(appeal *repository.Appeal) (bool, error) {
+			return false, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, muteRepo, nil, &MockViolationRepository{}, nil, nil, &MockBanRepository{}, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if _, _, err := svc.FileAppeal(context.Background(), 100, 456, "user", SanctionMute, "text", time.Hour); !errors.Is(err, ErrAppealAlreadyOpen) {
+		t.Errorf("FileAppeal() error = %v, want ErrAppealAlreadyOpen", err)
+	}
+}
+
+func TestModerationService_LiftClosesAppeals(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var closed []string
+	appealRepo := &MockAppealRepository{
+		CloseOpenFunc: func(chatID, userID int64, sanctionType string) error {
+			closed = append(closed, sanctionType)
+			return nil
+		},
+	}
+	roles := map[int64]string{1: repository.RoleModerator}
+	svc := NewModerationService(logger, nil, rolesRepo(roles), nil, &MockMuteRepository{}, nil, &MockViolationRepository{}, nil, nil, &MockBanRepository{}, appealRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.UnmuteUser(context.Background(), 100, 1, 456); err != nil {
+		t.Fatalf("UnmuteUser() error = %v", err)
+	}
+	if err := svc.UnbanUser(context.Background(), 100, 1, 456); err != nil {
+		t.Fatalf("UnbanUser() error = %v", err)
+	}
+	if len(closed) != 2 || closed[0] != SanctionMute || closed[1] != SanctionBan {
+		t.Errorf("closed appeals = %v, want [mute ban]", closed)
+	}
+}
+
+func TestReducedExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      time.Time
+	}{
+		{name: "Halves remaining", expiresAt: now.Add(4 * time.Hour), want: now.Add(2 * time.Hour)},
+		{name: "Permanent becomes week", expiresAt: repository.PermanentExpiry, want: now.Add(7 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reducedExpiry(tt.expiresAt, now); !got.Equal(tt.want) {
+				t.Errorf("reducedExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"strings"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const defaultArchiveDays = 7
+
+var (
+	ErrArchivedMessageNotFound = errors.New("archived message not found")
+	ErrAlreadyRestored         = errors.New("message already restored")
+)
+
+func (s *ModerationService) ArchiveMessage(ctx context.Context, message repository.ArchivedMessage) error {
+	_, span := s.tracer.Start(ctx, "ArchiveMessage")
+	defer span.End()
+
+	if s.archiveRepo == nil {
+		return nil
+	}
+	settings, err := s.settingsRepo.GetSettings(message.ChatID)
+	if err != nil {
+		return err
+	}
+	if !settings.EnableArchive {
+		return nil
+	}
+	days := settings.ArchiveDays
+	if days <= 0 {
+		days = defaultArchiveDays
+	}
+	message.Status = repository.ArchiveStatusPending
+	message.CreatedAt = time.Now()
+	message.ExpiresAt = message.CreatedAt.Add(time.Duration(days) * 24 * time.Hour)
+	return s.archiveRepo.Add(&message)
+}
+
+func (s *ModerationService) GetArchivedMessages(ctx context.Context, chatID int64, page int) ([]repository.ArchivedMessage, int64, error) {
+	_, span := s.tracer.Start(ctx, "GetArchivedMessages")
+	defer span.End()
+	pageSize := 10
+	offset := (page - 1) * pageSize
+	return s.archiveRepo.GetPaginated(chatID, offset, pageSize)
+}
+
+func (s *ModerationService) GetArchivedMessage(ctx context.Context, id uint) (*repository.ArchivedMessage, error) {
+	_, span := s.tracer.Start(ctx, "GetArchivedMessage")
+	defer span.End()
+	return s.archiveRepo.Get(id)
+}
+
+func (s *ModerationService) RestoreArchivedMessage(ctx context.Context, id uint, moderatorID int64) error {
+	ctx, span := s.tracer.Start(ctx, "RestoreArchivedMessage")
+	defer span.End()
+
+	message, err := s.archiveRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return ErrArchivedMessageNotFound
+	}
+	if message.Status == repository.ArchiveStatusRestored {
+		return ErrAlreadyRestored
+	}
+	if s.bot == nil {
+		return fmt.Errorf("bot is not configured")
+	}
+	claimed, err := s.archiveRepo.MarkRestored(id, moderatorID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrAlreadyRestored
+	}
+
+	name := message.UserName
+	if name == "" {
+		name = "User"
+	}
+	msg := maxbot.NewMessage()
+	msg.SetChat(message.ChatID)
+	msg.SetText(fmt.Sprintf(messages.MsgArchiveRestoredPost, name, message.UserID, message.Text))
+	msg.SetFormat("markdown")
+	addArchivedAttachments(msg, message.Attachments)
+	if err := s.bot.Messages.Send(ctx, msg); err != nil {
+		if rollbackErr := s.archiveRepo.SetStatus(id, message.Status, message.ReviewedBy); rollbackErr != nil {
+			s.logger.Error("Failed to roll back archived message status", "id", id, "error", rollbackErr)
+		}
+		return fmt.Errorf("failed to repost archived message: %w", err)
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       message.ChatID,
+		ActorID:      moderatorID,
+		TargetUserID: message.UserID,
+		Action:       repository.AuditActionRestore,
+		Filter:       message.Filter,
+		MessageID:    message.MessageID,
+	})
+	return nil
+}
+
+func (s *ModerationService) MarkFalsePositive(ctx context.Context, id uint, moderatorID int64) error {
+	_, span := s.tracer.Start(ctx, "MarkFalsePositive")
+	defer span.End()
+
+	message, err := s.archiveRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return ErrArchivedMessageNotFound
+	}
+	if err := s.archiveRepo.SetStatus(id, repository.ArchiveStatusFalsePositive, moderatorID); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       message.ChatID,
+		ActorID:      moderatorID,
+		TargetUserID: message.UserID,
+		Action:       repository.AuditActionFalsePos,
+		Filter:       message.Filter,
+		Reason:       message.Reason,
+		MessageID:    message.MessageID,
+	})
+	return nil
+}
+
+func (s *ModerationService) SetArchiveRetention(ctx context.Context, chatID int64, days int) error {
+	_, span := s.tracer.Start(ctx, "SetArchiveRetention")
+	defer span.End()
+
+	if days < 1 || days > 365 {
+		return fmt.Errorf("invalid archive retention: %d", days)
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	settings.ArchiveDays = days
+	return s.settingsRepo.UpdateSettings(settings)
+}
+
+func (s *ModerationService) TrustUser(ctx context.Context, chatID, moderatorID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "TrustUser")
+	defer span.End()
+
+	if err := s.archiveRepo.AddTrusted(chatID, userID, moderatorID); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: moderatorID, TargetUserID: userID, Action: repository.AuditActionTrust})
+	return nil
+}
+
+func (s *ModerationService) UntrustUser(ctx context.Context, chatID, moderatorID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "UntrustUser")
+	defer span.End()
+
+	if err := s.archiveRepo.RemoveTrusted(chatID, userID); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: moderatorID, TargetUserID: userID, Action: repository.AuditActionUntrust})
+	return nil
+}
+
+func (s *ModerationService) IsTrustedUser(ctx context.Context, chatID, userID int64) (bool, error) {
+	_, span := s.tracer.Start(ctx, "IsTrustedUser")
+	defer span.End()
+	return s.archiveRepo.IsTrusted(chatID, userID)
+}
+
+func (s *ModerationService) StartArchiveCleanupTask(ctx context.Context) {
+	if s.archiveRepo == nil {
+		return
+	}
+	ticker := time.NewTicker(1 * time.Hour)
+
+	sweep := func() {
+		deleted, err := s.archiveRepo.DeleteExpired(time.Now())
+		if err != nil {
+			s.logger.Error("Failed to purge message archive", "error", err)
+			return
+		}
+		if deleted > 0 {
+			s.logger.Info("Purged archived messages", "count", deleted)
+		}
+	}
+
+	go func() {
+		defer ticker.Stop()
+		sweep()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
+}
+
+func (s *ModerationService) isTrusted(chatID, userID int64) bool {
+	if s.archiveRepo == nil {
+		return false
+	}
+	trusted, err := s.archiveRepo.IsTrusted(chatID, userID)
+	if err != nil {
+		s.logger.Error("Failed to check trusted user", "chat_id", chatID, "user_id", userID, "error", err)
+		return false
+	}
+	return trusted
+}
+
+func addArchivedAttachments(msg *maxbot.Message, attachments []string) {
+	for _, att := range attachments {
+		kind, token, ok := strings.Cut(att, ":")
+		if !ok || token == "" {
+			continue
+		}
+		switch kind {
+		case "image":
+			msg.AddPhoto(&schemes.PhotoTokens{Photos: map[string]schemes.PhotoToken{token: {Token: token}}})
+		case "video":
+			msg.AddVideo(&schemes.UploadedInfo{Token: token})
+		case "audio":
+			msg.AddAudio(&schemes.UploadedInfo{Token: token})
+		case "file":
+			msg.AddFile(&schemes.UploadedInfo{Token: token})
+		case "sticker":
+			msg.AddSticker(token)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/pipeline"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+	"time"
+)
+
+type stubFilter struct {
+	name  string
+	allow bool
+}
+
+func (f *stubFilter) Name() string { return f.name }
+func (f *stubFilter) Process(_ context.Context, _ pipeline.Payload) (*pipeline.Result, error) {
+	return &pipeline.Result{IsAllowed: f.allow, FilterName: f.name}, nil
+}
+
+func TestModerationService_ArchiveMessage(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	tests := []struct {
+		name     string
+		settings repository.ChatSettings
+		wantAdd  bool
+		wantDays int
+	}{
+		{name: "Disabled", settings: repository.ChatSettings{EnableArchive: false, ArchiveDays: 7}},
+		{name: "Enabled", settings: repository.ChatSettings{EnableArchive: true, ArchiveDays: 3}, wantAdd: true, wantDays: 3},
+		{name: "Enabled without retention", settings: repository.ChatSettings{EnableArchive: true}, wantAdd: true, wantDays: defaultArchiveDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added *repository.ArchivedMessage
+			archiveRepo := &MockArchiveRepository{
+				AddFunc: func(message *repository.ArchivedMessage) error {
+					added = message
+					return nil
+				},
+			}
+			settingsRepo := &MockSettingsRepository{
+				GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+					settings := tt.settings
+					return &settings, nil
+				},
+			}
+			svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, archiveRepo, nil, nil, nil, nil)
+
+			err := svc.ArchiveMessage(context.Background(), repository.ArchivedMessage{ChatID: 100, UserID: 456, Text: "spam", Filter: "word_filter"})
+			if err != nil {
+				t.Fatalf("ArchiveMessage() error = %v", err)
+			}
+			if (added != nil) != tt.wantAdd {
+				t.Fatalf("archived = %v, want %v", added != nil, tt.wantAdd)
+			}
+			if !tt.wantAdd {
+				return
+			}
+			if added.Status != repository.ArchiveStatusPending || added.Text != "spam" {
+				t.Errorf("unexpected archived message: %+v", added)
+			}
+			if got := added.ExpiresAt.Sub(added.CreatedAt); got != time.Duration(tt.wantDays)*24*time.Hour {
+				t.Errorf("retention = %v, want %d days", got, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestModerationService_ModerateMessage_TrustedBypass(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	archiveRepo := &MockArchiveRepository{
+		IsTrustedFunc: func(chatID, userID int64) (bool, error) { return userID == 7, nil },
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, archiveRepo, nil, nil, nil, nil).(*ModerationService)
+	svc.pipeline = pipeline.NewManager(&stubFilter{name: "word_filter"})
+	svc.trustedPipeline = pipeline.NewManager(&stubFilter{name: "mute_filter", allow: true})
+
+	res, err := svc.ModerateMessage(context.Background(), pipeline.Payload{ChatID: 100, SenderID: 7})
+	if err != nil || !res.IsAllowed {
+		t.Errorf("trusted sender result = %+v, %v; want allowed", res, err)
+	}
+	res, err = svc.ModerateMessage(context.Background(), pipeline.Payload{ChatID: 100, SenderID: 8})
+	if err != nil || res.IsAllowed || res.FilterName != "word_filter" {
+		t.Errorf("regular sender result = %+v, %v; want blocked by word_filter", res, err)
+	}
+}
+
+func TestModerationService_MarkFalsePositive(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var gotStatus string
+	var gotReviewer int64
+	archiveRepo := &MockArchiveRepository{
+		GetFunc: func(id uint) (*repository.ArchivedMessage, error) {
+			return &repository.ArchivedMessage{ID: id, ChatID: 100, UserID: 456, Filter: "link_filter", MessageID: "mid"}, nil
+		},
+		SetStatusFunc: func(id uint, status string, reviewedBy int64) error {
+			gotStatus, gotReviewer = status, reviewedBy
+			return nil
+		},
+	}
+	var entries []repository.AuditEntry
+	auditRepo := &MockAuditRepository{
+		AddFunc: func(entry *repository.AuditEntry) error {
+			entries = append(entries, *entry)
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, archiveRepo, nil, nil, nil, nil)
+
+	if err := svc.MarkFalsePositive(context.Background(), 5, 7); err != nil {
+		t.Fatalf("MarkFalsePositive() error = %v", err)
+	}
+	if gotStatus != repository.ArchiveStatusFalsePositive || gotReviewer != 7 {
+		t.Errorf("status = %q by %d", gotStatus, gotReviewer)
+	}
+	if len(entries) != 1 || entries[0].Action != repository.AuditActionFalsePos || entries[0].Filter != "link_filter" || entries[0].MessageID != "mid" {
+		t.Errorf("audit entries = %+v", entries)
+	}
+}
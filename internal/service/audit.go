@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"max-moderation-bot/internal/repository"
+)
+
+func (s *ModerationService) LogAction(ctx context.Context, entry repository.AuditEntry) uint {
+	_, span := s.tracer.Start(ctx, "LogAction")
+	defer span.End()
+	return s.audit(entry)
+}
+
+func (s *ModerationService) GetAuditEntry(ctx context.Context, id uint) (*repository.AuditEntry, error) {
+	_, span := s.tracer.Start(ctx, "GetAuditEntry")
+	defer span.End()
+	return s.auditRepo.Get(id)
+}
+
+func (s *ModerationService) GetAuditLog(ctx context.Context, chatID int64, filter repository.AuditFilter, page int) ([]repository.AuditEntry, int64, error) {
+	_, span := s.tracer.Start(ctx, "GetAuditLog")
+	defer span.End()
+	pageSize := 10
+	offset := (page - 1) * pageSize
+	return s.auditRepo.GetPaginated(chatID, filter, offset, pageSize)
+}
+
+func (s *ModerationService) audit(entry repository.AuditEntry) uint {
+	if s.auditRepo == nil {
+		return 0
+	}
+	entry.Reason = truncateReason(entry.Reason)
+	if err := s.auditRepo.Add(&entry); err != nil {
+		s.logger.Error("Failed to write audit entry", "chat_id", entry.ChatID, "action", entry.Action, "error", err)
+		return 0
+	}
+	return entry.ID
+}
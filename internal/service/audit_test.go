@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModerationService_Audit_Sanctions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var entries []repository.AuditEntry
+	auditRepo := &MockAuditRepository{
+		AddFunc: func(entry *repository.AuditEntry) error {
+			entries = append(entries, *entry)
+			return nil
+		},
+	}
+	adminRepo := &MockChatAdminRepository{
+		IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+	}
+	violationRepo := &MockViolationRepository{
+		AddWarningFunc: func(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+			return nil
+		},
+		SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+			return 1, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, adminRepo, nil, &MockMuteRepository{}, nil, violationRepo, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, nil, nil, nil).(*ModerationService)
+
+	if err := svc.MuteUser(context.Background(), 100, 7, 456, "User", "флуд", time.Hour); err != nil {
+		t.Fatalf("MuteUser() error = %v", err)
+	}
+	if err := svc.UnmuteUser(context.Background(), 100, 7, 456); err != nil {
+		t.Fatalf("UnmuteUser() error = %v", err)
+	}
+	if _, err := svc.WarnUser(context.Background(), 100, 7, 456, "оффтоп"); err != nil {
+		t.Fatalf("WarnUser() error = %v", err)
+	}
+
+	want := []string{repository.AuditActionMute, repository.AuditActionUnmute, repository.AuditActionWarn}
+	if len(entries) != len(want) {
+		t.Fatalf("audit entries = %+v, want actions %v", entries, want)
+	}
+	for i, action := range want {
+		e := entries[i]
+		if e.Action != action || e.ChatID != 100 || e.ActorID != 7 || e.TargetUserID != 456 {
+			t.Errorf("entry %d = %+v, want action %q by 7 on 456", i, e, action)
+		}
+	}
+	if entries[0].Reason != "флуд" || entries[0].Filter != SanctionSourceAdmin {
+		t.Errorf("mute entry = %+v", entries[0])
+	}
+}
+
+func TestModerationService_GetAuditLog(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var gotFilter repository.AuditFilter
+	var gotOffset, gotLimit int
+	auditRepo := &MockAuditRepository{
+		GetPaginatedFunc: func(chatID int64, filter repository.AuditFilter, offset, limit int) ([]repository.AuditEntry, int64, error) {
+			gotFilter, gotOffset, gotLimit = filter, offset, limit
+			return []repository.AuditEntry{{ID: 1}}, 21, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, nil, nil, nil)
+
+	filter := repository.AuditFilter{Action: repository.AuditActionDelete, UserID: 456}
+	entries, total, err := svc.GetAuditLog(context.Background(), 100, filter, 3)
+	if err != nil {
+		t.Fatalf("GetAuditLog() error = %v", err)
+	}
+	if len(entries) != 1 || total != 21 {
+		t.Errorf("GetAuditLog() = %d entries, total %d", len(entries), total)
+	}
+	if gotFilter != filter || gotOffset != 20 || gotLimit != 10 {
+		t.Errorf("repo called with filter %+v offset %d limit %d", gotFilter, gotOffset, gotLimit)
+	}
+}
+
+func TestModerationService_LogAction_TruncatesReason(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var saved repository.AuditEntry
+	auditRepo := &MockAuditRepository{
+		AddFunc: func(entry *repository.AuditEntry) error {
+			saved = *entry
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, nil, nil, nil)
+
+	svc.LogAction(context.Background(), repository.AuditEntry{ChatID: 100, Action: repository.AuditActionBlocklist, Reason: strings.Repeat("спам, ", 200)})
+	if n := len([]rune(saved.Reason)); n != 500 {
+		t.Errorf("audit reason length = %d, want 500", n)
+	}
+}
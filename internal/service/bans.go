@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+)
+
+type MemberRemover interface {
+	Remove(ctx context.Context, chatID, userID int64) error
+}
+
+type maxMemberRemover struct {
+	bot *maxbot.Api
+}
+
+func NewMaxMemberRemover(bot *maxbot.Api) MemberRemover {
+	return &maxMemberRemover{bot: bot}
+}
+
+func (r *maxMemberRemover) Remove(ctx context.Context, chatID, userID int64) error {
+	if r.bot == nil {
+		return fmt.Errorf("bot client not initialized in service")
+	}
+	if _, err := r.bot.Chats.RemoveMember(ctx, chatID, userID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	return nil
+}
+
+func (s *ModerationService) SystemKickUser(ctx context.Context, chatID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "SystemKickUser")
+	defer span.End()
+	return s.remover.Remove(ctx, chatID, userID)
+}
+
+func (s *ModerationService) BanUser(ctx context.Context, chatID, moderatorID, userID int64, userName, reason string, duration time.Duration) error {
+	ctx, span := s.tracer.Start(ctx, "BanUser")
+	defer span.End()
+
+	if err := s.requireRole(chatID, moderatorID, repository.RoleModerator); err != nil {
+		return err
+	}
+	if err := s.SystemKickUser(ctx, chatID, userID); err != nil {
+		return err
+	}
+	if err := s.banRepo.BanUser(chatID, userID, userName, duration); err != nil {
+		return err
+	}
+	s.recordSanction(chatID, userID, userName, SanctionBan, reason, SanctionSourceAdmin, moderatorID, duration)
+	s.propagateSanction(ctx, chatID, moderatorID, func(siblingID int64) error {
+		if err := s.banRepo.BanUser(siblingID, userID, userName, duration); err != nil {
+			return err
+		}
+		s.recordSanction(siblingID, userID, userName, SanctionBan, reason, SanctionSourceChatGroup, moderatorID, duration)
+		if err := s.SystemKickUser(ctx, siblingID, userID); err != nil {
+			s.logger.Debug("Propagated ban target not removed", "chat_id", siblingID, "user_id", userID, "error", err)
+		}
+		return nil
+	})
+	return nil
+}
+
+func (s *ModerationService) UnbanUser(ctx context.Context, chatID, adminID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "UnbanUser")
+	defer span.End()
+	if err := s.requireRole(chatID, adminID, repository.RoleModerator); err != nil {
+		return err
+	}
+	return s.liftBan(chatID, userID, adminID, repository.SanctionEndUnbanned)
+}
+
+func (s *ModerationService) liftBan(chatID, userID, endedBy int64, endReason string) error {
+	if err := s.banRepo.UnbanUser(chatID, userID); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: endedBy, TargetUserID: userID, Action: repository.AuditActionUnban, Reason: endReason})
+	s.endSanction(chatID, userID, SanctionBan, endedBy, endReason)
+	s.closeAppeals(chatID, userID, SanctionBan)
+	return nil
+}
+
+func (s *ModerationService) GetActiveBansPaginated(ctx context.Context, chatID int64, page int) ([]repository.Ban, int64, error) {
+	_, span := s.tracer.Start(ctx, "GetActiveBansPaginated")
+	defer span.End()
+	pageSize := 10
+	offset := (page - 1) * pageSize
+	return s.banRepo.GetActiveBansPaginated(chatID, offset, pageSize)
+}
+
+func (s *ModerationService) GetBan(ctx context.Context, chatID, userID int64) (*repository.Ban, error) {
+	_, span := s.tracer.Start(ctx, "GetBan")
+	defer span.End()
+	return s.banRepo.GetBan(chatID, userID)
+}
+
+func (s *ModerationService) EnforceBan(ctx context.Context, chatID, userID int64) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "EnforceBan")
+	defer span.End()
+
+	banned, _, err := s.banRepo.IsBanned(chatID, userID)
+	if err != nil {
+		return false, err
+	}
+	if !banned {
+		return false, nil
+	}
+	if err := s.SystemKickUser(ctx, chatID, userID); err != nil {
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestModerationService_UnbanUser(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name        string
+		isAdmin     bool
+		wantErr     bool
+		wantRemoved bool
+	}{
+		{name: "Success", isAdmin: true, wantErr: false, wantRemoved: true},
+		{name: "Not Admin", isAdmin: false, wantErr: true, wantRemoved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed := false
+			adminRepo := &MockChatAdminRepository{
+				IsAdminFunc: func(chatID, userID int64) (bool, error) {
+					return tt.isAdmin, nil
+				},
+			}
+			banRepo := &MockBanRepository{
+				UnbanUserFunc: func(chatID, userID int64) error {
+					removed = true
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			err := svc.UnbanUser(context.Background(), 100, 1, 456)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnbanUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("UnbanUser() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestModerationService_EnforceBan(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Not banned", func(t *testing.T) {
+		banRepo := &MockBanRepository{
+			IsBannedFunc: func(chatID, userID int64) (bool, time.Time, error) {
+				return false, time.Time{}, nil
+			},
+		}
+		svc := NewModerationService(logger, nil, nil, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		banned, err := svc.EnforceBan(context.Background(), 100, 456)
+		if err != nil || banned {
+			t.Errorf("EnforceBan() = %v, %v; want false, nil", banned, err)
+		}
+	})
+
+	t.Run("Banned without bot client", func(t *testing.T) {
+		banRepo := &MockBanRepository{
+			IsBannedFunc: func(chatID, userID int64) (bool, time.Time, error) {
+				return true, time.Now().Add(time.Hour), nil
+			},
+		}
+		svc := NewModerationService(logger, nil, nil, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		banned, err := svc.EnforceBan(context.Background(), 100, 456)
+		if !banned || err == nil {
+			t.Errorf("EnforceBan() = %v, %v; want true and a removal error", banned, err)
+		}
+	})
+}
+
+type fakeRemover struct {
+	err     error
+	removed []int64
+}
+
+func (f *fakeRemover) Remove(ctx context.Context, chatID, userID int64) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.removed = append(f.removed, chatID)
+	return nil
+}
+
+func TestModerationService_BanUser_KickFirst(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name       string
+		kickErr    error
+		wantBanned bool
+	}{
+		{name: "Kick succeeds", wantBanned: true},
+		{name: "Kick fails", kickErr: errors.New("api down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banned, recorded := false, false
+			banRepo := &MockBanRepository{
+				BanUserFunc: func(chatID, userID int64, userName string, duration time.Duration) error {
+					banned = true
+					return nil
+				},
+			}
+			historyRepo := &MockSanctionHistoryRepository{
+				AddFunc: func(record *repository.SanctionRecord) error {
+					recorded = true
+					return nil
+				},
+			}
+			adminRepo := rolesRepo(map[int64]string{5: repository.RoleModerator})
+			svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, nil, nil, nil, historyRepo, nil, nil, nil, nil, nil, nil).(*ModerationService)
+			svc.remover = &fakeRemover{err: tt.kickErr}
+
+			err := svc.BanUser(context.Background(), 100, 5, 456, "spammer", "спам", 0)
+			if (err != nil) != (tt.kickErr != nil) {
+				t.Fatalf("BanUser() error = %v, want error %v", err, tt.kickErr)
+			}
+			if banned != tt.wantBanned || recorded != tt.wantBanned {
+				t.Errorf("banned = %v, recorded = %v, want %v", banned, recorded, tt.wantBanned)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	BlocklistWords   = "words"
+	BlocklistDomains = "domains"
+)
+
+func FilterBlocklist(list []string, query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matched := make([]string, 0, len(list))
+	for _, entry := range list {
+		if query == "" || strings.Contains(strings.ToLower(entry), query) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+func (s *ModerationService) GetBlocklistPage(ctx context.Context, chatID int64, kind, query string, page int) ([]string, int, error) {
+	_, span := s.tracer.Start(ctx, "GetBlocklistPage")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var list []string
+	switch kind {
+	case BlocklistWords:
+		list = settings.BlockedWords
+	case BlocklistDomains:
+		list = settings.BlockedDomains
+	default:
+		return nil, 0, fmt.Errorf("unknown blocklist: %s", kind)
+	}
+
+	matched := FilterBlocklist(list, query)
+	pageSize := 10
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(matched) {
+		return nil, len(matched), nil
+	}
+	end := offset + pageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[offset:end], len(matched), nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFilterBlocklist(t *testing.T) {
+	list := []string{"spam", "casino", "Scam", "free money"}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "Empty query sorts all", query: "", want: []string{"Scam", "casino", "free money", "spam"}},
+		{name: "Substring", query: "am", want: []string{"Scam", "spam"}},
+		{name: "Case insensitive", query: " SCA ", want: []string{"Scam"}},
+		{name: "No match", query: "xyz", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterBlocklist(list, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterBlocklist(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_GetBlocklistPage(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var words []string
+	for i := 0; i < 25; i++ {
+		words = append(words, fmt.Sprintf("word%02d", i))
+	}
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			return &repository.ChatSettings{ChatID: chatID, BlockedWords: words, BlockedDomains: []string{"bad.com", "evil.org"}}, nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		kind      string
+		query     string
+		page      int
+		wantFirst string
+		wantLen   int
+		wantTotal int
+	}{
+		{name: "First page", kind: BlocklistWords, page: 1, wantFirst: "word00", wantLen: 10, wantTotal: 25},
+		{name: "Last page", kind: BlocklistWords, page: 3, wantFirst: "word20", wantLen: 5, wantTotal: 25},
+		{name: "Past the end", kind: BlocklistWords, page: 4, wantTotal: 25},
+		{name: "Search", kind: BlocklistWords, query: "word1", page: 1, wantFirst: "word10", wantLen: 10, wantTotal: 10},
+		{name: "Domains", kind: BlocklistDomains, query: "evil", page: 1, wantFirst: "evil.org", wantLen: 1, wantTotal: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total, err := svc.GetBlocklistPage(context.Background(), 100, tt.kind, tt.query, tt.page)
+			if err != nil {
+				t.Fatalf("GetBlocklistPage() error = %v", err)
+			}
+			if total != tt.wantTotal || len(entries) != tt.wantLen {
+				t.Fatalf("GetBlocklistPage() = %d entries of %d, want %d of %d", len(entries), total, tt.wantLen, tt.wantTotal)
+			}
+			if tt.wantLen > 0 && entries[0] != tt.wantFirst {
+				t.Errorf("first entry = %q, want %q", entries[0], tt.wantFirst)
+			}
+		})
+	}
+
+	if _, _, err := svc.GetBlocklistPage(context.Background(), 100, "unknown", "", 1); err == nil {
+		t.Error("GetBlocklistPage(unknown) expected error")
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"time"
+)
+
+var (
+	ErrInsufficientRole  = errors.New("insufficient admin role")
+	ErrNotBotAdmin       = errors.New("not a bot admin")
+	ErrInvalidRole       = errors.New("invalid co-admin role")
+	ErrInvalidInvite     = errors.New("invalid or expired invite")
+	ErrAlreadyOwner      = errors.New("user already owns the chat")
+	ErrCannotRemoveOwner = errors.New("chat owner cannot be removed")
+)
+
+var CoAdminRoles = []string{repository.RoleViewer, repository.RoleModerator, repository.RoleManager}
+
+var roleRanks = map[string]int{
+	repository.RoleViewer:    1,
+	repository.RoleModerator: 2,
+	repository.RoleManager:   3,
+	repository.RoleOwner:     4,
+}
+
+const coAdminInviteTTL = 24 * time.Hour
+
+func RoleAtLeast(role, minRole string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[minRole]
+}
+
+func isCoAdminRole(role string) bool {
+	for _, r := range CoAdminRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ModerationService) requireRole(chatID, userID int64, minRole string) error {
+	role, err := s.chatAdminRepo.GetRole(chatID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check admin status: %w", err)
+	}
+	if role == "" {
+		return fmt.Errorf("%w: user %d in chat %d", ErrNotBotAdmin, userID, chatID)
+	}
+	if !RoleAtLeast(role, minRole) {
+		return fmt.Errorf("%w: user %d is %s in chat %d", ErrInsufficientRole, userID, role, chatID)
+	}
+	return nil
+}
+
+func (s *ModerationService) adminsWithRole(chatID int64, minRole string) ([]int64, error) {
+	admins, err := s.chatAdminRepo.GetChatAdmins(chatID)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []int64
+	for _, admin := range admins {
+		if RoleAtLeast(admin.Role, minRole) {
+			userIDs = append(userIDs, admin.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (s *ModerationService) GetChatRole(ctx context.Context, chatID, userID int64) (string, error) {
+	_, span := s.tracer.Start(ctx, "GetChatRole")
+	defer span.End()
+	return s.chatAdminRepo.GetRole(chatID, userID)
+}
+
+func (s *ModerationService) GetCoAdmins(ctx context.Context, chatID int64) ([]repository.ChatAdmin, error) {
+	_, span := s.tracer.Start(ctx, "GetCoAdmins")
+	defer span.End()
+	return s.chatAdminRepo.GetChatAdmins(chatID)
+}
+
+func (s *ModerationService) CreateCoAdminInvite(ctx context.Context, chatID, ownerID int64, role string) (string, error) {
+	_, span := s.tracer.Start(ctx, "CreateCoAdminInvite")
+	defer span.End()
+
+	if !isCoAdminRole(role) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidRole, role)
+	}
+	if err := s.requireRole(chatID, ownerID, repository.RoleOwner); err != nil {
+		return "", err
+	}
+	return s.linkTokenRepo.CreateInvite(ownerID, chatID, role, coAdminInviteTTL)
+}
+
+func (s *ModerationService) AcceptCoAdminInvite(ctx context.Context, token string, userID int64) (*repository.LinkToken, error) {
+	_, span := s.tracer.Start(ctx, "AcceptCoAdminInvite")
+	defer span.End()
+
+	invite, err := s.linkTokenRepo.Get(token)
+	if err != nil || invite == nil {
+		return nil, ErrInvalidInvite
+	}
+	if invite.Role == "" || invite.ChatID == 0 || invite.UserID == userID {
+		return nil, ErrInvalidInvite
+	}
+	if err := s.requireRole(invite.ChatID, invite.UserID, repository.RoleOwner); err != nil {
+		return nil, ErrInvalidInvite
+	}
+	current, err := s.chatAdminRepo.GetRole(invite.ChatID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check admin status: %w", err)
+	}
+	if current == repository.RoleOwner {
+		return nil, ErrAlreadyOwner
+	}
+	if err := s.chatAdminRepo.AddAdminWithRole(invite.ChatID, userID, invite.Role, invite.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.linkTokenRepo.Delete(token); err != nil {
+		s.logger.Error("Failed to delete used invite token", "chat_id", invite.ChatID, "error", err)
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       invite.ChatID,
+		ActorID:      invite.UserID,
+		TargetUserID: userID,
+		Action:       repository.AuditActionLink,
+		Reason:       "co-admin: " + invite.Role,
+	})
+	return invite, nil
+}
+
+func (s *ModerationService) RemoveCoAdmin(ctx context.Context, chatID, ownerID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "RemoveCoAdmin")
+	defer span.End()
+
+	if err := s.requireRole(chatID, ownerID, repository.RoleOwner); err != nil {
+		return err
+	}
+	role, err := s.chatAdminRepo.GetRole(chatID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check admin status: %w", err)
+	}
+	if role == "" {
+		return nil
+	}
+	if role == repository.RoleOwner {
+		return ErrCannotRemoveOwner
+	}
+	if err := s.chatAdminRepo.RemoveAdmin(chatID, userID); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       chatID,
+		ActorID:      ownerID,
+		TargetUserID: userID,
+		Action:       repository.AuditActionLink,
+		Reason:       "co-admin removed: " + role,
+	})
+	return nil
+}
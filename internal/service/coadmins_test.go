@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRoleAtLeast(t *testing.T) {
+	tests := []struct {
+		role, min string
+		want      bool
+	}{
+		{repository.RoleOwner, repository.RoleManager, true},
+		{repository.RoleManager, repository.RoleManager, true},
+		{repository.RoleModerator, repository.RoleManager, false},
+		{repository.RoleModerator, repository.RoleViewer, true},
+		{repository.RoleViewer, repository.RoleModerator, false},
+		{"", repository.RoleViewer, false},
+		{"unknown", repository.RoleViewer, false},
+	}
+	for _, tt := range tests {
+		if got := RoleAtLeast(tt.role, tt.min); got != tt.want {
+			t.Errorf("RoleAtLeast(%q, %q) = %v, want %v", tt.role, tt.min, got, tt.want)
+		}
+	}
+}
+
+func rolesRepo(roles map[int64]string) *MockChatAdminRepository {
+	return &MockChatAdminRepository{
+		GetRoleFunc: func(chatID, userID int64) (string, error) {
+			return roles[userID], nil
+		},
+		GetChatAdminsFunc: func(chatID int64) ([]repository.ChatAdmin, error) {
+			var admins []repository.ChatAdmin
+			for userID, role := range roles {
+				admins = append(admins, repository.ChatAdmin{ChatID: chatID, UserID: userID, Role: role})
+			}
+			return admins, nil
+		},
+	}
+}
+
+func TestModerationService_RoleChecks(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	roles := map[int64]string{1: repository.RoleViewer, 2: repository.RoleModerator}
+	svc := NewModerationService(logger, nil, rolesRepo(roles), nil, nil, nil, &MockViolationRepository{}, nil, nil, &MockBanRepository{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.UnbanUser(context.Background(), 100, 1, 456); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("UnbanUser() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+	if err := svc.UnbanUser(context.Background(), 100, 2, 456); err != nil {
+		t.Errorf("UnbanUser() by moderator error = %v", err)
+	}
+	if err := svc.UnbanUser(context.Background(), 100, 3, 456); !errors.Is(err, ErrNotBotAdmin) {
+		t.Errorf("UnbanUser() by stranger error = %v, want ErrNotBotAdmin", err)
+	}
+	if err := svc.BanUser(context.Background(), 100, 1, 456, "spammer", "", 0); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("BanUser() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+	if err := svc.KickUser(context.Background(), 100, 3, 456, "spammer", "", SanctionSourceAdmin); err == nil {
+		t.Error("KickUser() by stranger should fail")
+	}
+	if err := svc.UnmuteUser(context.Background(), 100, 1, 456); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("UnmuteUser() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+	if _, err := svc.WarnUser(context.Background(), 100, 1, 456, ""); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("WarnUser() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+	if _, err := svc.WarnUser(context.Background(), 100, 3, 456, ""); !errors.Is(err, ErrNotBotAdmin) {
+		t.Errorf("WarnUser() by stranger error = %v, want ErrNotBotAdmin", err)
+	}
+	if _, err := svc.RemoveLatestWarning(context.Background(), 100, 1, 456); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("RemoveLatestWarning() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+
+	recipients, err := svc.(*ModerationService).adminsWithRole(100, repository.RoleModerator)
+	if err != nil || len(recipients) != 1 || recipients[0] != 2 {
+		t.Errorf("adminsWithRole() = %v, %v, want [2]", recipients, err)
+	}
+}
+
+func TestModerationService_CreateCoAdminInvite(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	roles := map[int64]string{1: repository.RoleOwner, 2: repository.RoleManager}
+	var created struct {
+		chatID int64
+		role   string
+		ttl    time.Duration
+	}
+	tokens := &MockLinkTokenRepository{
+		CreateInviteFunc: func(userID, chatID int64, role string, ttl time.Duration) (string, error) {
+			created.chatID, created.role, created.ttl = chatID, role, ttl
+			return "invite", nil
+		},
+	}
+	svc := NewModerationService(logger, nil, rolesRepo(roles), tokens, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	token, err := svc.CreateCoAdminInvite(context.Background(), 100, 1, repository.RoleModerator)
+	if err != nil || token != "invite" {
+		t.Fatalf("CreateCoAdminInvite() = %q, %v", token, err)
+	}
+	if created.chatID != 100 || created.role != repository.RoleModerator || created.ttl != 24*time.Hour {
+		t.Errorf("invite created with %+v", created)
+	}
+	if _, err := svc.CreateCoAdminInvite(context.Background(), 100, 1, repository.RoleOwner); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("owner invite error = %v, want ErrInvalidRole", err)
+	}
+	if _, err := svc.CreateCoAdminInvite(context.Background(), 100, 2, repository.RoleViewer); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("manager invite error = %v, want ErrInsufficientRole", err)
+	}
+}
+
+func TestModerationService_AcceptCoAdminInvite(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	invites := map[string]*repository.LinkToken{
+		"invite": {Token: "invite", UserID: 1, ChatID: 100, Role: repository.RoleModerator},
+		"link":   {Token: "link", UserID: 1},
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		userID  int64
+		wantErr error
+	}{
+		{name: "Accepted", token: "invite", userID: 5},
+		{name: "Link token", token: "link", userID: 5, wantErr: ErrInvalidInvite},
+		{name: "Unknown token", token: "missing", userID: 5, wantErr: ErrInvalidInvite},
+		{name: "Own invite", token: "invite", userID: 1, wantErr: ErrInvalidInvite},
+		{name: "Existing owner", token: "invite", userID: 7, wantErr: ErrAlreadyOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := map[int64]string{1: repository.RoleOwner, 7: repository.RoleOwner}
+			adminRepo := rolesRepo(roles)
+			var added string
+			adminRepo.AddAdminWithRoleFunc = func(chatID, userID int64, role string, invitedBy int64) error {
+				if invitedBy != 1 {
+					t.Errorf("invitedBy = %d, want 1", invitedBy)
+				}
+				added = role
+				return nil
+			}
+			deleted := false
+			tokens := &MockLinkTokenRepository{
+				GetFunc: func(token string) (*repository.LinkToken, error) {
+					if invite, ok := invites[token]; ok {
+						return invite, nil
+					}
+					return nil, errors.New("record not found")
+				},
+				DeleteFunc: func(token string) error {
+					deleted = true
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, nil, adminRepo, tokens, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			invite, err := svc.AcceptCoAdminInvite(context.Background(), tt.token, tt.userID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("AcceptCoAdminInvite() error = %v, want %v", err, tt.wantErr)
+				}
+				if added != "" || deleted {
+					t.Error("rejected invite must not change admins or consume the token")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AcceptCoAdminInvite() error = %v", err)
+			}
+			if invite.ChatID != 100 || added != repository.RoleModerator || !deleted {
+				t.Errorf("invite = %+v, added = %q, deleted = %v", invite, added, deleted)
+			}
+		})
+	}
+}
+
+func TestModerationService_LinkGroupRejectsInvite(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	tokens := &MockLinkTokenRepository{
+		GetFunc: func(token string) (*repository.LinkToken, error) {
+			return &repository.LinkToken{Token: token, UserID: 5, ChatID: 100, Role: repository.RoleViewer}, nil
+		},
+	}
+	adminRepo := &MockChatAdminRepository{
+		AddAdminFunc: func(chatID, userID int64) error {
+			t.Error("invite token must not link a chat")
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, nil, adminRepo, tokens, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.LinkGroup(context.Background(), "invite", 200, 5); err == nil {
+		t.Error("LinkGroup() with invite token should fail")
+	}
+}
+
+func TestModerationService_RemoveCoAdmin(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	roles := map[int64]string{1: repository.RoleOwner, 2: repository.RoleManager, 3: repository.RoleOwner}
+	adminRepo := rolesRepo(roles)
+	var removed []int64
+	adminRepo.RemoveAdminFunc = func(chatID, userID int64) error {
+		removed = append(removed, userID)
+		return nil
+	}
+	svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.RemoveCoAdmin(context.Background(), 100, 2, 1); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("RemoveCoAdmin() by manager error = %v, want ErrInsufficientRole", err)
+	}
+	if err := svc.RemoveCoAdmin(context.Background(), 100, 1, 3); !errors.Is(err, ErrCannotRemoveOwner) {
+		t.Errorf("RemoveCoAdmin() of owner error = %v, want ErrCannotRemoveOwner", err)
+	}
+	if err := svc.RemoveCoAdmin(context.Background(), 100, 1, 2); err != nil {
+		t.Fatalf("RemoveCoAdmin() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != 2 {
+		t.Errorf("removed = %v, want [2]", removed)
+	}
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CopySectionFilters     = "filters"
+	CopySectionBlocklists  = "blocklists"
+	CopySectionAttachments = "attachments"
+	CopySectionSchedules   = "schedules"
+)
+
+var CopySections = []string{CopySectionFilters, CopySectionBlocklists, CopySectionAttachments, CopySectionSchedules}
+
+var ErrUnknownCopySection = errors.New("unknown settings section")
+
+type CopyChange struct {
+	Section string
+	Field   string
+	From    string
+	To      string
+	Added   int
+	Removed int
+}
+
+type CopyPreview struct {
+	ChatID  int64
+	Changes []CopyChange
+}
+
+type copyField struct {
+	section string
+	field   string
+	value   func(*SettingsSnapshot) string
+}
+
+var copyScalarFields = []copyField{
+	{CopySectionFilters, "auto_delete", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.EnableAutoDelete) }},
+	{CopySectionFilters, "word_filter", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.EnableWordFilter) }},
+	{CopySectionFilters, "link_filter", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.EnableLinkFilter) }},
+	{CopySectionFilters, "mute", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.EnableMute) }},
+	{CopySectionFilters, "global_bans", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.UseGlobalBans) }},
+	{CopySectionFilters, "slow_mode", func(s *SettingsSnapshot) string { return strconv.Itoa(s.Settings.SlowModeSeconds) }},
+	{CopySectionAttachments, "restrict_image", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.RestrictImage) }},
+	{CopySectionAttachments, "restrict_video", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.RestrictVideo) }},
+	{CopySectionAttachments, "restrict_audio", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.RestrictAudio) }},
+	{CopySectionAttachments, "restrict_file", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.RestrictFile) }},
+	{CopySectionSchedules, "timezone", func(s *SettingsSnapshot) string { return s.Settings.Timezone }},
+	{CopySectionSchedules, "schedule_notices", func(s *SettingsSnapshot) string { return strconv.FormatBool(s.Settings.ScheduleNotices) }},
+}
+
+func ValidateCopySections(sections []string) error {
+	if len(sections) == 0 {
+		return fmt.Errorf("%w: none selected", ErrUnknownCopySection)
+	}
+	for _, section := range sections {
+		known := false
+		for _, s := range CopySections {
+			if s == section {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrUnknownCopySection, section)
+		}
+	}
+	return nil
+}
+
+func copySnapshotSection(dst, src *SettingsSnapshot, section string) {
+	switch section {
+	case CopySectionFilters:
+		dst.Settings.EnableAutoDelete = src.Settings.EnableAutoDelete
+		dst.Settings.EnableWordFilter = src.Settings.EnableWordFilter
+		dst.Settings.EnableLinkFilter = src.Settings.EnableLinkFilter
+		dst.Settings.EnableMute = src.Settings.EnableMute
+		dst.Settings.UseGlobalBans = src.Settings.UseGlobalBans
+		dst.Settings.SlowModeSeconds = src.Settings.SlowModeSeconds
+	case CopySectionBlocklists:
+		dst.BlockedWords = src.BlockedWords
+		dst.BlockedDomains = src.BlockedDomains
+		dst.WordRules = src.WordRules
+	case CopySectionAttachments:
+		dst.Settings.RestrictImage = src.Settings.RestrictImage
+		dst.Settings.RestrictVideo = src.Settings.RestrictVideo
+		dst.Settings.RestrictAudio = src.Settings.RestrictAudio
+		dst.Settings.RestrictFile = src.Settings.RestrictFile
+	case CopySectionSchedules:
+		dst.Settings.Timezone = src.Settings.Timezone
+		dst.Settings.ScheduleNotices = src.Settings.ScheduleNotices
+		dst.Schedule = src.Schedule
+	}
+}
+
+func wordRuleEntries(rules repository.WordRules) []string {
+	entries := make([]string, 0, len(rules))
+	for word, rule := range rules {
+		entries = append(entries, fmt.Sprintf("%s:%s:%d", word, rule.Mode, rule.Severity))
+	}
+	return entries
+}
+
+func scheduleEntries(rules []SnapshotScheduleRule) []string {
+	entries := make([]string, 0, len(rules))
+	for _, r := range rules {
+		entries = append(entries, fmt.Sprintf("%s %s %s", r.Days, r.Window, strings.Join(r.Restrictions, ",")))
+	}
+	return entries
+}
+
+func listChange(section, field string, from, to []string) (CopyChange, bool) {
+	change := CopyChange{Section: section, Field: field}
+	diff := diffImportList(from, to, true, ImportModeReplace)
+	change.Added = len(diff.Added)
+	change.Removed = len(diff.Removed)
+	return change, change.Added > 0 || change.Removed > 0
+}
+
+func DiffSettingsSnapshots(current, next *SettingsSnapshot, sections []string) []CopyChange {
+	selected := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		selected[section] = true
+	}
+	var changes []CopyChange
+	for _, f := range copyScalarFields {
+		if !selected[f.section] {
+			continue
+		}
+		if from, to := f.value(current), f.value(next); from != to {
+			changes = append(changes, CopyChange{Section: f.section, Field: f.field, From: from, To: to})
+		}
+	}
+	if selected[CopySectionBlocklists] {
+		for _, l := range []struct {
+			field    string
+			from, to []string
+		}{
+			{"blocked_words", current.BlockedWords, next.BlockedWords},
+			{"blocked_domains", current.BlockedDomains, next.BlockedDomains},
+			{"word_rules", wordRuleEntries(current.WordRules), wordRuleEntries(next.WordRules)},
+		} {
+			if change, ok := listChange(CopySectionBlocklists, l.field, l.from, l.to); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+	if selected[CopySectionSchedules] {
+		if change, ok := listChange(CopySectionSchedules, "schedule", scheduleEntries(current.Schedule), scheduleEntries(next.Schedule)); ok {
+			changes = append(changes, change)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return sectionIndex(changes[i].Section) < sectionIndex(changes[j].Section)
+	})
+	return changes
+}
+
+func sectionIndex(section string) int {
+	for i, s := range CopySections {
+		if s == section {
+			return i
+		}
+	}
+	return len(CopySections)
+}
+
+func (s *ModerationService) planSettingsCopy(sourceID int64, targetIDs []int64, sections []string) (map[int64]*SettingsSnapshot, []CopyPreview, error) {
+	if err := ValidateCopySections(sections); err != nil {
+		return nil, nil, err
+	}
+	source, err := s.settingsSnapshot(sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	planned := make(map[int64]*SettingsSnapshot, len(targetIDs))
+	var previews []CopyPreview
+	for _, targetID := range targetIDs {
+		if targetID == sourceID {
+			continue
+		}
+		current, err := s.settingsSnapshot(targetID)
+		if err != nil {
+			return nil, nil, err
+		}
+		next := *current
+		for _, section := range sections {
+			copySnapshotSection(&next, source, section)
+		}
+		planned[targetID] = &next
+		previews = append(previews, CopyPreview{ChatID: targetID, Changes: DiffSettingsSnapshots(current, &next, sections)})
+	}
+	return planned, previews, nil
+}
+
+func (s *ModerationService) PreviewSettingsCopy(ctx context.Context, sourceID int64, targetIDs []int64, sections []string) ([]CopyPreview, error) {
+	_, span := s.tracer.Start(ctx, "PreviewSettingsCopy")
+	defer span.End()
+
+	_, previews, err := s.planSettingsCopy(sourceID, targetIDs, sections)
+	return previews, err
+}
+
+func (s *ModerationService) CopySettings(ctx context.Context, sourceID, adminID int64, targetIDs []int64, sections []string) ([]CopyPreview, error) {
+	_, span := s.tracer.Start(ctx, "CopySettings")
+	defer span.End()
+
+	planned, previews, err := s.planSettingsCopy(sourceID, targetIDs, sections)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		selected[section] = true
+	}
+	var applied []CopyPreview
+	for _, preview := range previews {
+		if len(preview.Changes) == 0 {
+			applied = append(applied, preview)
+			continue
+		}
+		snap := planned[preview.ChatID]
+		settings, err := s.settingsRepo.GetSettings(preview.ChatID)
+		if err != nil {
+			return applied, err
+		}
+		snap.applyTo(settings)
+		if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+			return applied, fmt.Errorf("failed to copy settings to %d: %w", preview.ChatID, err)
+		}
+		if selected[CopySectionSchedules] {
+			if err := s.replaceScheduleRules(preview.ChatID, snap.Schedule); err != nil {
+				return applied, fmt.Errorf("failed to copy schedule to %d: %w", preview.ChatID, err)
+			}
+		}
+		s.audit(repository.AuditEntry{
+			ChatID:  preview.ChatID,
+			ActorID: adminID,
+			Action:  repository.AuditActionSetting,
+			Reason:  fmt.Sprintf("copy_settings from %d: %s", sourceID, strings.Join(sections, ", ")),
+		})
+		applied = append(applied, preview)
+	}
+	return applied, nil
+}
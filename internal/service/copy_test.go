@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestModerationService_CopySettings(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	settings := map[int64]*repository.ChatSettings{
+		100: {ChatID: 100, BlockedWords: []string{"spam", "casino"}, EnableWordFilter: true, RestrictVideo: true, SlowModeSeconds: 30, Timezone: "Europe/Moscow", ArchiveDays: 14},
+		200: {ChatID: 200, BlockedWords: []string{"old"}, EnableLinkFilter: true, Timezone: "UTC", ArchiveDays: 7},
+		300: {ChatID: 300, BlockedWords: []string{"spam", "casino"}, EnableWordFilter: true, RestrictVideo: true, SlowModeSeconds: 30, Timezone: "UTC", ArchiveDays: 7},
+	}
+	rules := map[int64][]repository.ScheduleRule{
+		100: {{ID: 1, ChatID: 100, Weekdays: 1, StartMinute: 0, EndMinute: 60, ReadOnly: true}},
+	}
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			copied := *settings[chatID]
+			return &copied, nil
+		},
+		UpdateSettingsFunc: func(s *repository.ChatSettings) error {
+			settings[s.ChatID] = s
+			return nil
+		},
+	}
+	scheduleRepo := &MockScheduleRepository{
+		GetRulesFunc: func(chatID int64) ([]repository.ScheduleRule, error) {
+			return rules[chatID], nil
+		},
+		AddRuleFunc: func(rule *repository.ScheduleRule) error {
+			rules[rule.ChatID] = append(rules[rule.ChatID], *rule)
+			return nil
+		},
+		DeleteRuleFunc: func(chatID int64, ruleID uint) error {
+			rules[chatID] = nil
+			return nil
+		},
+	}
+	strikeLadderRepo := &MockStrikeLadderRepository{
+		GetStepsFunc:   func(chatID int64) ([]repository.StrikeStep, error) { return nil, nil },
+		GetWeightsFunc: func(chatID int64) (map[string]int, error) { return nil, nil },
+	}
+	archiveRepo := &MockArchiveRepository{
+		GetTrustedFunc: func(chatID int64) ([]repository.TrustedUser, error) { return nil, nil },
+	}
+	var audited []int64
+	auditRepo := &MockAuditRepository{
+		AddFunc: func(entry *repository.AuditEntry) error {
+			audited = append(audited, entry.ChatID)
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, scheduleRepo, strikeLadderRepo, nil, nil, nil, nil, nil, auditRepo, archiveRepo, nil, nil, nil, nil)
+	sections := []string{CopySectionFilters, CopySectionBlocklists, CopySectionAttachments}
+
+	previews, err := svc.PreviewSettingsCopy(context.Background(), 100, []int64{100, 200, 300}, sections)
+	if err != nil {
+		t.Fatalf("PreviewSettingsCopy() error = %v", err)
+	}
+	if len(previews) != 2 || previews[0].ChatID != 200 || previews[1].ChatID != 300 {
+		t.Fatalf("previews = %+v, want chats 200 and 300", previews)
+	}
+	if len(previews[1].Changes) != 0 {
+		t.Errorf("chat 300 changes = %+v, want none", previews[1].Changes)
+	}
+	fields := map[string]CopyChange{}
+	for _, change := range previews[0].Changes {
+		fields[change.Field] = change
+	}
+	if c := fields["word_filter"]; c.From != "false" || c.To != "true" {
+		t.Errorf("word_filter change = %+v", c)
+	}
+	if c := fields["blocked_words"]; c.Added != 2 || c.Removed != 1 {
+		t.Errorf("blocked_words change = %+v", c)
+	}
+	if _, ok := fields["timezone"]; ok {
+		t.Error("timezone change reported for an unselected section")
+	}
+
+	if _, err := svc.CopySettings(context.Background(), 100, 5, []int64{200, 300}, sections); err != nil {
+		t.Fatalf("CopySettings() error = %v", err)
+	}
+	got := settings[200]
+	if !got.EnableWordFilter || got.EnableLinkFilter || !got.RestrictVideo || got.SlowModeSeconds != 30 {
+		t.Errorf("settings = %+v, want copied toggles", got)
+	}
+	if !reflect.DeepEqual([]string(got.BlockedWords), []string{"casino", "spam"}) {
+		t.Errorf("blocked words = %v, want source list", got.BlockedWords)
+	}
+	if got.Timezone != "UTC" || got.ArchiveDays != 7 || len(rules[200]) != 0 {
+		t.Errorf("unselected sections changed: timezone %q, archive %d, rules %v", got.Timezone, got.ArchiveDays, rules[200])
+	}
+	if !reflect.DeepEqual(audited, []int64{200}) {
+		t.Errorf("audited chats = %v, want [200]", audited)
+	}
+
+	if _, err := svc.CopySettings(context.Background(), 100, 5, []int64{200}, []string{CopySectionSchedules}); err != nil {
+		t.Fatalf("CopySettings(schedules) error = %v", err)
+	}
+	if settings[200].Timezone != "Europe/Moscow" || len(rules[200]) != 1 || !rules[200][0].ReadOnly {
+		t.Errorf("schedule copy = %q / %+v", settings[200].Timezone, rules[200])
+	}
+
+	if _, err := svc.PreviewSettingsCopy(context.Background(), 100, []int64{200}, []string{"ladder"}); !errors.Is(err, ErrUnknownCopySection) {
+		t.Errorf("PreviewSettingsCopy(unknown) error = %v, want %v", err, ErrUnknownCopySection)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"time"
+)
+
+var (
+	ErrAuditEntryNotFound  = errors.New("audit entry not found")
+	ErrNoMatchedRule       = errors.New("deletion has no removable rule")
+	ErrRuleAlreadyRemoved  = errors.New("rule is already removed")
+	ErrStrikeAlreadyUndone = errors.New("strike is already undone")
+)
+
+const strikeMuteLinkWindow = time.Minute
+
+var attachmentRuleSettings = map[string]string{
+	"image_filter": "image",
+	"video_filter": "video",
+	"audio_filter": "audio",
+	"file_filter":  "file",
+}
+
+func CorrectionActions(auditID uint, rule string) []NotifyAction {
+	var actions []NotifyAction
+	if rule != "" {
+		actions = append(actions, NotifyAction{Label: messages.BtnCorrectionRemoveRule, Payload: fmt.Sprintf("fxr_%d", auditID)})
+	}
+	return append(actions,
+		NotifyAction{Label: messages.BtnCorrectionExempt, Payload: fmt.Sprintf("fxe_%d", auditID)},
+		NotifyAction{Label: messages.BtnCorrectionUndo, Payload: fmt.Sprintf("fxu_%d", auditID)},
+	)
+}
+
+func (s *ModerationService) NotifyDeletion(ctx context.Context, entry repository.AuditEntry, userName string) {
+	_, span := s.tracer.Start(ctx, "NotifyDeletion")
+	defer span.End()
+
+	if entry.ID == 0 {
+		return
+	}
+	rule := entry.Rule
+	if rule == "" {
+		rule = "—"
+	}
+	text := fmt.Sprintf(messages.MsgNotifyDeletion, userName, entry.TargetUserID, entry.Filter, rule, entry.Reason)
+	s.notifyAdmins(ctx, entry.ChatID, NotifyEventDeletes, text, CorrectionActions(entry.ID, entry.Rule)...)
+}
+
+func (s *ModerationService) correctionEntry(auditID uint) (*repository.AuditEntry, error) {
+	entry, err := s.auditRepo.Get(auditID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.Action != repository.AuditActionDelete {
+		return nil, ErrAuditEntryNotFound
+	}
+	return entry, nil
+}
+
+func (s *ModerationService) RemoveMatchedRule(ctx context.Context, auditID uint, adminID int64) error {
+	ctx, span := s.tracer.Start(ctx, "RemoveMatchedRule")
+	defer span.End()
+
+	entry, err := s.correctionEntry(auditID)
+	if err != nil {
+		return err
+	}
+	if entry.Rule == "" {
+		return ErrNoMatchedRule
+	}
+
+	var removed bool
+	action := repository.AuditActionBlocklist
+	reason := ""
+	switch entry.Filter {
+	case "word_filter":
+		removed, err = s.RemoveBlockedWord(ctx, entry.ChatID, entry.Rule)
+		reason = "remove_words: " + entry.Rule
+	case "link_filter":
+		removed, err = s.RemoveBlockedDomain(ctx, entry.ChatID, entry.Rule)
+		reason = "remove_domains: " + entry.Rule
+	default:
+		kind, ok := attachmentRuleSettings[entry.Filter]
+		if !ok {
+			return ErrNoMatchedRule
+		}
+		removed, err = s.liftAttachmentRestriction(entry.ChatID, kind)
+		action = repository.AuditActionSetting
+		reason = fmt.Sprintf("restrict_%s=false", kind)
+	}
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrRuleAlreadyRemoved
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       entry.ChatID,
+		ActorID:      adminID,
+		TargetUserID: entry.TargetUserID,
+		Action:       action,
+		Filter:       entry.Filter,
+		Rule:         entry.Rule,
+		Reason:       reason,
+	})
+	return nil
+}
+
+func (s *ModerationService) liftAttachmentRestriction(chatID int64, kind string) (bool, error) {
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	var restricted *bool
+	switch kind {
+	case "image":
+		restricted = &settings.RestrictImage
+	case "video":
+		restricted = &settings.RestrictVideo
+	case "audio":
+		restricted = &settings.RestrictAudio
+	case "file":
+		restricted = &settings.RestrictFile
+	default:
+		return false, fmt.Errorf("unknown attachment type: %s", kind)
+	}
+	if !*restricted {
+		return false, nil
+	}
+	*restricted = false
+	return true, s.settingsRepo.UpdateSettings(settings)
+}
+
+func (s *ModerationService) ExemptUser(ctx context.Context, auditID uint, adminID int64) error {
+	ctx, span := s.tracer.Start(ctx, "ExemptUser")
+	defer span.End()
+
+	entry, err := s.correctionEntry(auditID)
+	if err != nil {
+		return err
+	}
+	return s.TrustUser(ctx, entry.ChatID, adminID, entry.TargetUserID)
+}
+
+func (s *ModerationService) UndoStrike(ctx context.Context, auditID uint, adminID int64) (bool, bool, error) {
+	ctx, span := s.tracer.Start(ctx, "UndoStrike")
+	defer span.End()
+
+	entry, err := s.correctionEntry(auditID)
+	if err != nil {
+		return false, false, err
+	}
+	claimed, err := s.auditRepo.MarkUndone(auditID)
+	if err != nil {
+		return false, false, err
+	}
+	if !claimed {
+		return false, false, ErrStrikeAlreadyUndone
+	}
+	if entry.MessageID == "" {
+		return false, false, nil
+	}
+
+	removed, err := s.violationRepo.DeleteViolationByMessage(ctx, entry.ChatID, entry.TargetUserID, entry.Filter, entry.MessageID)
+	if err != nil {
+		return false, false, err
+	}
+	if removed == nil {
+		return false, false, nil
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       entry.ChatID,
+		ActorID:      adminID,
+		TargetUserID: entry.TargetUserID,
+		Action:       repository.AuditActionUnwarn,
+		Filter:       entry.Filter,
+		Rule:         entry.Rule,
+		Reason:       fmt.Sprintf(messages.MsgCorrectionAuditReason, auditID),
+		MessageID:    entry.MessageID,
+	})
+
+	if !s.strikeMuteFrom(entry.ChatID, entry.TargetUserID, removed) {
+		return true, false, nil
+	}
+	if err := s.liftMute(ctx, entry.ChatID, entry.TargetUserID, adminID, repository.SanctionEndUnmuted); err != nil {
+		return true, false, err
+	}
+	return true, true, nil
+}
+
+func (s *ModerationService) strikeMuteFrom(chatID, userID int64, violation *repository.UserViolation) bool {
+	if s.historyRepo == nil {
+		return false
+	}
+	record, err := s.historyRepo.GetActive(chatID, userID, SanctionMute)
+	if err != nil {
+		s.logger.Error("Failed to get active mute record", "chat_id", chatID, "user_id", userID, "error", err)
+		return false
+	}
+	if record == nil || record.Source != SanctionSourceStrikeLadder {
+		return false
+	}
+	return !record.CreatedAt.Before(violation.CreatedAt) && record.CreatedAt.Sub(violation.CreatedAt) <= strikeMuteLinkWindow
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestModerationService_RemoveMatchedRule(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	tests := []struct {
+		name        string
+		entry       *repository.AuditEntry
+		settings    repository.ChatSettings
+		wantErr     error
+		wantWords   []string
+		wantDomains []string
+		wantImage   bool
+		wantAudit   string
+	}{
+		{
+			name:      "Word rule",
+			entry:     &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionDelete, Filter: "word_filter", Rule: "spam"},
+			settings:  repository.ChatSettings{BlockedWords: []string{"spam", "scam"}},
+			wantWords: []string{"scam"},
+			wantAudit: repository.AuditActionBlocklist,
+		},
+		{
+			name:        "Domain rule",
+			entry:       &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionDelete, Filter: "link_filter", Rule: "bad.com"},
+			settings:    repository.ChatSettings{BlockedDomains: []string{"bad.com", "evil.org"}},
+			wantDomains: []string{"evil.org"},
+			wantAudit:   repository.AuditActionBlocklist,
+		},
+		{
+			name:      "Attachment rule",
+			entry:     &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionDelete, Filter: "image_filter", Rule: "image"},
+			settings:  repository.ChatSettings{RestrictImage: true},
+			wantAudit: repository.AuditActionSetting,
+		},
+		{
+			name:     "Already removed",
+			entry:    &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionDelete, Filter: "word_filter", Rule: "spam"},
+			settings: repository.ChatSettings{BlockedWords: []string{"scam"}},
+			wantErr:  ErrRuleAlreadyRemoved,
+		},
+		{
+			name:    "No rule",
+			entry:   &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionDelete, Filter: "rate_limit_filter"},
+			wantErr: ErrNoMatchedRule,
+		},
+		{
+			name:    "Not a deletion",
+			entry:   &repository.AuditEntry{ID: 1, ChatID: 100, Action: repository.AuditActionWarn, Rule: "spam"},
+			wantErr: ErrAuditEntryNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *repository.ChatSettings
+			var audited *repository.AuditEntry
+			settingsRepo := &MockSettingsRepository{
+				GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+					settings := tt.settings
+					return &settings, nil
+				},
+				UpdateSettingsFunc: func(settings *repository.ChatSettings) error {
+					updated = settings
+					return nil
+				},
+			}
+			auditRepo := &MockAuditRepository{
+				GetFunc: func(id uint) (*repository.AuditEntry, error) {
+					return tt.entry, nil
+				},
+				AddFunc: func(entry *repository.AuditEntry) error {
+					audited = entry
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, nil, nil, nil)
+
+			err := svc.RemoveMatchedRule(context.Background(), 1, 5)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RemoveMatchedRule() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if updated != nil {
+					t.Error("settings updated on failed correction")
+				}
+				return
+			}
+			if updated == nil {
+				t.Fatal("settings not updated")
+			}
+			if tt.wantWords != nil && !reflect.DeepEqual([]string(updated.BlockedWords), tt.wantWords) {
+				t.Errorf("words = %v, want %v", updated.BlockedWords, tt.wantWords)
+			}
+			if tt.wantDomains != nil && !reflect.DeepEqual([]string(updated.BlockedDomains), tt.wantDomains) {
+				t.Errorf("domains = %v, want %v", updated.BlockedDomains, tt.wantDomains)
+			}
+			if updated.RestrictImage != tt.wantImage {
+				t.Errorf("RestrictImage = %v, want %v", updated.RestrictImage, tt.wantImage)
+			}
+			if audited == nil || audited.Action != tt.wantAudit || audited.ActorID != 5 || audited.Rule != tt.entry.Rule {
+				t.Errorf("audit entry = %+v, want %s by 5", audited, tt.wantAudit)
+			}
+		})
+	}
+}
+
+func TestModerationService_UndoStrike(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	struck := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		violation   *repository.UserViolation
+		mute        *repository.SanctionRecord
+		wantRemoved bool
+		wantUnmuted bool
+	}{
+		{
+			name:        "Strike and its ladder mute",
+			violation:   &repository.UserViolation{ID: 1, CreatedAt: struck},
+			mute:        &repository.SanctionRecord{Source: SanctionSourceStrikeLadder, CreatedAt: struck.Add(time.Second)},
+			wantRemoved: true,
+			wantUnmuted: true,
+		},
+		{
+			name:        "Manual mute is kept",
+			violation:   &repository.UserViolation{ID: 1, CreatedAt: struck},
+			mute:        &repository.SanctionRecord{Source: SanctionSourceAdmin, CreatedAt: struck.Add(time.Second)},
+			wantRemoved: true,
+		},
+		{
+			name:        "Ladder mute from another strike is kept",
+			violation:   &repository.UserViolation{ID: 1, CreatedAt: struck},
+			mute:        &repository.SanctionRecord{Source: SanctionSourceStrikeLadder, CreatedAt: struck.Add(30 * time.Minute)},
+			wantRemoved: true,
+		},
+		{name: "Strike only", violation: &repository.UserViolation{ID: 1, CreatedAt: struck}, wantRemoved: true},
+		{name: "Nothing to undo", mute: &repository.SanctionRecord{Source: SanctionSourceStrikeLadder, CreatedAt: struck}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletedType, deletedMessage string
+			unmuted := false
+			violationRepo := &MockViolationRepository{
+				DeleteViolationByMessageFunc: func(ctx context.Context, chatID, userID int64, violationType, messageID string) (*repository.UserViolation, error) {
+					deletedType = violationType
+					deletedMessage = messageID
+					return tt.violation, nil
+				},
+			}
+			muteRepo := &MockMuteRepository{
+				UnmuteUserFunc: func(chatID, userID int64) error {
+					unmuted = true
+					return nil
+				},
+			}
+			historyRepo := &MockSanctionHistoryRepository{
+				GetActiveFunc: func(chatID, userID int64, sanctionType string) (*repository.SanctionRecord, error) {
+					if sanctionType != SanctionMute {
+						t.Errorf("GetActive() type = %q, want %q", sanctionType, SanctionMute)
+					}
+					return tt.mute, nil
+				},
+			}
+			auditRepo := &MockAuditRepository{
+				GetFunc: func(id uint) (*repository.AuditEntry, error) {
+					return &repository.AuditEntry{ID: id, ChatID: 100, TargetUserID: 456, Action: repository.AuditActionDelete, Filter: "word_filter", Rule: "spam", MessageID: "mid.1"}, nil
+				},
+			}
+			svc := NewModerationService(logger, nil, nil, nil, muteRepo, nil, violationRepo, nil, nil, nil, nil, nil, nil, historyRepo, auditRepo, nil, nil, nil, nil, nil)
+
+			removed, wasUnmuted, err := svc.UndoStrike(context.Background(), 7, 5)
+			if err != nil {
+				t.Fatalf("UndoStrike() error = %v", err)
+			}
+			if deletedType != "word_filter" || deletedMessage != "mid.1" {
+				t.Errorf("deleted violation = (%q, %q), want (word_filter, mid.1)", deletedType, deletedMessage)
+			}
+			if removed != tt.wantRemoved || wasUnmuted != tt.wantUnmuted || unmuted != tt.wantUnmuted {
+				t.Errorf("UndoStrike() = (%v, %v), want (%v, %v)", removed, wasUnmuted, tt.wantRemoved, tt.wantUnmuted)
+			}
+		})
+	}
+}
+
+func TestModerationService_UndoStrike_Once(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	undone := false
+	deletes := 0
+	violationRepo := &MockViolationRepository{
+		DeleteViolationByMessageFunc: func(ctx context.Context, chatID, userID int64, violationType, messageID string) (*repository.UserViolation, error) {
+			deletes++
+			return &repository.UserViolation{ID: int64(deletes)}, nil
+		},
+	}
+	auditRepo := &MockAuditRepository{
+		GetFunc: func(id uint) (*repository.AuditEntry, error) {
+			return &repository.AuditEntry{ID: id, ChatID: 100, TargetUserID: 456, Action: repository.AuditActionDelete, Filter: "word_filter", MessageID: "mid.1"}, nil
+		},
+		MarkUndoneFunc: func(id uint) (bool, error) {
+			if undone {
+				return false, nil
+			}
+			undone = true
+			return true, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, nil, nil, nil)
+
+	if removed, _, err := svc.UndoStrike(context.Background(), 7, 5); err != nil || !removed {
+		t.Fatalf("UndoStrike() first click removed = %v, error = %v", removed, err)
+	}
+	if _, _, err := svc.UndoStrike(context.Background(), 7, 5); !errors.Is(err, ErrStrikeAlreadyUndone) {
+		t.Errorf("UndoStrike() second click error = %v, want ErrStrikeAlreadyUndone", err)
+	}
+	if deletes != 1 {
+		t.Errorf("violations deleted = %d, want 1", deletes)
+	}
+}
+
+func TestModerationService_NotifyDeletion(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	notificationRepo := &MockNotificationRepository{
+		GetFunc: func(chatID, userID int64) (*repository.NotificationPref, error) {
+			pref := defaultNotificationPref(chatID, userID)
+			pref.NotifyDeletes = userID == 1
+			return pref, nil
+		},
+	}
+	chatAdminRepo := &MockChatAdminRepository{
+		GetAdminsFunc: func(chatID int64) ([]int64, error) {
+			return []int64{1, 2}, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, chatAdminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo, nil, nil).(*ModerationService)
+	notifier := &fakeNotifier{}
+	svc.notifier = notifier
+
+	svc.NotifyDeletion(context.Background(), repository.AuditEntry{ID: 42, ChatID: 100, TargetUserID: 456, Filter: "word_filter", Rule: "spam"}, "Spammer")
+
+	if len(notifier.sent[1]) != 1 || len(notifier.sent[2]) != 0 {
+		t.Fatalf("notifications = %v, want only admin 1", notifier.sent)
+	}
+	want := []NotifyAction{
+		{Label: CorrectionActions(42, "spam")[0].Label, Payload: "fxr_42"},
+		{Label: CorrectionActions(42, "spam")[1].Label, Payload: "fxe_42"},
+		{Label: CorrectionActions(42, "spam")[2].Label, Payload: "fxu_42"},
+	}
+	if !reflect.DeepEqual(notifier.actions[1], want) {
+		t.Errorf("actions = %v, want %v", notifier.actions[1], want)
+	}
+	if actions := CorrectionActions(42, ""); len(actions) != 2 || actions[0].Payload != "fxe_42" {
+		t.Errorf("CorrectionActions without rule = %v, want exempt and undo only", actions)
+	}
+}
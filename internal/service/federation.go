@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"strings"
+	"time"
+)
+
+const suspectDuration = 24 * time.Hour
+
+var ErrChatInOtherGroup = errors.New("chat already belongs to another owner's group")
+
+var suspectViolationTypes = map[string]bool{
+	"link_filter": true,
+	"word_filter": true,
+}
+
+func (s *ModerationService) CreateChatGroup(ctx context.Context, ownerID int64, name string) (*repository.ChatGroup, error) {
+	_, span := s.tracer.Start(ctx, "CreateChatGroup")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("group name is empty")
+	}
+	if runes := []rune(name); len(runes) > 100 {
+		name = string(runes[:100])
+	}
+	return s.federationRepo.CreateGroup(ownerID, name)
+}
+
+func (s *ModerationService) GetChatGroups(ctx context.Context, ownerID int64) ([]repository.ChatGroup, error) {
+	_, span := s.tracer.Start(ctx, "GetChatGroups")
+	defer span.End()
+	return s.federationRepo.GetGroups(ownerID)
+}
+
+func (s *ModerationService) GetChatGroup(ctx context.Context, ownerID int64, groupID uint) (*repository.ChatGroup, []int64, error) {
+	_, span := s.tracer.Start(ctx, "GetChatGroup")
+	defer span.End()
+
+	group, err := s.ownedGroup(ownerID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	chats, err := s.federationRepo.GetGroupChats(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, chats, nil
+}
+
+func (s *ModerationService) DeleteChatGroup(ctx context.Context, ownerID int64, groupID uint) error {
+	_, span := s.tracer.Start(ctx, "DeleteChatGroup")
+	defer span.End()
+
+	if _, err := s.ownedGroup(ownerID, groupID); err != nil {
+		return err
+	}
+	return s.federationRepo.DeleteGroup(groupID)
+}
+
+func (s *ModerationService) ToggleGroupPropagation(ctx context.Context, ownerID int64, groupID uint) (bool, error) {
+	_, span := s.tracer.Start(ctx, "ToggleGroupPropagation")
+	defer span.End()
+
+	group, err := s.ownedGroup(ownerID, groupID)
+	if err != nil {
+		return false, err
+	}
+	if err := s.federationRepo.SetPropagate(groupID, !group.Propagate); err != nil {
+		return false, err
+	}
+	return !group.Propagate, nil
+}
+
+func (s *ModerationService) AddChatToGroup(ctx context.Context, ownerID int64, groupID uint, chatID int64) error {
+	_, span := s.tracer.Start(ctx, "AddChatToGroup")
+	defer span.End()
+
+	if _, err := s.ownedGroup(ownerID, groupID); err != nil {
+		return err
+	}
+	if err := s.requireRole(chatID, ownerID, repository.RoleManager); err != nil {
+		return err
+	}
+	current, err := s.federationRepo.GetChatGroup(chatID)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.OwnerID != ownerID && s.requireRole(chatID, current.OwnerID, repository.RoleManager) == nil {
+		return ErrChatInOtherGroup
+	}
+	return s.federationRepo.AddChat(groupID, chatID)
+}
+
+func (s *ModerationService) RemoveChatFromGroup(ctx context.Context, ownerID int64, groupID uint, chatID int64) error {
+	_, span := s.tracer.Start(ctx, "RemoveChatFromGroup")
+	defer span.End()
+
+	if _, err := s.ownedGroup(ownerID, groupID); err != nil {
+		return err
+	}
+	return s.federationRepo.RemoveChat(groupID, chatID)
+}
+
+func (s *ModerationService) ownedGroup(ownerID int64, groupID uint) (*repository.ChatGroup, error) {
+	group, err := s.federationRepo.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil || group.OwnerID != ownerID {
+		return nil, fmt.Errorf("chat group %d not found for user %d", groupID, ownerID)
+	}
+	return group, nil
+}
+
+func (s *ModerationService) siblingChats(chatID int64) ([]int64, *repository.ChatGroup, error) {
+	if s.federationRepo == nil {
+		return nil, nil, nil
+	}
+	group, err := s.federationRepo.GetChatGroup(chatID)
+	if err != nil || group == nil {
+		return nil, nil, err
+	}
+	chats, err := s.federationRepo.GetGroupChats(group.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	siblings := make([]int64, 0, len(chats))
+	for _, id := range chats {
+		if id != chatID {
+			siblings = append(siblings, id)
+		}
+	}
+	return siblings, group, nil
+}
+
+func (s *ModerationService) propagateSanction(ctx context.Context, chatID, moderatorID int64, apply func(siblingID int64) error) {
+	_, span := s.tracer.Start(ctx, "propagateSanction")
+	defer span.End()
+
+	siblings, group, err := s.siblingChats(chatID)
+	if err != nil {
+		s.logger.Error("Failed to get sibling chats", "chat_id", chatID, "error", err)
+		return
+	}
+	if group == nil || !group.Propagate {
+		return
+	}
+	if err := s.requireRole(chatID, group.OwnerID, repository.RoleManager); err != nil {
+		s.logger.Warn("Chat group owner lost access, sanction not propagated", "chat_id", chatID, "group_id", group.ID, "error", err)
+		return
+	}
+	for _, siblingID := range siblings {
+		if err := s.requireRole(siblingID, group.OwnerID, repository.RoleManager); err != nil {
+			s.logger.Warn("Chat group owner lost access to sibling chat", "sibling_id", siblingID, "group_id", group.ID, "error", err)
+			continue
+		}
+		if err := s.requireRole(siblingID, moderatorID, repository.RoleModerator); err != nil {
+			s.logger.Info("Moderator has no access to sibling chat, sanction not propagated", "sibling_id", siblingID, "moderator_id", moderatorID, "error", err)
+			continue
+		}
+		if err := apply(siblingID); err != nil {
+			s.logger.Error("Failed to propagate sanction", "chat_id", chatID, "sibling_id", siblingID, "error", err)
+		}
+	}
+}
+
+func (s *ModerationService) flagSuspectInSiblings(chatID, userID int64, violationType string) {
+	siblings, _, err := s.siblingChats(chatID)
+	if err != nil {
+		s.logger.Error("Failed to get sibling chats", "chat_id", chatID, "error", err)
+		return
+	}
+	expiresAt := time.Now().Add(suspectDuration)
+	for _, siblingID := range siblings {
+		if err := s.federationRepo.AddSuspect(siblingID, userID, chatID, violationType, expiresAt); err != nil {
+			s.logger.Error("Failed to flag suspect", "chat_id", siblingID, "user_id", userID, "error", err)
+		}
+	}
+}
+
+func (s *ModerationService) isSuspect(ctx context.Context, chatID, userID int64) (bool, error) {
+	if s.federationRepo == nil {
+		return false, nil
+	}
+	return s.federationRepo.IsSuspect(chatID, userID)
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func groupedFederation(propagate bool) *MockFederationRepository {
+	return &MockFederationRepository{
+		GetChatGroupFunc: func(chatID int64) (*repository.ChatGroup, error) {
+			return &repository.ChatGroup{ID: 1, OwnerID: 1, Propagate: propagate}, nil
+		},
+		GetGroupChatsFunc: func(groupID uint) ([]int64, error) {
+			return []int64{100, 200, 300}, nil
+		},
+	}
+}
+
+func TestModerationService_MuteUserPropagation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name      string
+		propagate bool
+		want      []int64
+	}{
+		{name: "Propagation enabled", propagate: true, want: []int64{100, 200, 300}},
+		{name: "Propagation disabled", propagate: false, want: []int64{100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var muted []int64
+			muteRepo := &MockMuteRepository{
+				MuteUserFunc: func(chatID, userID int64, userName, reason string, duration time.Duration) error {
+					muted = append(muted, chatID)
+					return nil
+				},
+			}
+			adminRepo := &MockChatAdminRepository{
+				IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+			}
+			svc := NewModerationService(logger, nil, adminRepo, nil, muteRepo, nil, &MockViolationRepository{}, nil, nil, nil, nil, groupedFederation(tt.propagate), nil, nil, nil, nil, nil, nil, nil, nil)
+
+			if err := svc.MuteUser(context.Background(), 100, 1, 456, "spammer", "спам", time.Hour); err != nil {
+				t.Fatalf("MuteUser() error = %v", err)
+			}
+			sort.Slice(muted, func(i, j int) bool { return muted[i] < muted[j] })
+			if len(muted) != len(tt.want) {
+				t.Fatalf("MuteUser() muted in %v, want %v", muted, tt.want)
+			}
+			for i := range muted {
+				if muted[i] != tt.want[i] {
+					t.Errorf("MuteUser() muted in %v, want %v", muted, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestModerationService_TrackViolationFlagsSuspects(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name          string
+		violationType string
+		want          []int64
+	}{
+		{name: "Spam flags siblings", violationType: "link_filter", want: []int64{200, 300}},
+		{name: "Attachment does not flag", violationType: "attachment_filter", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flagged []int64
+			federation := groupedFederation(false)
+			federation.AddSuspectFunc = func(chatID, userID, sourceChatID int64, reason string, expiresAt time.Time) error {
+				if sourceChatID != 100 || userID != 456 {
+					t.Errorf("AddSuspect() source %d user %d, want 100 and 456", sourceChatID, userID)
+				}
+				flagged = append(flagged, chatID)
+				return nil
+			}
+			violationRepo := &MockViolationRepository{
+				AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
+					return nil
+				},
+				SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+					return 1, nil
+				},
+			}
+			svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil, nil, nil, nil, federation, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			if _, err := svc.TrackViolation(context.Background(), 100, 456, tt.violationType, 0, ""); err != nil {
+				t.Fatalf("TrackViolation() error = %v", err)
+			}
+			if len(flagged) != len(tt.want) {
+				t.Fatalf("TrackViolation() flagged %v, want %v", flagged, tt.want)
+			}
+			for i := range flagged {
+				if flagged[i] != tt.want[i] {
+					t.Errorf("TrackViolation() flagged %v, want %v", flagged, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestModerationService_AddChatToGroupRequiresOwnership(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	added := false
+	federation := &MockFederationRepository{
+		GetGroupFunc: func(groupID uint) (*repository.ChatGroup, error) {
+			return &repository.ChatGroup{ID: groupID, OwnerID: 1}, nil
+		},
+		AddChatFunc: func(groupID uint, chatID int64) error {
+			added = true
+			return nil
+		},
+	}
+	adminRepo := &MockChatAdminRepository{
+		IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+	}
+	svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, &MockViolationRepository{}, nil, nil, nil, nil, federation, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if err := svc.AddChatToGroup(context.Background(), 2, 1, 100); err == nil {
+		t.Error("AddChatToGroup() expected error for foreign group")
+	}
+	if added {
+		t.Error("AddChatToGroup() added chat to a foreign group")
+	}
+	if err := svc.AddChatToGroup(context.Background(), 1, 1, 100); err != nil || !added {
+		t.Errorf("AddChatToGroup() error = %v, added = %v", err, added)
+	}
+}
+
+func chatRolesRepo(roles map[int64]map[int64]string) *MockChatAdminRepository {
+	return &MockChatAdminRepository{
+		GetRoleFunc: func(chatID, userID int64) (string, error) {
+			return roles[chatID][userID], nil
+		},
+	}
+}
+
+func TestModerationService_PropagationRequiresSiblingAccess(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name  string
+		roles map[int64]map[int64]string
+		want  []int64
+	}{
+		{
+			name: "Moderator in all chats",
+			roles: map[int64]map[int64]string{
+				100: {1: repository.RoleOwner, 5: repository.RoleModerator},
+				200: {1: repository.RoleOwner, 5: repository.RoleModerator},
+				300: {1: repository.RoleOwner, 5: repository.RoleModerator},
+			},
+			want: []int64{100, 200, 300},
+		},
+		{
+			name: "Moderator of one sibling only",
+			roles: map[int64]map[int64]string{
+				100: {1: repository.RoleOwner, 5: repository.RoleModerator},
+				200: {1: repository.RoleOwner, 5: repository.RoleViewer},
+				300: {1: repository.RoleOwner, 5: repository.RoleModerator},
+			},
+			want: []int64{100, 300},
+		},
+		{
+			name: "Group owner lost sibling access",
+			roles: map[int64]map[int64]string{
+				100: {1: repository.RoleOwner, 5: repository.RoleModerator},
+				200: {5: repository.RoleModerator},
+				300: {1: repository.RoleOwner, 5: repository.RoleModerator},
+			},
+			want: []int64{100, 300},
+		},
+		{
+			name: "Group owner lost source access",
+			roles: map[int64]map[int64]string{
+				100: {5: repository.RoleOwner},
+				200: {1: repository.RoleOwner, 5: repository.RoleModerator},
+				300: {1: repository.RoleOwner, 5: repository.RoleModerator},
+			},
+			want: []int64{100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var banned []int64
+			banRepo := &MockBanRepository{
+				BanUserFunc: func(chatID, userID int64, userName string, duration time.Duration) error {
+					banned = append(banned, chatID)
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, nil, chatRolesRepo(tt.roles), nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, nil, groupedFederation(true), nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+			svc.remover = &fakeRemover{}
+
+			_ = svc.BanUser(context.Background(), 100, 5, 456, "spammer", "спам", 0)
+			sort.Slice(banned, func(i, j int) bool { return banned[i] < banned[j] })
+			if !reflect.DeepEqual(banned, tt.want) {
+				t.Errorf("BanUser() banned in %v, want %v", banned, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_AddChatToGroupRejectsForeignMembership(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	for _, foreignOwnerRole := range []string{repository.RoleOwner, ""} {
+		added := false
+		federation := &MockFederationRepository{
+			GetGroupFunc: func(groupID uint) (*repository.ChatGroup, error) {
+				return &repository.ChatGroup{ID: groupID, OwnerID: 2}, nil
+			},
+			GetChatGroupFunc: func(chatID int64) (*repository.ChatGroup, error) {
+				return &repository.ChatGroup{ID: 7, OwnerID: 1}, nil
+			},
+			AddChatFunc: func(groupID uint, chatID int64) error {
+				added = true
+				return nil
+			},
+		}
+		roles := map[int64]map[int64]string{100: {1: foreignOwnerRole, 2: repository.RoleManager}}
+		svc := NewModerationService(logger, nil, chatRolesRepo(roles), nil, nil, nil, &MockViolationRepository{}, nil, nil, nil, nil, federation, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		err := svc.AddChatToGroup(context.Background(), 2, 3, 100)
+		if foreignOwnerRole != "" {
+			if !errors.Is(err, ErrChatInOtherGroup) || added {
+				t.Errorf("AddChatToGroup() error = %v, added = %v, want ErrChatInOtherGroup", err, added)
+			}
+			continue
+		}
+		if err != nil || !added {
+			t.Errorf("AddChatToGroup() after owner lost access error = %v, added = %v", err, added)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"strconv"
+	"time"
+)
+
+func (s *ModerationService) SetStrikeDecay(ctx context.Context, chatID int64, days int) error {
+	_, span := s.tracer.Start(ctx, "SetStrikeDecay")
+	defer span.End()
+
+	if days < 0 {
+		return fmt.Errorf("invalid strike decay period: %d", days)
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	settings.StrikeDecayDays = days
+	return s.settingsRepo.UpdateSettings(settings)
+}
+
+func (s *ModerationService) ForgiveUser(ctx context.Context, chatID, moderatorID, userID int64) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "ForgiveUser")
+	defer span.End()
+	if err := s.requireRole(chatID, moderatorID, repository.RoleModerator); err != nil {
+		return 0, err
+	}
+	forgiven, err := s.violationRepo.ForgiveViolations(ctx, chatID, userID, moderatorID)
+	if err != nil || forgiven == 0 {
+		return forgiven, err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: moderatorID, TargetUserID: userID, Action: repository.AuditActionForgive, Reason: strconv.Itoa(forgiven)})
+	return forgiven, nil
+}
+
+func (s *ModerationService) StartStrikeDecayTask(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour)
+
+	sweep := func() {
+		forgiven, err := s.violationRepo.DecayViolations(ctx, time.Now())
+		if err != nil {
+			s.logger.Error("Failed to decay violations", "error", err)
+			return
+		}
+		if forgiven > 0 {
+			s.logger.Info("Decayed violations", "count", forgiven)
+		}
+	}
+
+	go func() {
+		defer ticker.Stop()
+		sweep()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+)
+
+func TestModerationService_ForgiveUser(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	var gotModerator, gotUser int64
+	violationRepo := &MockViolationRepository{
+		ForgiveViolationsFunc: func(ctx context.Context, chatID, userID, moderatorID int64) (int, error) {
+			gotUser, gotModerator = userID, moderatorID
+			return 3, nil
+		},
+	}
+	roles := map[int64]string{1: repository.RoleModerator, 2: repository.RoleViewer}
+	svc := NewModerationService(logger, nil, rolesRepo(roles), nil, nil, nil, violationRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if _, err := svc.ForgiveUser(context.Background(), 100, 2, 456); !errors.Is(err, ErrInsufficientRole) {
+		t.Errorf("ForgiveUser() by viewer error = %v, want ErrInsufficientRole", err)
+	}
+	forgiven, err := svc.ForgiveUser(context.Background(), 100, 1, 456)
+	if err != nil {
+		t.Fatalf("ForgiveUser() error = %v", err)
+	}
+	if forgiven != 3 {
+		t.Errorf("ForgiveUser() forgiven = %d, want 3", forgiven)
+	}
+	if gotUser != 456 || gotModerator != 1 {
+		t.Errorf("ForgiveUser() recorded user %d by %d, want 456 by 1", gotUser, gotModerator)
+	}
+}
+
+func TestModerationService_SetStrikeDecay(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name    string
+		days    int
+		wantErr bool
+	}{
+		{name: "Enable", days: 7, wantErr: false},
+		{name: "Disable", days: 0, wantErr: false},
+		{name: "Negative", days: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *repository.ChatSettings
+			settingsRepo := &MockSettingsRepository{
+				UpdateSettingsFunc: func(settings *repository.ChatSettings) error {
+					saved = settings
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, &MockViolationRepository{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			err := svc.SetStrikeDecay(context.Background(), 100, tt.days)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetStrikeDecay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (saved == nil || saved.StrikeDecayDays != tt.days) {
+				t.Errorf("SetStrikeDecay() saved = %+v, want %d days", saved, tt.days)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"max-moderation-bot/internal/metrics"
+	"max-moderation-bot/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	GlobalBanFormatCSV  = "csv"
+	GlobalBanFormatJSON = "json"
+
+	GlobalBanTriggerJoin    = "join"
+	GlobalBanTriggerMessage = "message"
+)
+
+type globalBanEntry struct {
+	UserID    int64     `json:"user_id"`
+	Reason    string    `json:"reason,omitempty"`
+	AddedBy   int64     `json:"added_by,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (s *ModerationService) AddGlobalBan(ctx context.Context, operatorID, userID int64, reason string) error {
+	_, span := s.tracer.Start(ctx, "AddGlobalBan")
+	defer span.End()
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user id: %d", userID)
+	}
+	return s.globalBanRepo.Add(repository.GlobalBan{
+		UserID:  userID,
+		Reason:  truncateReason(reason),
+		AddedBy: operatorID,
+	})
+}
+
+func (s *ModerationService) RemoveGlobalBan(ctx context.Context, userID int64) (bool, error) {
+	_, span := s.tracer.Start(ctx, "RemoveGlobalBan")
+	defer span.End()
+	return s.globalBanRepo.Remove(userID)
+}
+
+func (s *ModerationService) CountGlobalBans(ctx context.Context) (int64, error) {
+	_, span := s.tracer.Start(ctx, "CountGlobalBans")
+	defer span.End()
+	return s.globalBanRepo.Count()
+}
+
+func (s *ModerationService) ImportGlobalBans(ctx context.Context, operatorID int64, data []byte, format string) (int, error) {
+	_, span := s.tracer.Start(ctx, "ImportGlobalBans")
+	defer span.End()
+
+	bans, err := ParseGlobalBanList(data, format)
+	if err != nil {
+		return 0, err
+	}
+	for i := range bans {
+		bans[i].AddedBy = operatorID
+	}
+	return s.globalBanRepo.AddMany(bans)
+}
+
+func (s *ModerationService) ExportGlobalBans(ctx context.Context, format string) ([]byte, error) {
+	_, span := s.tracer.Start(ctx, "ExportGlobalBans")
+	defer span.End()
+
+	bans, err := s.globalBanRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return FormatGlobalBanList(bans, format)
+}
+
+func (s *ModerationService) EnforceGlobalBan(ctx context.Context, chatID, userID int64, trigger string) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "EnforceGlobalBan")
+	defer span.End()
+
+	if s.globalBanRepo == nil {
+		return false, nil
+	}
+	listed, err := s.globalBanRepo.IsListed(userID)
+	if err != nil || !listed {
+		return false, err
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.UseGlobalBans {
+		return false, nil
+	}
+	if err := s.KickUser(ctx, chatID, 0, userID, "", "", SanctionSourceGlobalBan); err != nil {
+		return false, err
+	}
+	if err := s.globalBanRepo.LogEnforcement(chatID, userID, trigger); err != nil {
+		s.logger.Error("Failed to log global ban enforcement", "chat_id", chatID, "user_id", userID, "error", err)
+	}
+	s.logger.Info("Global ban enforced", "chat_id", chatID, "user_id", userID, "trigger", trigger)
+	metrics.IncBotAction("global_ban_enforced")
+	return true, nil
+}
+
+func truncateReason(reason string) string {
+	reason = strings.TrimSpace(reason)
+	if runes := []rune(reason); len(runes) > 500 {
+		reason = string(runes[:500])
+	}
+	return reason
+}
+
+func DetectGlobalBanFormat(data []byte) string {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return GlobalBanFormatJSON
+	}
+	return GlobalBanFormatCSV
+}
+
+func ParseGlobalBanList(data []byte, format string) ([]repository.GlobalBan, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if format == "" {
+		format = DetectGlobalBanFormat(data)
+	}
+	var bans []repository.GlobalBan
+	var err error
+	switch format {
+	case GlobalBanFormatJSON:
+		bans, err = parseGlobalBanJSON(data)
+	case GlobalBanFormatCSV:
+		bans, err = parseGlobalBanCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(bans))
+	unique := bans[:0]
+	for _, ban := range bans {
+		if ban.UserID <= 0 || seen[ban.UserID] {
+			continue
+		}
+		seen[ban.UserID] = true
+		ban.Reason = truncateReason(ban.Reason)
+		unique = append(unique, ban)
+	}
+	return unique, nil
+}
+
+func parseGlobalBanJSON(data []byte) ([]repository.GlobalBan, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+	bans := make([]repository.GlobalBan, 0, len(raw))
+	for i, item := range raw {
+		var id int64
+		if err := json.Unmarshal(item, &id); err == nil {
+			bans = append(bans, repository.GlobalBan{UserID: id})
+			continue
+		}
+		var entry globalBanEntry
+		if err := json.Unmarshal(item, &entry); err != nil {
+			return nil, fmt.Errorf("invalid json entry %d: %w", i+1, err)
+		}
+		bans = append(bans, repository.GlobalBan{UserID: entry.UserID, Reason: entry.Reason})
+	}
+	return bans, nil
+}
+
+func parseGlobalBanCSV(data []byte) ([]repository.GlobalBan, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var bans []repository.GlobalBan
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		idText := strings.TrimSpace(record[0])
+		id, err := strconv.ParseInt(idText, 10, 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid user id on line %d: %s", line, idText)
+		}
+		ban := repository.GlobalBan{UserID: id}
+		if len(record) > 1 {
+			ban.Reason = record[1]
+		}
+		bans = append(bans, ban)
+	}
+	return bans, nil
+}
+
+func FormatGlobalBanList(bans []repository.GlobalBan, format string) ([]byte, error) {
+	switch format {
+	case GlobalBanFormatJSON:
+		entries := make([]globalBanEntry, len(bans))
+		for i, ban := range bans {
+			entries[i] = globalBanEntry{
+				UserID:    ban.UserID,
+				Reason:    ban.Reason,
+				AddedBy:   ban.AddedBy,
+				CreatedAt: ban.CreatedAt.UTC(),
+			}
+		}
+		return json.MarshalIndent(entries, "", "  ")
+	case GlobalBanFormatCSV:
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write([]string{"user_id", "reason", "added_by", "created_at"}); err != nil {
+			return nil, err
+		}
+		for _, ban := range bans {
+			record := []string{
+				strconv.FormatInt(ban.UserID, 10),
+				ban.Reason,
+				strconv.FormatInt(ban.AddedBy, 10),
+				ban.CreatedAt.UTC().Format(time.RFC3339),
+			}
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		writer.Flush()
+		return buf.Bytes(), writer.Error()
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseGlobalBanList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		want    []repository.GlobalBan
+		wantErr bool
+	}{
+		{
+			name:   "CSV with header",
+			input:  "user_id,reason\n101,spam\n102,\"scam, links\"\n",
+			format: GlobalBanFormatCSV,
+			want:   []repository.GlobalBan{{UserID: 101, Reason: "spam"}, {UserID: 102, Reason: "scam, links"}},
+		},
+		{
+			name:  "CSV without header detected",
+			input: "101\n\n102,bot\n101,dup\n",
+			want:  []repository.GlobalBan{{UserID: 101}, {UserID: 102, Reason: "bot"}},
+		},
+		{
+			name:    "CSV invalid id",
+			input:   "101\nabc\n",
+			format:  GlobalBanFormatCSV,
+			wantErr: true,
+		},
+		{
+			name:  "JSON objects",
+			input: `[{"user_id": 201, "reason": "spam"}, {"user_id": 0}]`,
+			want:  []repository.GlobalBan{{UserID: 201, Reason: "spam"}},
+		},
+		{
+			name:   "JSON numbers",
+			input:  "\ufeff[301, 302]",
+			format: GlobalBanFormatJSON,
+			want:   []repository.GlobalBan{{UserID: 301}, {UserID: 302}},
+		},
+		{
+			name:    "JSON invalid",
+			input:   `[{"user_id": "x"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGlobalBanList([]byte(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGlobalBanList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGlobalBanList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGlobalBanList_RoundTrip(t *testing.T) {
+	bans := []repository.GlobalBan{{UserID: 1, Reason: "spam, ads"}, {UserID: 2}}
+	for _, format := range []string{GlobalBanFormatCSV, GlobalBanFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			data, err := FormatGlobalBanList(bans, format)
+			if err != nil {
+				t.Fatalf("FormatGlobalBanList() error = %v", err)
+			}
+			got, err := ParseGlobalBanList(data, format)
+			if err != nil {
+				t.Fatalf("ParseGlobalBanList() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, bans) {
+				t.Errorf("round trip = %v, want %v", got, bans)
+			}
+		})
+	}
+}
+
+func TestModerationService_EnforceGlobalBan(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	tests := []struct {
+		name         string
+		listed       bool
+		useGlobal    bool
+		wantEnforced bool
+		wantErr      bool
+	}{
+		{name: "Not listed", listed: false, useGlobal: true},
+		{name: "Chat opted out", listed: true, useGlobal: false},
+		{name: "Kick failure", listed: true, useGlobal: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logged := false
+			globalBanRepo := &MockGlobalBanRepository{
+				IsListedFunc: func(userID int64) (bool, error) { return tt.listed, nil },
+				LogEnforcementFunc: func(chatID, userID int64, trigger string) error {
+					logged = true
+					return nil
+				},
+			}
+			settingsRepo := &MockSettingsRepository{
+				GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+					return &repository.ChatSettings{ChatID: chatID, UseGlobalBans: tt.useGlobal}, nil
+				},
+			}
+			svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, &MockViolationRepository{}, nil, nil, nil, nil, nil, globalBanRepo, nil, nil, nil, nil, nil, nil, nil)
+
+			enforced, err := svc.EnforceGlobalBan(context.Background(), 100, 456, GlobalBanTriggerJoin)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnforceGlobalBan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if enforced != tt.wantEnforced {
+				t.Errorf("EnforceGlobalBan() = %v, want %v", enforced, tt.wantEnforced)
+			}
+			if logged {
+				t.Error("EnforceGlobalBan() logged an enforcement that did not happen")
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/utils"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportModeMerge   = "merge"
+	ImportModeReplace = "replace"
+
+	MaxImportSize     = 5 << 20
+	maxImportEntryLen = 200
+)
+
+var ErrUnsupportedImportFile = errors.New("unsupported import file")
+
+var matchModeAliases = map[string]string{
+	"":          "",
+	"contains":  repository.MatchContains,
+	"substring": repository.MatchContains,
+	"подстрока": repository.MatchContains,
+	"word":      repository.MatchWord,
+	"слово":     repository.MatchWord,
+	"exact":     repository.MatchExact,
+	"точно":     repository.MatchExact,
+}
+
+var severityAliases = map[string]int{
+	"low":     1,
+	"низкая":  1,
+	"medium":  2,
+	"средняя": 2,
+	"high":    3,
+	"высокая": 3,
+}
+
+type ImportEntry struct {
+	List     string `json:"list"`
+	Pattern  string `json:"pattern"`
+	Mode     string `json:"mode,omitempty"`
+	Severity int    `json:"severity,omitempty"`
+}
+
+type BlocklistImport struct {
+	Entries []ImportEntry `json:"entries"`
+	Invalid []string      `json:"invalid,omitempty"`
+}
+
+type ImportListDiff struct {
+	Added      []string
+	Duplicates int
+	Removed    []string
+}
+
+type ImportDiff struct {
+	Words   ImportListDiff
+	Domains ImportListDiff
+	Invalid []string
+}
+
+func ParseBlocklistImport(name string, data []byte, defaultList string) (*BlocklistImport, error) {
+	imp := &BlocklistImport{}
+	if err := imp.parseFile(name, data, defaultList, true); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+func (imp *BlocklistImport) parseFile(name string, data []byte, defaultList string, allowArchive bool) error {
+	list := importListForFile(name, defaultList)
+	switch strings.ToLower(path.Ext(name)) {
+	case ".zip":
+		if !allowArchive {
+			return fmt.Errorf("%w: nested archive %s", ErrUnsupportedImportFile, name)
+		}
+		return imp.parseZip(data, defaultList)
+	case ".csv":
+		return imp.parseCSV(data, list)
+	case ".txt", "":
+		return imp.parseText(data, list)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedImportFile, name)
+}
+
+func importListForFile(name, defaultList string) string {
+	base := strings.ToLower(path.Base(name))
+	for _, hint := range []string{"domain", "домен", "link", "ссылк"} {
+		if strings.Contains(base, hint) {
+			return BlocklistDomains
+		}
+	}
+	for _, hint := range []string{"word", "phrase", "слов", "фраз"} {
+		if strings.Contains(base, hint) {
+			return BlocklistWords
+		}
+	}
+	return defaultList
+}
+
+func (imp *BlocklistImport) parseZip(data []byte, defaultList string) error {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("invalid zip: %w", err)
+	}
+	var total int64
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".txt", ".csv":
+		default:
+			imp.Invalid = append(imp.Invalid, f.Name)
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, MaxImportSize-total+1))
+		_ = rc.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		total += int64(len(content))
+		if total > MaxImportSize {
+			return fmt.Errorf("archive exceeds %d bytes", MaxImportSize)
+		}
+		if err := imp.parseFile(f.Name, content, defaultList, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stripComment(line string) string {
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line)
+}
+
+func (imp *BlocklistImport) parseText(data []byte, list string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	for scanner.Scan() {
+		line := stripComment(strings.TrimSpace(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		imp.add(line, ImportEntry{List: list, Pattern: line})
+	}
+	return scanner.Err()
+}
+
+func (imp *BlocklistImport) parseCSV(data []byte, list string) error {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Contains(firstLine, []byte(";")) && !bytes.Contains(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid csv: %w", err)
+		}
+		if first {
+			first = false
+			if strings.EqualFold(strings.TrimSpace(record[0]), "pattern") {
+				continue
+			}
+		}
+		raw := strings.Join(record, ",")
+		pattern := strings.TrimSpace(record[0])
+		if pattern == "" {
+			continue
+		}
+		entry := ImportEntry{List: list, Pattern: pattern}
+		if list == BlocklistWords {
+			if len(record) > 1 {
+				mode, ok := matchModeAliases[strings.ToLower(strings.TrimSpace(record[1]))]
+				if !ok {
+					imp.Invalid = append(imp.Invalid, raw)
+					continue
+				}
+				entry.Mode = mode
+			}
+			if len(record) > 2 {
+				severity, ok := parseSeverity(record[2])
+				if !ok {
+					imp.Invalid = append(imp.Invalid, raw)
+					continue
+				}
+				entry.Severity = severity
+			}
+		}
+		imp.add(raw, entry)
+	}
+	return nil
+}
+
+func parseSeverity(value string) (int, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, true
+	}
+	if severity, ok := severityAliases[value]; ok {
+		return severity, true
+	}
+	severity, err := strconv.Atoi(value)
+	if err != nil || severity < 1 || severity > 10 {
+		return 0, false
+	}
+	return severity, true
+}
+
+func (imp *BlocklistImport) add(raw string, entry ImportEntry) {
+	pattern, ok := normalizeImportPattern(entry.List, entry.Pattern)
+	if !ok {
+		imp.Invalid = append(imp.Invalid, raw)
+		return
+	}
+	entry.Pattern = pattern
+	if entry.Mode == repository.MatchContains {
+		entry.Mode = ""
+	}
+	imp.Entries = append(imp.Entries, entry)
+}
+
+func normalizeImportPattern(list, pattern string) (string, bool) {
+	if list == BlocklistDomains {
+		domain := utils.NormalizeDomain(pattern)
+		if domain == "" || !strings.Contains(domain, ".") || strings.ContainsAny(domain, " \t") || len([]rune(domain)) > maxImportEntryLen {
+			return "", false
+		}
+		return domain, true
+	}
+	phrase := strings.ToLower(strings.Join(strings.Fields(pattern), " "))
+	if phrase == "" || len([]rune(phrase)) > maxImportEntryLen {
+		return "", false
+	}
+	return phrase, true
+}
+
+func (imp *BlocklistImport) patterns(list string) ([]string, bool) {
+	var patterns []string
+	present := false
+	for _, entry := range imp.Entries {
+		if entry.List == list {
+			present = true
+			patterns = append(patterns, entry.Pattern)
+		}
+	}
+	return patterns, present
+}
+
+func diffImportList(current, imported []string, present bool, mode string) ImportListDiff {
+	var diff ImportListDiff
+	existing := make(map[string]bool, len(current))
+	for _, entry := range current {
+		existing[entry] = true
+	}
+	seen := make(map[string]bool, len(imported))
+	for _, entry := range imported {
+		if seen[entry] || existing[entry] {
+			diff.Duplicates++
+			seen[entry] = true
+			continue
+		}
+		seen[entry] = true
+		diff.Added = append(diff.Added, entry)
+	}
+	if mode == ImportModeReplace && present {
+		for _, entry := range current {
+			if !seen[entry] {
+				diff.Removed = append(diff.Removed, entry)
+			}
+		}
+	}
+	return diff
+}
+
+func DiffBlocklistImport(settings *repository.ChatSettings, imp *BlocklistImport, mode string) ImportDiff {
+	words, wordsPresent := imp.patterns(BlocklistWords)
+	domains, domainsPresent := imp.patterns(BlocklistDomains)
+	return ImportDiff{
+		Words:   diffImportList(settings.BlockedWords, words, wordsPresent, mode),
+		Domains: diffImportList(settings.BlockedDomains, domains, domainsPresent, mode),
+		Invalid: imp.Invalid,
+	}
+}
+
+func applyImportList(current []string, diff ImportListDiff) []string {
+	removed := make(map[string]bool, len(diff.Removed))
+	for _, entry := range diff.Removed {
+		removed[entry] = true
+	}
+	result := make([]string, 0, len(current)+len(diff.Added))
+	for _, entry := range current {
+		if !removed[entry] {
+			result = append(result, entry)
+		}
+	}
+	return append(result, diff.Added...)
+}
+
+func (s *ModerationService) PreviewBlocklistImport(ctx context.Context, chatID int64, imp *BlocklistImport, mode string) (ImportDiff, error) {
+	_, span := s.tracer.Start(ctx, "PreviewBlocklistImport")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return ImportDiff{}, err
+	}
+	return DiffBlocklistImport(settings, imp, mode), nil
+}
+
+func (s *ModerationService) ApplyBlocklistImport(ctx context.Context, chatID int64, imp *BlocklistImport, mode string) (ImportDiff, error) {
+	_, span := s.tracer.Start(ctx, "ApplyBlocklistImport")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return ImportDiff{}, err
+	}
+	diff := DiffBlocklistImport(settings, imp, mode)
+	settings.BlockedWords = applyImportList(settings.BlockedWords, diff.Words)
+	settings.BlockedDomains = applyImportList(settings.BlockedDomains, diff.Domains)
+	for _, entry := range imp.Entries {
+		if entry.List != BlocklistWords {
+			continue
+		}
+		if entry.Mode == "" && entry.Severity == 0 {
+			if mode == ImportModeReplace {
+				delete(settings.WordRules, entry.Pattern)
+			}
+			continue
+		}
+		if settings.WordRules == nil {
+			settings.WordRules = repository.WordRules{}
+		}
+		settings.WordRules[entry.Pattern] = repository.WordRule{Mode: entry.Mode, Severity: entry.Severity}
+	}
+	pruneWordRules(settings)
+	if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+		return ImportDiff{}, err
+	}
+	return diff, nil
+}
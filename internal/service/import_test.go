@@ -0,0 +1,208 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseBlocklistImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		data        string
+		defaultList string
+		wantEntries []ImportEntry
+		wantInvalid int
+	}{
+		{
+			name:        "Text with phrases and comments",
+			file:        "list.txt",
+			data:        "\ufeff# header\nSpam\n  free   money  \nbuy now # inline\n\n",
+			defaultList: BlocklistWords,
+			wantEntries: []ImportEntry{
+				{List: BlocklistWords, Pattern: "spam"},
+				{List: BlocklistWords, Pattern: "free money"},
+				{List: BlocklistWords, Pattern: "buy now"},
+			},
+		},
+		{
+			name:        "CSV with modes and severity",
+			file:        "rules.csv",
+			data:        "pattern,mode,severity\nspam,word,high\ncasino,contains,2\nfree money,exact\nbad,regex,1\nworse,word,11\n",
+			defaultList: BlocklistWords,
+			wantEntries: []ImportEntry{
+				{List: BlocklistWords, Pattern: "spam", Mode: repository.MatchWord, Severity: 3},
+				{List: BlocklistWords, Pattern: "casino", Severity: 2},
+				{List: BlocklistWords, Pattern: "free money", Mode: repository.MatchExact},
+			},
+			wantInvalid: 2,
+		},
+		{
+			name:        "CSV with semicolons",
+			file:        "rules.csv",
+			data:        "spam;word;low\n",
+			defaultList: BlocklistWords,
+			wantEntries: []ImportEntry{{List: BlocklistWords, Pattern: "spam", Mode: repository.MatchWord, Severity: 1}},
+		},
+		{
+			name:        "Domains",
+			file:        "list.txt",
+			data:        "https://Bad.com/\nnot a domain\nlocalhost\n",
+			defaultList: BlocklistDomains,
+			wantEntries: []ImportEntry{{List: BlocklistDomains, Pattern: "bad.com"}},
+			wantInvalid: 2,
+		},
+		{
+			name:        "File name hints the list",
+			file:        "blocked_domains.txt",
+			data:        "evil.org\n",
+			defaultList: BlocklistWords,
+			wantEntries: []ImportEntry{{List: BlocklistDomains, Pattern: "evil.org"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imp, err := ParseBlocklistImport(tt.file, []byte(tt.data), tt.defaultList)
+			if err != nil {
+				t.Fatalf("ParseBlocklistImport() error = %v", err)
+			}
+			if !reflect.DeepEqual(imp.Entries, tt.wantEntries) {
+				t.Errorf("entries = %+v, want %+v", imp.Entries, tt.wantEntries)
+			}
+			if len(imp.Invalid) != tt.wantInvalid {
+				t.Errorf("invalid = %v, want %d lines", imp.Invalid, tt.wantInvalid)
+			}
+		})
+	}
+
+	if _, err := ParseBlocklistImport("list.pdf", []byte("spam"), BlocklistWords); !errors.Is(err, ErrUnsupportedImportFile) {
+		t.Errorf("ParseBlocklistImport(pdf) error = %v, want %v", err, ErrUnsupportedImportFile)
+	}
+}
+
+func TestParseBlocklistImport_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	files := map[string]string{
+		"words.txt":          "spam\nfree money\n",
+		"domains.csv":        "bad.com\n",
+		"readme.md":          "ignored",
+		"__MACOSX/words.txt": "junk",
+	}
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	imp, err := ParseBlocklistImport("bundle.zip", buf.Bytes(), BlocklistWords)
+	if err != nil {
+		t.Fatalf("ParseBlocklistImport() error = %v", err)
+	}
+	words, _ := imp.patterns(BlocklistWords)
+	domains, _ := imp.patterns(BlocklistDomains)
+	if !reflect.DeepEqual(words, []string{"spam", "free money"}) || !reflect.DeepEqual(domains, []string{"bad.com"}) {
+		t.Errorf("words = %v, domains = %v", words, domains)
+	}
+	if !reflect.DeepEqual(imp.Invalid, []string{"readme.md"}) {
+		t.Errorf("invalid = %v, want [readme.md]", imp.Invalid)
+	}
+}
+
+func TestParseBlocklistImport_ZipSizeLimit(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	content := bytes.Repeat([]byte("spam\n"), MaxImportSize/10)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseBlocklistImport("bundle.zip", buf.Bytes(), BlocklistWords); err == nil {
+		t.Error("ParseBlocklistImport() expected error for archive over the size limit")
+	}
+}
+
+func TestDiffBlocklistImport(t *testing.T) {
+	settings := &repository.ChatSettings{BlockedWords: []string{"spam", "old"}, BlockedDomains: []string{"bad.com"}}
+	imp := &BlocklistImport{Entries: []ImportEntry{
+		{List: BlocklistWords, Pattern: "spam"},
+		{List: BlocklistWords, Pattern: "casino"},
+		{List: BlocklistWords, Pattern: "casino"},
+	}}
+
+	merge := DiffBlocklistImport(settings, imp, ImportModeMerge)
+	if !reflect.DeepEqual(merge.Words.Added, []string{"casino"}) || merge.Words.Duplicates != 2 || len(merge.Words.Removed) != 0 {
+		t.Errorf("merge words diff = %+v", merge.Words)
+	}
+
+	replace := DiffBlocklistImport(settings, imp, ImportModeReplace)
+	if !reflect.DeepEqual(replace.Words.Removed, []string{"old"}) {
+		t.Errorf("replace words removed = %v, want [old]", replace.Words.Removed)
+	}
+	if len(replace.Domains.Removed) != 0 {
+		t.Errorf("replace domains removed = %v, want none for a list absent from the import", replace.Domains.Removed)
+	}
+}
+
+func TestModerationService_ApplyBlocklistImport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	stored := &repository.ChatSettings{
+		ChatID:         100,
+		BlockedWords:   []string{"spam", "old"},
+		WordRules:      repository.WordRules{"old": {Mode: repository.MatchWord}},
+		BlockedDomains: []string{"bad.com"},
+	}
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			copied := *stored
+			return &copied, nil
+		},
+		UpdateSettingsFunc: func(s *repository.ChatSettings) error {
+			stored = s
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	imp := &BlocklistImport{Entries: []ImportEntry{
+		{List: BlocklistWords, Pattern: "spam", Mode: repository.MatchWord, Severity: 2},
+		{List: BlocklistWords, Pattern: "casino"},
+		{List: BlocklistDomains, Pattern: "evil.org"},
+	}}
+	diff, err := svc.ApplyBlocklistImport(context.Background(), 100, imp, ImportModeReplace)
+	if err != nil {
+		t.Fatalf("ApplyBlocklistImport() error = %v", err)
+	}
+	if len(diff.Words.Added) != 1 || len(diff.Words.Removed) != 1 || len(diff.Domains.Removed) != 1 {
+		t.Errorf("diff = %+v", diff)
+	}
+	if !reflect.DeepEqual([]string(stored.BlockedWords), []string{"spam", "casino"}) || !reflect.DeepEqual([]string(stored.BlockedDomains), []string{"evil.org"}) {
+		t.Errorf("lists = %v / %v", stored.BlockedWords, stored.BlockedDomains)
+	}
+	if !reflect.DeepEqual(stored.WordRules, repository.WordRules{"spam": {Mode: repository.MatchWord, Severity: 2}}) {
+		t.Errorf("word rules = %v, want only spam rule", stored.WordRules)
+	}
+}
@@ -34,18 +34,53 @@ func (m *MockSettingsRepository) UpdateSettings(settings *repository.ChatSetting
 }
 
 type MockViolationRepository struct {
-	AddViolationFunc         func(ctx context.Context, chatID, userID int64, violationType string) error
-	CountViolationsSinceFunc func(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
-	IncrementChatStatFunc    func(ctx context.Context, chatID int64, field string) error
-	GetChatTotalStatsFunc    func(ctx context.Context, chatID int64) (*repository.ChatStats, error)
+	AddViolationFunc             func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error
+	CountViolationsSinceFunc     func(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
+	SumViolationWeightsSinceFunc func(ctx context.Context, chatID, userID int64, since time.Time) (int, error)
+	AddWarningFunc               func(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error
+	GetViolationsSinceFunc       func(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]repository.UserViolation, error)
+	DeleteLatestViolationFunc    func(ctx context.Context, chatID, userID int64, violationType string) (*repository.UserViolation, error)
+	DeleteViolationByMessageFunc func(ctx context.Context, chatID, userID int64, violationType, messageID string) (*repository.UserViolation, error)
+	ForgiveViolationsFunc        func(ctx context.Context, chatID, userID, moderatorID int64) (int, error)
+	DecayViolationsFunc          func(ctx context.Context, now time.Time) (int, error)
+	IncrementChatStatFunc        func(ctx context.Context, chatID int64, field string) error
+	GetChatTotalStatsFunc        func(ctx context.Context, chatID int64) (*repository.ChatStats, error)
 }
 
-func (m *MockViolationRepository) AddViolation(ctx context.Context, chatID, userID int64, violationType string) error {
-	return m.AddViolationFunc(ctx, chatID, userID, violationType)
+func (m *MockViolationRepository) AddViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
+	return m.AddViolationFunc(ctx, chatID, userID, violationType, weight, messageID)
 }
 func (m *MockViolationRepository) CountViolationsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
 	return m.CountViolationsSinceFunc(ctx, chatID, userID, since)
 }
+func (m *MockViolationRepository) SumViolationWeightsSince(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+	return m.SumViolationWeightsSinceFunc(ctx, chatID, userID, since)
+}
+func (m *MockViolationRepository) AddWarning(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+	return m.AddWarningFunc(ctx, chatID, userID, moderatorID, reason, weight)
+}
+func (m *MockViolationRepository) GetViolationsSince(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]repository.UserViolation, error) {
+	return m.GetViolationsSinceFunc(ctx, chatID, userID, violationType, since)
+}
+func (m *MockViolationRepository) DeleteLatestViolation(ctx context.Context, chatID, userID int64, violationType string) (*repository.UserViolation, error) {
+	return m.DeleteLatestViolationFunc(ctx, chatID, userID, violationType)
+}
+
+func (m *MockViolationRepository) DeleteViolationByMessage(ctx context.Context, chatID, userID int64, violationType, messageID string) (*repository.UserViolation, error) {
+	return m.DeleteViolationByMessageFunc(ctx, chatID, userID, violationType, messageID)
+}
+func (m *MockViolationRepository) ForgiveViolations(ctx context.Context, chatID, userID, moderatorID int64) (int, error) {
+	if m.ForgiveViolationsFunc != nil {
+		return m.ForgiveViolationsFunc(ctx, chatID, userID, moderatorID)
+	}
+	return 0, nil
+}
+func (m *MockViolationRepository) DecayViolations(ctx context.Context, now time.Time) (int, error) {
+	if m.DecayViolationsFunc != nil {
+		return m.DecayViolationsFunc(ctx, now)
+	}
+	return 0, nil
+}
 func (m *MockViolationRepository) IncrementChatStat(ctx context.Context, chatID int64, field string) error {
 	if m.IncrementChatStatFunc != nil {
 		return m.IncrementChatStatFunc(ctx, chatID, field)
@@ -60,9 +95,11 @@ func (m *MockViolationRepository) GetChatTotalStats(ctx context.Context, chatID
 }
 
 type MockLinkTokenRepository struct {
-	CreateFunc func(userID int64, ttl time.Duration) (string, error)
-	GetFunc    func(token string) (*repository.LinkToken, error)
-	DeleteFunc func(token string) error
+	CreateFunc           func(userID int64, ttl time.Duration) (string, error)
+	CreateWithPresetFunc func(userID int64, presetID *uint, ttl time.Duration) (string, error)
+	CreateInviteFunc     func(userID, chatID int64, role string, ttl time.Duration) (string, error)
+	GetFunc              func(token string) (*repository.LinkToken, error)
+	DeleteFunc           func(token string) error
 }
 
 func (m *MockLinkTokenRepository) Create(userID int64, ttl time.Duration) (string, error) {
@@ -72,6 +109,20 @@ func (m *MockLinkTokenRepository) Create(userID int64, ttl time.Duration) (strin
 	return "mock-token", nil
 }
 
+func (m *MockLinkTokenRepository) CreateWithPreset(userID int64, presetID *uint, ttl time.Duration) (string, error) {
+	if m.CreateWithPresetFunc != nil {
+		return m.CreateWithPresetFunc(userID, presetID, ttl)
+	}
+	return "mock-token", nil
+}
+
+func (m *MockLinkTokenRepository) CreateInvite(userID, chatID int64, role string, ttl time.Duration) (string, error) {
+	if m.CreateInviteFunc != nil {
+		return m.CreateInviteFunc(userID, chatID, role, ttl)
+	}
+	return "mock-invite", nil
+}
+
 func (m *MockLinkTokenRepository) Get(token string) (*repository.LinkToken, error) {
 	if m.GetFunc != nil {
 		return m.GetFunc(token)
@@ -96,6 +147,13 @@ type MockChatAdminRepository struct {
 	RemoveAdminFunc              func(chatID, userID int64) error
 	GetManagedChatsFunc          func(userID int64) ([]int64, error)
 	GetManagedChatsPaginatedFunc func(userID int64, offset, limit int) ([]int64, int64, error)
+	GetAdminsFunc                func(chatID int64) ([]int64, error)
+	GetLinkedChatsFunc           func() ([]int64, error)
+	AddAdminWithRoleFunc         func(chatID, userID int64, role string, invitedBy int64) error
+	GetRoleFunc                  func(chatID, userID int64) (string, error)
+	GetChatAdminsFunc            func(chatID int64) ([]repository.ChatAdmin, error)
+	MarkDemotedFunc              func(chatID, userID int64, at time.Time) error
+	ClearDemotedFunc             func(chatID, userID int64) error
 }
 
 func (m *MockChatAdminRepository) IsAdmin(chatID, userID int64) (bool, error) {
@@ -133,19 +191,82 @@ func (m *MockChatAdminRepository) GetManagedChatsPaginated(userID int64, offset,
 	return nil, 0, nil
 }
 
+func (m *MockChatAdminRepository) GetAdmins(chatID int64) ([]int64, error) {
+	if m.GetAdminsFunc != nil {
+		return m.GetAdminsFunc(chatID)
+	}
+	return nil, nil
+}
+
+func (m *MockChatAdminRepository) GetLinkedChats() ([]int64, error) {
+	if m.GetLinkedChatsFunc != nil {
+		return m.GetLinkedChatsFunc()
+	}
+	return nil, nil
+}
+
+func (m *MockChatAdminRepository) AddAdminWithRole(chatID, userID int64, role string, invitedBy int64) error {
+	if m.AddAdminWithRoleFunc != nil {
+		return m.AddAdminWithRoleFunc(chatID, userID, role, invitedBy)
+	}
+	return nil
+}
+
+func (m *MockChatAdminRepository) GetRole(chatID, userID int64) (string, error) {
+	if m.GetRoleFunc != nil {
+		return m.GetRoleFunc(chatID, userID)
+	}
+	isAdmin, err := m.IsAdmin(chatID, userID)
+	if err != nil || !isAdmin {
+		return "", err
+	}
+	return repository.RoleOwner, nil
+}
+
+func (m *MockChatAdminRepository) GetChatAdmins(chatID int64) ([]repository.ChatAdmin, error) {
+	if m.GetChatAdminsFunc != nil {
+		return m.GetChatAdminsFunc(chatID)
+	}
+	userIDs, err := m.GetAdmins(chatID)
+	if err != nil {
+		return nil, err
+	}
+	admins := make([]repository.ChatAdmin, len(userIDs))
+	for i, userID := range userIDs {
+		admins[i] = repository.ChatAdmin{ChatID: chatID, UserID: userID, Role: repository.RoleOwner}
+	}
+	return admins, nil
+}
+
+func (m *MockChatAdminRepository) MarkDemoted(chatID, userID int64, at time.Time) error {
+	if m.MarkDemotedFunc != nil {
+		return m.MarkDemotedFunc(chatID, userID, at)
+	}
+	return nil
+}
+
+func (m *MockChatAdminRepository) ClearDemoted(chatID, userID int64) error {
+	if m.ClearDemotedFunc != nil {
+		return m.ClearDemotedFunc(chatID, userID)
+	}
+	return nil
+}
+
 type MockMuteRepository struct {
-	MuteUserFunc                func(chatID, userID int64, userName string, duration time.Duration) error
+	MuteUserFunc                func(chatID, userID int64, userName, reason string, duration time.Duration) error
 	UnmuteUserFunc              func(chatID, userID int64) error
 	IsMutedFunc                 func(chatID, userID int64) (bool, time.Time, error)
 	GetActiveMutesPaginatedFunc func(chatID int64, offset, limit int) ([]repository.Mute, int64, error)
 	GetMuteFunc                 func(chatID, userID int64) (*repository.Mute, error)
 	GetActiveMutesFunc          func(chatID int64) ([]repository.Mute, error)
 	CountActiveMutesFunc        func() (int64, error)
+	GetActiveMutesByUserFunc    func(userID int64) ([]repository.Mute, error)
+	SetExpiryFunc               func(chatID, userID int64, expiresAt time.Time) error
 }
 
-func (m *MockMuteRepository) MuteUser(chatID, userID int64, userName string, duration time.Duration) error {
+func (m *MockMuteRepository) MuteUser(chatID, userID int64, userName, reason string, duration time.Duration) error {
 	if m.MuteUserFunc != nil {
-		return m.MuteUserFunc(chatID, userID, userName, duration)
+		return m.MuteUserFunc(chatID, userID, userName, reason, duration)
 	}
 	return nil
 }
@@ -185,3 +306,615 @@ func (m *MockMuteRepository) CountActiveMutes() (int64, error) {
 	}
 	return 0, nil
 }
+func (m *MockMuteRepository) GetActiveMutesByUser(userID int64) ([]repository.Mute, error) {
+	if m.GetActiveMutesByUserFunc != nil {
+		return m.GetActiveMutesByUserFunc(userID)
+	}
+	return nil, nil
+}
+func (m *MockMuteRepository) SetExpiry(chatID, userID int64, expiresAt time.Time) error {
+	if m.SetExpiryFunc != nil {
+		return m.SetExpiryFunc(chatID, userID, expiresAt)
+	}
+	return nil
+}
+
+type MockScheduleRepository struct {
+	GetRulesFunc            func(chatID int64) ([]repository.ScheduleRule, error)
+	AddRuleFunc             func(rule *repository.ScheduleRule) error
+	DeleteRuleFunc          func(chatID int64, ruleID uint) error
+	GetScheduledChatIDsFunc func() ([]int64, error)
+}
+
+func (m *MockScheduleRepository) GetRules(chatID int64) ([]repository.ScheduleRule, error) {
+	if m.GetRulesFunc != nil {
+		return m.GetRulesFunc(chatID)
+	}
+	return nil, nil
+}
+func (m *MockScheduleRepository) AddRule(rule *repository.ScheduleRule) error {
+	if m.AddRuleFunc != nil {
+		return m.AddRuleFunc(rule)
+	}
+	return nil
+}
+func (m *MockScheduleRepository) DeleteRule(chatID int64, ruleID uint) error {
+	if m.DeleteRuleFunc != nil {
+		return m.DeleteRuleFunc(chatID, ruleID)
+	}
+	return nil
+}
+func (m *MockScheduleRepository) GetScheduledChatIDs() ([]int64, error) {
+	if m.GetScheduledChatIDsFunc != nil {
+		return m.GetScheduledChatIDsFunc()
+	}
+	return nil, nil
+}
+
+type MockStrikeLadderRepository struct {
+	GetStepsFunc   func(chatID int64) ([]repository.StrikeStep, error)
+	AddStepFunc    func(step *repository.StrikeStep) error
+	DeleteStepFunc func(chatID int64, stepID uint) error
+	GetWeightsFunc func(chatID int64) (map[string]int, error)
+	SetWeightFunc  func(chatID int64, violationType string, weight int) error
+}
+
+func (m *MockStrikeLadderRepository) GetSteps(chatID int64) ([]repository.StrikeStep, error) {
+	if m.GetStepsFunc != nil {
+		return m.GetStepsFunc(chatID)
+	}
+	return nil, nil
+}
+func (m *MockStrikeLadderRepository) AddStep(step *repository.StrikeStep) error {
+	if m.AddStepFunc != nil {
+		return m.AddStepFunc(step)
+	}
+	return nil
+}
+func (m *MockStrikeLadderRepository) DeleteStep(chatID int64, stepID uint) error {
+	if m.DeleteStepFunc != nil {
+		return m.DeleteStepFunc(chatID, stepID)
+	}
+	return nil
+}
+func (m *MockStrikeLadderRepository) GetWeights(chatID int64) (map[string]int, error) {
+	if m.GetWeightsFunc != nil {
+		return m.GetWeightsFunc(chatID)
+	}
+	return map[string]int{}, nil
+}
+func (m *MockStrikeLadderRepository) SetWeight(chatID int64, violationType string, weight int) error {
+	if m.SetWeightFunc != nil {
+		return m.SetWeightFunc(chatID, violationType, weight)
+	}
+	return nil
+}
+
+type MockBanRepository struct {
+	BanUserFunc                func(chatID, userID int64, userName string, duration time.Duration) error
+	UnbanUserFunc              func(chatID, userID int64) error
+	IsBannedFunc               func(chatID, userID int64) (bool, time.Time, error)
+	GetActiveBansPaginatedFunc func(chatID int64, offset, limit int) ([]repository.Ban, int64, error)
+	GetBanFunc                 func(chatID, userID int64) (*repository.Ban, error)
+	GetActiveBansByUserFunc    func(userID int64) ([]repository.Ban, error)
+	SetExpiryFunc              func(chatID, userID int64, expiresAt time.Time) error
+}
+
+func (m *MockBanRepository) BanUser(chatID, userID int64, userName string, duration time.Duration) error {
+	if m.BanUserFunc != nil {
+		return m.BanUserFunc(chatID, userID, userName, duration)
+	}
+	return nil
+}
+func (m *MockBanRepository) UnbanUser(chatID, userID int64) error {
+	if m.UnbanUserFunc != nil {
+		return m.UnbanUserFunc(chatID, userID)
+	}
+	return nil
+}
+func (m *MockBanRepository) IsBanned(chatID, userID int64) (bool, time.Time, error) {
+	if m.IsBannedFunc != nil {
+		return m.IsBannedFunc(chatID, userID)
+	}
+	return false, time.Time{}, nil
+}
+func (m *MockBanRepository) GetActiveBansPaginated(chatID int64, offset, limit int) ([]repository.Ban, int64, error) {
+	if m.GetActiveBansPaginatedFunc != nil {
+		return m.GetActiveBansPaginatedFunc(chatID, offset, limit)
+	}
+	return nil, 0, nil
+}
+func (m *MockBanRepository) GetBan(chatID, userID int64) (*repository.Ban, error) {
+	if m.GetBanFunc != nil {
+		return m.GetBanFunc(chatID, userID)
+	}
+	return nil, nil
+}
+func (m *MockBanRepository) GetActiveBansByUser(userID int64) ([]repository.Ban, error) {
+	if m.GetActiveBansByUserFunc != nil {
+		return m.GetActiveBansByUserFunc(userID)
+	}
+	return nil, nil
+}
+func (m *MockBanRepository) SetExpiry(chatID, userID int64, expiresAt time.Time) error {
+	if m.SetExpiryFunc != nil {
+		return m.SetExpiryFunc(chatID, userID, expiresAt)
+	}
+	return nil
+}
+
+type MockAppealRepository struct {
+	CreateFunc          func(appeal *repository.Appeal) (bool, error)
+	GetFunc             func(id uint) (*repository.Appeal, error)
+	GetOpenFunc         func(chatID, userID int64, sanctionType string) (*repository.Appeal, error)
+	GetLatestByUserFunc func(userID int64) (*repository.Appeal, error)
+	ResolveFunc         func(id uint, status string, resolvedBy int64) (bool, error)
+	CloseOpenFunc       func(chatID, userID int64, sanctionType string) error
+	CloseExpiredFunc    func(now time.Time) (int64, error)
+}
+
+func (m *MockAppealRepository) Create(appeal *repository.Appeal) (bool, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(appeal)
+	}
+	return true, nil
+}
+func (m *MockAppealRepository) Get(id uint) (*repository.Appeal, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	return nil, nil
+}
+func (m *MockAppealRepository) GetOpen(chatID, userID int64, sanctionType string) (*repository.Appeal, error) {
+	if m.GetOpenFunc != nil {
+		return m.GetOpenFunc(chatID, userID, sanctionType)
+	}
+	return nil, nil
+}
+func (m *MockAppealRepository) GetLatestByUser(userID int64) (*repository.Appeal, error) {
+	if m.GetLatestByUserFunc != nil {
+		return m.GetLatestByUserFunc(userID)
+	}
+	return nil, nil
+}
+func (m *MockAppealRepository) Resolve(id uint, status string, resolvedBy int64) (bool, error) {
+	if m.ResolveFunc != nil {
+		return m.ResolveFunc(id, status, resolvedBy)
+	}
+	return true, nil
+}
+func (m *MockAppealRepository) CloseOpen(chatID, userID int64, sanctionType string) error {
+	if m.CloseOpenFunc != nil {
+		return m.CloseOpenFunc(chatID, userID, sanctionType)
+	}
+	return nil
+}
+func (m *MockAppealRepository) CloseExpired(now time.Time) (int64, error) {
+	if m.CloseExpiredFunc != nil {
+		return m.CloseExpiredFunc(now)
+	}
+	return 0, nil
+}
+
+type MockFederationRepository struct {
+	CreateGroupFunc   func(ownerID int64, name string) (*repository.ChatGroup, error)
+	GetGroupFunc      func(groupID uint) (*repository.ChatGroup, error)
+	GetGroupsFunc     func(ownerID int64) ([]repository.ChatGroup, error)
+	DeleteGroupFunc   func(groupID uint) error
+	SetPropagateFunc  func(groupID uint, propagate bool) error
+	AddChatFunc       func(groupID uint, chatID int64) error
+	RemoveChatFunc    func(groupID uint, chatID int64) error
+	GetGroupChatsFunc func(groupID uint) ([]int64, error)
+	GetChatGroupFunc  func(chatID int64) (*repository.ChatGroup, error)
+	AddSuspectFunc    func(chatID, userID, sourceChatID int64, reason string, expiresAt time.Time) error
+	IsSuspectFunc     func(chatID, userID int64) (bool, error)
+}
+
+func (m *MockFederationRepository) CreateGroup(ownerID int64, name string) (*repository.ChatGroup, error) {
+	if m.CreateGroupFunc != nil {
+		return m.CreateGroupFunc(ownerID, name)
+	}
+	return &repository.ChatGroup{OwnerID: ownerID, Name: name, Propagate: true}, nil
+}
+func (m *MockFederationRepository) GetGroup(groupID uint) (*repository.ChatGroup, error) {
+	if m.GetGroupFunc != nil {
+		return m.GetGroupFunc(groupID)
+	}
+	return nil, nil
+}
+func (m *MockFederationRepository) GetGroups(ownerID int64) ([]repository.ChatGroup, error) {
+	if m.GetGroupsFunc != nil {
+		return m.GetGroupsFunc(ownerID)
+	}
+	return nil, nil
+}
+func (m *MockFederationRepository) DeleteGroup(groupID uint) error {
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(groupID)
+	}
+	return nil
+}
+func (m *MockFederationRepository) SetPropagate(groupID uint, propagate bool) error {
+	if m.SetPropagateFunc != nil {
+		return m.SetPropagateFunc(groupID, propagate)
+	}
+	return nil
+}
+func (m *MockFederationRepository) AddChat(groupID uint, chatID int64) error {
+	if m.AddChatFunc != nil {
+		return m.AddChatFunc(groupID, chatID)
+	}
+	return nil
+}
+func (m *MockFederationRepository) RemoveChat(groupID uint, chatID int64) error {
+	if m.RemoveChatFunc != nil {
+		return m.RemoveChatFunc(groupID, chatID)
+	}
+	return nil
+}
+func (m *MockFederationRepository) GetGroupChats(groupID uint) ([]int64, error) {
+	if m.GetGroupChatsFunc != nil {
+		return m.GetGroupChatsFunc(groupID)
+	}
+	return nil, nil
+}
+func (m *MockFederationRepository) GetChatGroup(chatID int64) (*repository.ChatGroup, error) {
+	if m.GetChatGroupFunc != nil {
+		return m.GetChatGroupFunc(chatID)
+	}
+	return nil, nil
+}
+func (m *MockFederationRepository) AddSuspect(chatID, userID, sourceChatID int64, reason string, expiresAt time.Time) error {
+	if m.AddSuspectFunc != nil {
+		return m.AddSuspectFunc(chatID, userID, sourceChatID, reason, expiresAt)
+	}
+	return nil
+}
+func (m *MockFederationRepository) IsSuspect(chatID, userID int64) (bool, error) {
+	if m.IsSuspectFunc != nil {
+		return m.IsSuspectFunc(chatID, userID)
+	}
+	return false, nil
+}
+
+type MockGlobalBanRepository struct {
+	AddFunc            func(ban repository.GlobalBan) error
+	AddManyFunc        func(bans []repository.GlobalBan) (int, error)
+	RemoveFunc         func(userID int64) (bool, error)
+	IsListedFunc       func(userID int64) (bool, error)
+	GetAllFunc         func() ([]repository.GlobalBan, error)
+	CountFunc          func() (int64, error)
+	LogEnforcementFunc func(chatID, userID int64, trigger string) error
+}
+
+func (m *MockGlobalBanRepository) Add(ban repository.GlobalBan) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(ban)
+	}
+	return nil
+}
+func (m *MockGlobalBanRepository) AddMany(bans []repository.GlobalBan) (int, error) {
+	if m.AddManyFunc != nil {
+		return m.AddManyFunc(bans)
+	}
+	return len(bans), nil
+}
+func (m *MockGlobalBanRepository) Remove(userID int64) (bool, error) {
+	if m.RemoveFunc != nil {
+		return m.RemoveFunc(userID)
+	}
+	return false, nil
+}
+func (m *MockGlobalBanRepository) IsListed(userID int64) (bool, error) {
+	if m.IsListedFunc != nil {
+		return m.IsListedFunc(userID)
+	}
+	return false, nil
+}
+func (m *MockGlobalBanRepository) GetAll() ([]repository.GlobalBan, error) {
+	if m.GetAllFunc != nil {
+		return m.GetAllFunc()
+	}
+	return nil, nil
+}
+func (m *MockGlobalBanRepository) Count() (int64, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc()
+	}
+	return 0, nil
+}
+func (m *MockGlobalBanRepository) LogEnforcement(chatID, userID int64, trigger string) error {
+	if m.LogEnforcementFunc != nil {
+		return m.LogEnforcementFunc(chatID, userID, trigger)
+	}
+	return nil
+}
+
+type MockSanctionHistoryRepository struct {
+	AddFunc            func(record *repository.SanctionRecord) error
+	EndFunc            func(chatID, userID int64, sanctionType string, endedBy int64, reason string) error
+	EndExpiredFunc     func(now time.Time) (int64, error)
+	GetActiveFunc      func(chatID, userID int64, sanctionType string) (*repository.SanctionRecord, error)
+	GetUserHistoryFunc func(chatID, userID int64, offset, limit int) ([]repository.SanctionRecord, int64, error)
+}
+
+func (m *MockSanctionHistoryRepository) Add(record *repository.SanctionRecord) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(record)
+	}
+	return nil
+}
+func (m *MockSanctionHistoryRepository) End(chatID, userID int64, sanctionType string, endedBy int64, reason string) error {
+	if m.EndFunc != nil {
+		return m.EndFunc(chatID, userID, sanctionType, endedBy, reason)
+	}
+	return nil
+}
+func (m *MockSanctionHistoryRepository) EndExpired(now time.Time) (int64, error) {
+	if m.EndExpiredFunc != nil {
+		return m.EndExpiredFunc(now)
+	}
+	return 0, nil
+}
+func (m *MockSanctionHistoryRepository) GetActive(chatID, userID int64, sanctionType string) (*repository.SanctionRecord, error) {
+	if m.GetActiveFunc != nil {
+		return m.GetActiveFunc(chatID, userID, sanctionType)
+	}
+	return nil, nil
+}
+func (m *MockSanctionHistoryRepository) GetUserHistory(chatID, userID int64, offset, limit int) ([]repository.SanctionRecord, int64, error) {
+	if m.GetUserHistoryFunc != nil {
+		return m.GetUserHistoryFunc(chatID, userID, offset, limit)
+	}
+	return nil, 0, nil
+}
+
+type MockAuditRepository struct {
+	AddFunc                  func(entry *repository.AuditEntry) error
+	GetFunc                  func(id uint) (*repository.AuditEntry, error)
+	MarkUndoneFunc           func(id uint) (bool, error)
+	GetPaginatedFunc         func(chatID int64, filter repository.AuditFilter, offset, limit int) ([]repository.AuditEntry, int64, error)
+	CountDeletesByFilterFunc func(chatID int64, since time.Time) ([]repository.AuditFilterCount, error)
+	TopOffendersFunc         func(chatID int64, since time.Time, limit int) ([]repository.AuditOffenderCount, error)
+}
+
+func (m *MockAuditRepository) Add(entry *repository.AuditEntry) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(entry)
+	}
+	return nil
+}
+func (m *MockAuditRepository) Get(id uint) (*repository.AuditEntry, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	return nil, nil
+}
+func (m *MockAuditRepository) MarkUndone(id uint) (bool, error) {
+	if m.MarkUndoneFunc != nil {
+		return m.MarkUndoneFunc(id)
+	}
+	return true, nil
+}
+func (m *MockAuditRepository) GetPaginated(chatID int64, filter repository.AuditFilter, offset, limit int) ([]repository.AuditEntry, int64, error) {
+	if m.GetPaginatedFunc != nil {
+		return m.GetPaginatedFunc(chatID, filter, offset, limit)
+	}
+	return nil, 0, nil
+}
+func (m *MockAuditRepository) CountDeletesByFilter(chatID int64, since time.Time) ([]repository.AuditFilterCount, error) {
+	if m.CountDeletesByFilterFunc != nil {
+		return m.CountDeletesByFilterFunc(chatID, since)
+	}
+	return nil, nil
+}
+func (m *MockAuditRepository) TopOffenders(chatID int64, since time.Time, limit int) ([]repository.AuditOffenderCount, error) {
+	if m.TopOffendersFunc != nil {
+		return m.TopOffendersFunc(chatID, since, limit)
+	}
+	return nil, nil
+}
+
+type MockArchiveRepository struct {
+	AddFunc           func(message *repository.ArchivedMessage) error
+	GetFunc           func(id uint) (*repository.ArchivedMessage, error)
+	GetPaginatedFunc  func(chatID int64, offset, limit int) ([]repository.ArchivedMessage, int64, error)
+	SetStatusFunc     func(id uint, status string, reviewedBy int64) error
+	MarkRestoredFunc  func(id uint, reviewedBy int64) (bool, error)
+	DeleteExpiredFunc func(now time.Time) (int64, error)
+	AddTrustedFunc    func(chatID, userID, addedBy int64) error
+	RemoveTrustedFunc func(chatID, userID int64) error
+	IsTrustedFunc     func(chatID, userID int64) (bool, error)
+	GetTrustedFunc    func(chatID int64) ([]repository.TrustedUser, error)
+}
+
+func (m *MockArchiveRepository) Add(message *repository.ArchivedMessage) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(message)
+	}
+	return nil
+}
+func (m *MockArchiveRepository) Get(id uint) (*repository.ArchivedMessage, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	return nil, nil
+}
+func (m *MockArchiveRepository) GetPaginated(chatID int64, offset, limit int) ([]repository.ArchivedMessage, int64, error) {
+	if m.GetPaginatedFunc != nil {
+		return m.GetPaginatedFunc(chatID, offset, limit)
+	}
+	return nil, 0, nil
+}
+func (m *MockArchiveRepository) SetStatus(id uint, status string, reviewedBy int64) error {
+	if m.SetStatusFunc != nil {
+		return m.SetStatusFunc(id, status, reviewedBy)
+	}
+	return nil
+}
+func (m *MockArchiveRepository) MarkRestored(id uint, reviewedBy int64) (bool, error) {
+	if m.MarkRestoredFunc != nil {
+		return m.MarkRestoredFunc(id, reviewedBy)
+	}
+	return true, nil
+}
+func (m *MockArchiveRepository) DeleteExpired(now time.Time) (int64, error) {
+	if m.DeleteExpiredFunc != nil {
+		return m.DeleteExpiredFunc(now)
+	}
+	return 0, nil
+}
+func (m *MockArchiveRepository) AddTrusted(chatID, userID, addedBy int64) error {
+	if m.AddTrustedFunc != nil {
+		return m.AddTrustedFunc(chatID, userID, addedBy)
+	}
+	return nil
+}
+func (m *MockArchiveRepository) RemoveTrusted(chatID, userID int64) error {
+	if m.RemoveTrustedFunc != nil {
+		return m.RemoveTrustedFunc(chatID, userID)
+	}
+	return nil
+}
+func (m *MockArchiveRepository) IsTrusted(chatID, userID int64) (bool, error) {
+	if m.IsTrustedFunc != nil {
+		return m.IsTrustedFunc(chatID, userID)
+	}
+	return false, nil
+}
+
+func (m *MockArchiveRepository) GetTrusted(chatID int64) ([]repository.TrustedUser, error) {
+	if m.GetTrustedFunc != nil {
+		return m.GetTrustedFunc(chatID)
+	}
+	return nil, nil
+}
+
+type MockReportRepository struct {
+	CreateFunc               func(report *repository.Report) error
+	GetFunc                  func(id uint) (*repository.Report, error)
+	CountByReporterSinceFunc func(chatID, reporterID int64, since time.Time) (int64, error)
+	SetNoticeIDsFunc         func(id uint, noticeIDs []string) error
+	ResolveFunc              func(id uint, status string, resolvedBy int64) (bool, error)
+	ReopenFunc               func(id uint, status string) error
+}
+
+func (m *MockReportRepository) Create(report *repository.Report) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(report)
+	}
+	return nil
+}
+func (m *MockReportRepository) Get(id uint) (*repository.Report, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	return nil, nil
+}
+func (m *MockReportRepository) CountByReporterSince(chatID, reporterID int64, since time.Time) (int64, error) {
+	if m.CountByReporterSinceFunc != nil {
+		return m.CountByReporterSinceFunc(chatID, reporterID, since)
+	}
+	return 0, nil
+}
+func (m *MockReportRepository) SetNoticeIDs(id uint, noticeIDs []string) error {
+	if m.SetNoticeIDsFunc != nil {
+		return m.SetNoticeIDsFunc(id, noticeIDs)
+	}
+	return nil
+}
+func (m *MockReportRepository) Resolve(id uint, status string, resolvedBy int64) (bool, error) {
+	if m.ResolveFunc != nil {
+		return m.ResolveFunc(id, status, resolvedBy)
+	}
+	return false, nil
+}
+func (m *MockReportRepository) Reopen(id uint, status string) error {
+	if m.ReopenFunc != nil {
+		return m.ReopenFunc(id, status)
+	}
+	return nil
+}
+
+type MockNotificationRepository struct {
+	GetFunc                  func(chatID, userID int64) (*repository.NotificationPref, error)
+	SaveFunc                 func(pref *repository.NotificationPref) error
+	GetDigestSubscribersFunc func() ([]repository.NotificationPref, error)
+	MarkDigestSentFunc       func(chatID, userID int64, at time.Time) error
+}
+
+func (m *MockNotificationRepository) Get(chatID, userID int64) (*repository.NotificationPref, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(chatID, userID)
+	}
+	return nil, nil
+}
+func (m *MockNotificationRepository) Save(pref *repository.NotificationPref) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(pref)
+	}
+	return nil
+}
+func (m *MockNotificationRepository) GetDigestSubscribers() ([]repository.NotificationPref, error) {
+	if m.GetDigestSubscribersFunc != nil {
+		return m.GetDigestSubscribersFunc()
+	}
+	return nil, nil
+}
+func (m *MockNotificationRepository) MarkDigestSent(chatID, userID int64, at time.Time) error {
+	if m.MarkDigestSentFunc != nil {
+		return m.MarkDigestSentFunc(chatID, userID, at)
+	}
+	return nil
+}
+
+type MockPresetRepository struct {
+	CreateFunc             func(preset *repository.SettingsPreset) error
+	UpdateFunc             func(preset *repository.SettingsPreset) error
+	DeleteFunc             func(id uint) error
+	GetFunc                func(id uint) (*repository.SettingsPreset, error)
+	GetAvailableFunc       func(ownerID int64) ([]repository.SettingsPreset, error)
+	GetSubscribedChatsFunc func(id uint) ([]int64, error)
+}
+
+func (m *MockPresetRepository) Create(preset *repository.SettingsPreset) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(preset)
+	}
+	return nil
+}
+
+func (m *MockPresetRepository) Update(preset *repository.SettingsPreset) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(preset)
+	}
+	return nil
+}
+
+func (m *MockPresetRepository) Delete(id uint) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
+
+func (m *MockPresetRepository) Get(id uint) (*repository.SettingsPreset, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	return nil, nil
+}
+
+func (m *MockPresetRepository) GetAvailable(ownerID int64) ([]repository.SettingsPreset, error) {
+	if m.GetAvailableFunc != nil {
+		return m.GetAvailableFunc(ownerID)
+	}
+	return nil, nil
+}
+
+func (m *MockPresetRepository) GetSubscribedChats(id uint) ([]int64, error) {
+	if m.GetSubscribedChatsFunc != nil {
+		return m.GetSubscribedChatsFunc(id)
+	}
+	return nil, nil
+}
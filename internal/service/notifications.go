@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+const (
+	NotifyEventAutoMute = "automute"
+	NotifyEventRaid     = "raid"
+	NotifyEventRights   = "rights"
+	NotifyEventDeletes  = "deletes"
+	NotifyOptionSilence = "silence"
+)
+
+const (
+	raidJoinLimit      = 10
+	raidWindow         = 1 * time.Minute
+	raidAlertCooldown  = 10 * time.Minute
+	botRightsInterval  = 10 * time.Minute
+	digestTopOffenders = 5
+)
+
+type NotifyAction struct {
+	Label   string
+	Payload string
+}
+
+type AdminNotifier interface {
+	Notify(ctx context.Context, userID int64, text string, actions []NotifyAction) error
+}
+
+type maxNotifier struct {
+	bot *maxbot.Api
+}
+
+func NewMaxNotifier(bot *maxbot.Api) AdminNotifier {
+	return &maxNotifier{bot: bot}
+}
+
+func (n *maxNotifier) Notify(ctx context.Context, userID int64, text string, actions []NotifyAction) error {
+	if n.bot == nil {
+		return errors.New("bot is not configured")
+	}
+	msg := maxbot.NewMessage()
+	msg.SetUser(userID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	if len(actions) > 0 {
+		kb := n.bot.Messages.NewKeyboardBuilder()
+		for _, action := range actions {
+			kb.AddRow().AddCallback(action.Label, schemes.DEFAULT, action.Payload)
+		}
+		msg.AddKeyboard(kb)
+	}
+	return n.bot.Messages.Send(ctx, msg)
+}
+
+type joinTracker struct {
+	mu        sync.Mutex
+	joins     []time.Time
+	alertedAt time.Time
+}
+
+func defaultNotificationPref(chatID, userID int64) *repository.NotificationPref {
+	return &repository.NotificationPref{
+		ChatID:         chatID,
+		UserID:         userID,
+		NotifyAutoMute: true,
+		NotifyRaid:     true,
+		NotifyRights:   true,
+		Digest:         repository.DigestOff,
+	}
+}
+
+func notificationEnabled(pref *repository.NotificationPref, event string) bool {
+	if pref.Silenced {
+		return false
+	}
+	switch event {
+	case NotifyEventAutoMute:
+		return pref.NotifyAutoMute
+	case NotifyEventRaid:
+		return pref.NotifyRaid
+	case NotifyEventRights:
+		return pref.NotifyRights
+	case NotifyEventDeletes:
+		return pref.NotifyDeletes
+	}
+	return false
+}
+
+func digestPeriod(digest string) time.Duration {
+	switch digest {
+	case repository.DigestDaily:
+		return 24 * time.Hour
+	case repository.DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+func (s *ModerationService) GetNotificationPrefs(ctx context.Context, chatID, userID int64) (*repository.NotificationPref, error) {
+	_, span := s.tracer.Start(ctx, "GetNotificationPrefs")
+	defer span.End()
+
+	pref, err := s.notificationRepo.Get(chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pref == nil {
+		return defaultNotificationPref(chatID, userID), nil
+	}
+	return pref, nil
+}
+
+func (s *ModerationService) ToggleNotification(ctx context.Context, chatID, userID int64, option string) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "ToggleNotification")
+	defer span.End()
+
+	pref, err := s.GetNotificationPrefs(ctx, chatID, userID)
+	if err != nil {
+		return false, err
+	}
+	var value *bool
+	switch option {
+	case NotifyEventAutoMute:
+		value = &pref.NotifyAutoMute
+	case NotifyEventRaid:
+		value = &pref.NotifyRaid
+	case NotifyEventRights:
+		value = &pref.NotifyRights
+	case NotifyEventDeletes:
+		value = &pref.NotifyDeletes
+	case NotifyOptionSilence:
+		value = &pref.Silenced
+	default:
+		return false, fmt.Errorf("unknown notification option: %s", option)
+	}
+	*value = !*value
+	if err := s.notificationRepo.Save(pref); err != nil {
+		return false, err
+	}
+	return *value, nil
+}
+
+func (s *ModerationService) CycleDigest(ctx context.Context, chatID, userID int64) (string, error) {
+	ctx, span := s.tracer.Start(ctx, "CycleDigest")
+	defer span.End()
+
+	pref, err := s.GetNotificationPrefs(ctx, chatID, userID)
+	if err != nil {
+		return "", err
+	}
+	switch pref.Digest {
+	case repository.DigestDaily:
+		pref.Digest = repository.DigestWeekly
+	case repository.DigestWeekly:
+		pref.Digest = repository.DigestOff
+	default:
+		pref.Digest = repository.DigestDaily
+	}
+	if pref.Digest != repository.DigestOff {
+		now := time.Now()
+		pref.LastDigestAt = &now
+	}
+	if err := s.notificationRepo.Save(pref); err != nil {
+		return "", err
+	}
+	return pref.Digest, nil
+}
+
+func (s *ModerationService) TrackJoin(ctx context.Context, chatID int64) bool {
+	_, span := s.tracer.Start(ctx, "TrackJoin")
+	defer span.End()
+
+	count, raid := s.recordJoin(chatID, time.Now())
+	if raid {
+		s.logger.Warn("Join burst detected", "chat_id", chatID, "joins", count)
+		go s.notifyAdmins(context.Background(), chatID, NotifyEventRaid, fmt.Sprintf(messages.MsgNotifyRaid, count, int(raidWindow.Minutes())))
+	}
+	return raid
+}
+
+func (s *ModerationService) recordJoin(chatID int64, now time.Time) (int, bool) {
+	value, _ := s.joinState.LoadOrStore(chatID, &joinTracker{})
+	tracker := value.(*joinTracker)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	cutoff := now.Add(-raidWindow)
+	kept := tracker.joins[:0]
+	for _, t := range tracker.joins {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	tracker.joins = append(kept, now)
+
+	count := len(tracker.joins)
+	if count < raidJoinLimit || now.Sub(tracker.alertedAt) < raidAlertCooldown {
+		return count, false
+	}
+	tracker.alertedAt = now
+	return count, true
+}
+
+func (s *ModerationService) NotifyBotRemoved(ctx context.Context, chatID int64) {
+	_, span := s.tracer.Start(ctx, "NotifyBotRemoved")
+	defer span.End()
+
+	s.botRightsState.Store(chatID, false)
+	s.notifyAdmins(ctx, chatID, NotifyEventRights, messages.MsgNotifyBotRemoved)
+}
+
+func (s *ModerationService) notifyAdmins(ctx context.Context, chatID int64, event, text string, actions ...NotifyAction) {
+	if s.notificationRepo == nil || s.notifier == nil {
+		return
+	}
+	admins, err := s.chatAdminRepo.GetAdmins(chatID)
+	if err != nil {
+		s.logger.Error("Failed to get admins for notification", "chat_id", chatID, "event", event, "error", err)
+		return
+	}
+	var header string
+	for _, adminID := range admins {
+		pref, err := s.GetNotificationPrefs(ctx, chatID, adminID)
+		if err != nil {
+			s.logger.Error("Failed to get notification preferences", "chat_id", chatID, "user_id", adminID, "error", err)
+			continue
+		}
+		if !notificationEnabled(pref, event) {
+			continue
+		}
+		if header == "" {
+			header = fmt.Sprintf(messages.MsgNotifyHeader, s.chatTitle(ctx, chatID))
+		}
+		if err := s.notifier.Notify(ctx, adminID, header+text, actions); err != nil {
+			s.logger.Warn("Failed to send admin notification", "chat_id", chatID, "user_id", adminID, "event", event, "error", err)
+		}
+	}
+}
+
+func (s *ModerationService) chatTitle(ctx context.Context, chatID int64) string {
+	if s.bot != nil {
+		if chat, err := s.bot.Chats.GetChat(ctx, chatID); err == nil && chat.Title != "" {
+			return chat.Title
+		}
+	}
+	return strconv.FormatInt(chatID, 10)
+}
+
+func (s *ModerationService) buildDigest(chatID int64, digest string, since time.Time) (string, error) {
+	filters, err := s.auditRepo.CountDeletesByFilter(chatID, since)
+	if err != nil {
+		return "", err
+	}
+	offenders, err := s.auditRepo.TopOffenders(chatID, since, digestTopOffenders)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if digest == repository.DigestWeekly {
+		b.WriteString(messages.MsgDigestTitleWeekly)
+	} else {
+		b.WriteString(messages.MsgDigestTitleDaily)
+	}
+	if len(filters) == 0 && len(offenders) == 0 {
+		b.WriteString(messages.MsgDigestEmpty)
+		return b.String(), nil
+	}
+
+	var total int64
+	for _, f := range filters {
+		total += f.Count
+	}
+	fmt.Fprintf(&b, messages.MsgDigestDeleted, total)
+	for _, f := range filters {
+		name := f.Filter
+		if name == "" {
+			name = messages.MsgDigestManualFilter
+		}
+		fmt.Fprintf(&b, messages.MsgDigestFilterLine, name, f.Count)
+	}
+	if len(offenders) > 0 {
+		b.WriteString(messages.MsgDigestOffendersTitle)
+		for i, o := range offenders {
+			fmt.Fprintf(&b, messages.MsgDigestOffenderLine, i+1, o.UserID, o.Count)
+		}
+	}
+	return b.String(), nil
+}
+
+func (s *ModerationService) sendDigests(ctx context.Context, now time.Time) {
+	prefs, err := s.notificationRepo.GetDigestSubscribers()
+	if err != nil {
+		s.logger.Error("Failed to get digest subscribers", "error", err)
+		return
+	}
+	for _, pref := range prefs {
+		period := digestPeriod(pref.Digest)
+		if period == 0 {
+			continue
+		}
+		since := now.Add(-period)
+		if pref.LastDigestAt != nil {
+			if now.Sub(*pref.LastDigestAt) < period {
+				continue
+			}
+			since = *pref.LastDigestAt
+		}
+		isAdmin, err := s.chatAdminRepo.IsAdmin(pref.ChatID, pref.UserID)
+		if err != nil || !isAdmin {
+			continue
+		}
+		text, err := s.buildDigest(pref.ChatID, pref.Digest, since)
+		if err != nil {
+			s.logger.Error("Failed to build digest", "chat_id", pref.ChatID, "user_id", pref.UserID, "error", err)
+			continue
+		}
+		header := fmt.Sprintf(messages.MsgNotifyHeader, s.chatTitle(ctx, pref.ChatID))
+		if err := s.notifier.Notify(ctx, pref.UserID, header+text, nil); err != nil {
+			s.logger.Warn("Failed to send digest", "chat_id", pref.ChatID, "user_id", pref.UserID, "error", err)
+			continue
+		}
+		if err := s.notificationRepo.MarkDigestSent(pref.ChatID, pref.UserID, now); err != nil {
+			s.logger.Error("Failed to mark digest sent", "chat_id", pref.ChatID, "user_id", pref.UserID, "error", err)
+		}
+	}
+}
+
+func (s *ModerationService) botRightsChanged(chatID int64, isAdmin bool) bool {
+	prev, known := s.botRightsState.Swap(chatID, isAdmin)
+	return known && prev.(bool) && !isAdmin
+}
+
+func (s *ModerationService) checkBotRights(ctx context.Context) {
+	if s.bot == nil {
+		return
+	}
+	chatIDs, err := s.chatAdminRepo.GetLinkedChats()
+	if err != nil {
+		s.logger.Error("Failed to get linked chats for rights check", "error", err)
+		return
+	}
+	for _, chatID := range chatIDs {
+		member, err := s.bot.Chats.GetChatMembership(ctx, chatID)
+		if err != nil {
+			s.logger.Debug("Failed to get bot membership", "chat_id", chatID, "error", err)
+			continue
+		}
+		if s.botRightsChanged(chatID, member.IsAdmin) {
+			s.logger.Warn("Bot lost admin rights", "chat_id", chatID)
+			s.notifyAdmins(ctx, chatID, NotifyEventRights, messages.MsgNotifyRightsLost)
+		}
+	}
+}
+
+func (s *ModerationService) StartNotificationTasks(ctx context.Context) {
+	if s.notificationRepo == nil {
+		return
+	}
+	digestTicker := time.NewTicker(1 * time.Hour)
+	rightsTicker := time.NewTicker(botRightsInterval)
+
+	go func() {
+		defer digestTicker.Stop()
+		defer rightsTicker.Stop()
+		s.checkBotRights(ctx)
+		s.sendDigests(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-digestTicker.C:
+				s.sendDigests(ctx, time.Now())
+			case <-rightsTicker.C:
+				s.checkBotRights(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeNotifier struct {
+	sent    map[int64][]string
+	actions map[int64][]NotifyAction
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, userID int64, text string, actions []NotifyAction) error {
+	if f.sent == nil {
+		f.sent = map[int64][]string{}
+		f.actions = map[int64][]NotifyAction{}
+	}
+	f.sent[userID] = append(f.sent[userID], text)
+	f.actions[userID] = append(f.actions[userID], actions...)
+	return nil
+}
+
+func TestModerationService_RecordJoin(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+
+	start := time.Now()
+	for i := 0; i < raidJoinLimit-1; i++ {
+		if _, raid := svc.recordJoin(100, start.Add(time.Duration(i)*time.Second)); raid {
+			t.Fatalf("join %d flagged as raid below the limit", i+1)
+		}
+	}
+	count, raid := svc.recordJoin(100, start.Add(raidJoinLimit*time.Second))
+	if !raid || count != raidJoinLimit {
+		t.Fatalf("recordJoin() = (%d, %v), want (%d, true)", count, raid, raidJoinLimit)
+	}
+	if _, raid := svc.recordJoin(100, start.Add((raidJoinLimit+1)*time.Second)); raid {
+		t.Error("raid alert repeated within cooldown")
+	}
+	if _, raid := svc.recordJoin(200, start); raid {
+		t.Error("joins leaked between chats")
+	}
+	if count, _ := svc.recordJoin(100, start.Add(raidWindow+time.Hour)); count != 1 {
+		t.Errorf("count after window = %d, want 1", count)
+	}
+}
+
+func TestModerationService_NotifyAdmins(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	prefs := map[int64]*repository.NotificationPref{
+		2: {ChatID: 100, UserID: 2, NotifyAutoMute: false, NotifyRaid: true, NotifyRights: true},
+		3: {ChatID: 100, UserID: 3, NotifyAutoMute: true, NotifyRaid: true, NotifyRights: true, Silenced: true},
+	}
+	notificationRepo := &MockNotificationRepository{
+		GetFunc: func(chatID, userID int64) (*repository.NotificationPref, error) {
+			return prefs[userID], nil
+		},
+	}
+	chatAdminRepo := &MockChatAdminRepository{
+		GetAdminsFunc: func(chatID int64) ([]int64, error) {
+			return []int64{1, 2, 3}, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, chatAdminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo, nil, nil).(*ModerationService)
+	notifier := &fakeNotifier{}
+	svc.notifier = notifier
+
+	svc.notifyAdmins(context.Background(), 100, NotifyEventAutoMute, "muted")
+	if len(notifier.sent[1]) != 1 || len(notifier.sent[2]) != 0 || len(notifier.sent[3]) != 0 {
+		t.Fatalf("auto-mute notifications = %v, want only admin 1", notifier.sent)
+	}
+	if !strings.HasSuffix(notifier.sent[1][0], "muted") || !strings.Contains(notifier.sent[1][0], "100") {
+		t.Errorf("notification text = %q, want chat label and event text", notifier.sent[1][0])
+	}
+
+	notifier.sent = nil
+	svc.notifyAdmins(context.Background(), 100, NotifyEventRaid, "raid")
+	if len(notifier.sent[1]) != 1 || len(notifier.sent[2]) != 1 || len(notifier.sent[3]) != 0 {
+		t.Errorf("raid notifications = %v, want admins 1 and 2", notifier.sent)
+	}
+}
+
+func TestModerationService_ToggleNotification(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	var saved *repository.NotificationPref
+	notificationRepo := &MockNotificationRepository{
+		SaveFunc: func(pref *repository.NotificationPref) error {
+			saved = pref
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo, nil, nil)
+
+	enabled, err := svc.ToggleNotification(context.Background(), 100, 1, NotifyEventRaid)
+	if err != nil {
+		t.Fatalf("ToggleNotification() error = %v", err)
+	}
+	if enabled || saved == nil || saved.NotifyRaid || !saved.NotifyAutoMute || !saved.NotifyRights {
+		t.Errorf("ToggleNotification(raid) = %v, saved %+v, want raid off and other defaults kept", enabled, saved)
+	}
+	silenced, err := svc.ToggleNotification(context.Background(), 100, 1, NotifyOptionSilence)
+	if err != nil || !silenced || !saved.Silenced {
+		t.Errorf("ToggleNotification(silence) = (%v, %v), want silenced", silenced, err)
+	}
+	if _, err := svc.ToggleNotification(context.Background(), 100, 1, "unknown"); err == nil {
+		t.Error("ToggleNotification(unknown) expected error")
+	}
+}
+
+func TestModerationService_CycleDigest(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	stored := defaultNotificationPref(100, 1)
+	notificationRepo := &MockNotificationRepository{
+		GetFunc: func(chatID, userID int64) (*repository.NotificationPref, error) {
+			pref := *stored
+			return &pref, nil
+		},
+		SaveFunc: func(pref *repository.NotificationPref) error {
+			stored = pref
+			return nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo, nil, nil)
+
+	for _, want := range []string{repository.DigestDaily, repository.DigestWeekly, repository.DigestOff} {
+		got, err := svc.CycleDigest(context.Background(), 100, 1)
+		if err != nil {
+			t.Fatalf("CycleDigest() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("CycleDigest() = %q, want %q", got, want)
+		}
+		if want != repository.DigestOff && stored.LastDigestAt == nil {
+			t.Errorf("LastDigestAt not set when enabling %s digest", want)
+		}
+	}
+}
+
+func TestModerationService_SendDigests(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	now := time.Now()
+	recent := now.Add(-2 * time.Hour)
+	old := now.Add(-25 * time.Hour)
+	notificationRepo := &MockNotificationRepository{
+		GetDigestSubscribersFunc: func() ([]repository.NotificationPref, error) {
+			return []repository.NotificationPref{
+				{ChatID: 100, UserID: 1, Digest: repository.DigestDaily, LastDigestAt: &old},
+				{ChatID: 100, UserID: 2, Digest: repository.DigestDaily, LastDigestAt: &recent},
+				{ChatID: 100, UserID: 3, Digest: repository.DigestWeekly, LastDigestAt: &old},
+				{ChatID: 100, UserID: 4, Digest: repository.DigestDaily, LastDigestAt: &old},
+			}, nil
+		},
+	}
+	var marked []int64
+	notificationRepo.MarkDigestSentFunc = func(chatID, userID int64, at time.Time) error {
+		marked = append(marked, userID)
+		return nil
+	}
+	chatAdminRepo := &MockChatAdminRepository{
+		IsAdminFunc: func(chatID, userID int64) (bool, error) {
+			return userID != 4, nil
+		},
+	}
+	auditRepo := &MockAuditRepository{
+		CountDeletesByFilterFunc: func(chatID int64, since time.Time) ([]repository.AuditFilterCount, error) {
+			if !since.Equal(old) {
+				t.Errorf("since = %v, want last digest time %v", since, old)
+			}
+			return []repository.AuditFilterCount{{Filter: "word_filter", Count: 4}, {Filter: "link_filter", Count: 2}}, nil
+		},
+		TopOffendersFunc: func(chatID int64, since time.Time, limit int) ([]repository.AuditOffenderCount, error) {
+			return []repository.AuditOffenderCount{{UserID: 777, Count: 5}}, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, chatAdminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, nil, notificationRepo, nil, nil).(*ModerationService)
+	notifier := &fakeNotifier{}
+	svc.notifier = notifier
+
+	svc.sendDigests(context.Background(), now)
+
+	if len(notifier.sent) != 1 || len(notifier.sent[1]) != 1 {
+		t.Fatalf("digests sent = %v, want one digest for admin 1", notifier.sent)
+	}
+	text := notifier.sent[1][0]
+	for _, want := range []string{"6", "word_filter: 4", "link_filter: 2", "ID 777"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("digest %q does not contain %q", text, want)
+		}
+	}
+	if len(marked) != 1 || marked[0] != 1 {
+		t.Errorf("marked = %v, want [1]", marked)
+	}
+}
+
+func TestModerationService_BotRightsChanged(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*ModerationService)
+
+	if svc.botRightsChanged(100, false) {
+		t.Error("first observation reported as lost rights")
+	}
+	if svc.botRightsChanged(100, true) {
+		t.Error("gaining rights reported as lost rights")
+	}
+	if !svc.botRightsChanged(100, false) {
+		t.Error("losing rights not reported")
+	}
+	if svc.botRightsChanged(100, false) {
+		t.Error("lost rights reported twice")
+	}
+}
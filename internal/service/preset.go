@@ -0,0 +1,470 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+const maxPresetNameLen = 64
+
+var (
+	ErrPresetNotFound    = errors.New("preset not found")
+	ErrInvalidPresetName = errors.New("invalid preset name")
+	ErrPresetReadOnly    = errors.New("preset is read-only")
+)
+
+type PresetData struct {
+	Settings       SnapshotSettings     `json:"settings"`
+	BlockedWords   []string             `json:"blocked_words"`
+	BlockedDomains []string             `json:"blocked_domains"`
+	WordRules      repository.WordRules `json:"word_rules,omitempty"`
+}
+
+type PresetStatus struct {
+	Preset    *repository.SettingsPreset
+	Overrides []string
+}
+
+func presetDataFrom(settings *repository.ChatSettings) *PresetData {
+	data := &PresetData{
+		Settings:       snapshotSettingsFrom(settings),
+		BlockedWords:   FilterBlocklist(settings.BlockedWords, ""),
+		BlockedDomains: FilterBlocklist(settings.BlockedDomains, ""),
+	}
+	if len(settings.WordRules) > 0 {
+		data.WordRules = settings.WordRules
+	}
+	return data
+}
+
+func (d *PresetData) applyTo(settings *repository.ChatSettings) {
+	snap := &SettingsSnapshot{
+		Settings:       d.Settings,
+		BlockedWords:   d.BlockedWords,
+		BlockedDomains: d.BlockedDomains,
+		WordRules:      d.WordRules,
+	}
+	snap.applyTo(settings)
+}
+
+func (d *PresetData) fields() (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	raw, err := json.Marshal(d.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range map[string]any{
+		"blocked_words":   FilterBlocklist(d.BlockedWords, ""),
+		"blocked_domains": FilterBlocklist(d.BlockedDomains, ""),
+		"word_rules":      d.WordRules,
+	} {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = raw
+	}
+	if len(d.WordRules) == 0 {
+		fields["word_rules"] = json.RawMessage("null")
+	}
+	return fields, nil
+}
+
+func presetDataFromFields(fields map[string]json.RawMessage) (*PresetData, error) {
+	var data PresetData
+	settingsFields := make(map[string]json.RawMessage, len(fields))
+	for key, value := range fields {
+		switch key {
+		case "blocked_words":
+			if err := json.Unmarshal(value, &data.BlockedWords); err != nil {
+				return nil, err
+			}
+		case "blocked_domains":
+			if err := json.Unmarshal(value, &data.BlockedDomains); err != nil {
+				return nil, err
+			}
+		case "word_rules":
+			if err := json.Unmarshal(value, &data.WordRules); err != nil {
+				return nil, err
+			}
+		default:
+			settingsFields[key] = value
+		}
+	}
+	raw, err := json.Marshal(settingsFields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &data.Settings); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func parsePresetData(raw string) (*PresetData, error) {
+	var data PresetData
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, fmt.Errorf("invalid preset data: %w", err)
+	}
+	return &data, nil
+}
+
+func PresetOverrides(current, baseline *PresetData) ([]string, error) {
+	currentFields, err := current.fields()
+	if err != nil {
+		return nil, err
+	}
+	baselineFields, err := baseline.fields()
+	if err != nil {
+		return nil, err
+	}
+	var overrides []string
+	for key, value := range currentFields {
+		if !bytes.Equal(value, baselineFields[key]) {
+			overrides = append(overrides, key)
+		}
+	}
+	sort.Strings(overrides)
+	return overrides, nil
+}
+
+func MergePresetData(current, baseline, next *PresetData) (*PresetData, error) {
+	currentFields, err := current.fields()
+	if err != nil {
+		return nil, err
+	}
+	baselineFields, err := baseline.fields()
+	if err != nil {
+		return nil, err
+	}
+	nextFields, err := next.fields()
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]json.RawMessage, len(nextFields))
+	for key, value := range nextFields {
+		if bytes.Equal(currentFields[key], baselineFields[key]) {
+			merged[key] = value
+		} else {
+			merged[key] = currentFields[key]
+		}
+	}
+	return presetDataFromFields(merged)
+}
+
+func (s *ModerationService) availablePreset(userID int64, presetID uint) (*repository.SettingsPreset, error) {
+	preset, err := s.presetRepo.Get(presetID)
+	if err != nil {
+		return nil, err
+	}
+	if preset == nil || (preset.OwnerID != 0 && preset.OwnerID != userID) {
+		return nil, ErrPresetNotFound
+	}
+	return preset, nil
+}
+
+func (s *ModerationService) ownedPreset(userID int64, presetID uint, operator bool) (*repository.SettingsPreset, error) {
+	preset, err := s.availablePreset(userID, presetID)
+	if err != nil {
+		return nil, err
+	}
+	if !CanEditPreset(*preset, userID, operator) {
+		return nil, ErrPresetReadOnly
+	}
+	return preset, nil
+}
+
+func CanEditPreset(preset repository.SettingsPreset, userID int64, operator bool) bool {
+	if preset.OwnerID == 0 {
+		return operator
+	}
+	return preset.OwnerID == userID
+}
+
+func (s *ModerationService) GetPresets(ctx context.Context, userID int64) ([]repository.SettingsPreset, error) {
+	_, span := s.tracer.Start(ctx, "GetPresets")
+	defer span.End()
+	return s.presetRepo.GetAvailable(userID)
+}
+
+func (s *ModerationService) GetPreset(ctx context.Context, userID int64, presetID uint) (*repository.SettingsPreset, error) {
+	_, span := s.tracer.Start(ctx, "GetPreset")
+	defer span.End()
+	return s.availablePreset(userID, presetID)
+}
+
+func (s *ModerationService) GetPresetData(ctx context.Context, userID int64, presetID uint) (*PresetData, error) {
+	_, span := s.tracer.Start(ctx, "GetPresetData")
+	defer span.End()
+
+	preset, err := s.availablePreset(userID, presetID)
+	if err != nil {
+		return nil, err
+	}
+	return parsePresetData(preset.Data)
+}
+
+func (s *ModerationService) SavePresetFromChat(ctx context.Context, chatID, userID int64, name string, shared, operator bool) (*repository.SettingsPreset, error) {
+	_, span := s.tracer.Start(ctx, "SavePresetFromChat")
+	defer span.End()
+
+	if shared && !operator {
+		return nil, ErrPresetReadOnly
+	}
+
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || len([]rune(name)) > maxPresetNameLen {
+		return nil, ErrInvalidPresetName
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(presetDataFrom(settings))
+	if err != nil {
+		return nil, err
+	}
+	preset := &repository.SettingsPreset{
+		OwnerID:   userID,
+		CreatedBy: userID,
+		Name:      name,
+		Data:      string(raw),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if shared {
+		preset.OwnerID = 0
+	}
+	if err := s.presetRepo.Create(preset); err != nil {
+		return nil, err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: userID,
+		Action:  repository.AuditActionSetting,
+		Reason:  fmt.Sprintf("save_preset: %s", name),
+	})
+	return preset, nil
+}
+
+func (s *ModerationService) UpdatePresetFromChat(ctx context.Context, presetID uint, chatID, userID int64, operator bool) (int, error) {
+	_, span := s.tracer.Start(ctx, "UpdatePresetFromChat")
+	defer span.End()
+
+	preset, err := s.ownedPreset(userID, presetID, operator)
+	if err != nil {
+		return 0, err
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return 0, err
+	}
+	next := presetDataFrom(settings)
+	raw, err := json.Marshal(next)
+	if err != nil {
+		return 0, err
+	}
+	preset.Data = string(raw)
+	preset.UpdatedAt = time.Now()
+	if err := s.presetRepo.Update(preset); err != nil {
+		return 0, err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: userID,
+		Action:  repository.AuditActionSetting,
+		Reason:  fmt.Sprintf("update_preset: %s", preset.Name),
+	})
+
+	subscribers, err := s.presetRepo.GetSubscribedChats(presetID)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for _, subscriberID := range subscribers {
+		if err := s.syncPresetSubscriber(subscriberID, userID, preset, next); err != nil {
+			s.logger.Error("Failed to sync preset subscriber", "preset_id", presetID, "chat_id", subscriberID, "error", err)
+			continue
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+func (s *ModerationService) syncPresetSubscriber(chatID, actorID int64, preset *repository.SettingsPreset, next *PresetData) error {
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	baseline := next
+	if settings.PresetBaseline != "" {
+		if baseline, err = parsePresetData(settings.PresetBaseline); err != nil {
+			return err
+		}
+	}
+	merged, err := MergePresetData(presetDataFrom(settings), baseline, next)
+	if err != nil {
+		return err
+	}
+	merged.applyTo(settings)
+	raw, err := json.Marshal(next)
+	if err != nil {
+		return err
+	}
+	settings.PresetBaseline = string(raw)
+	if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: actorID,
+		Action:  repository.AuditActionSetting,
+		Reason:  fmt.Sprintf("sync_preset: %s", preset.Name),
+	})
+	return nil
+}
+
+func (s *ModerationService) applyPreset(chatID, userID int64, preset *repository.SettingsPreset, subscribe bool) error {
+	data, err := parsePresetData(preset.Data)
+	if err != nil {
+		return err
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	data.applyTo(settings)
+	settings.PresetID = nil
+	settings.PresetBaseline = ""
+	if subscribe {
+		id := preset.ID
+		settings.PresetID = &id
+		settings.PresetBaseline = preset.Data
+	}
+	if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+		return err
+	}
+	action := "apply_preset"
+	if subscribe {
+		action = "subscribe_preset"
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: userID,
+		Action:  repository.AuditActionSetting,
+		Reason:  fmt.Sprintf("%s: %s", action, preset.Name),
+	})
+	return nil
+}
+
+func (s *ModerationService) ApplyPreset(ctx context.Context, chatID, userID int64, presetID uint, subscribe bool) error {
+	_, span := s.tracer.Start(ctx, "ApplyPreset")
+	defer span.End()
+
+	preset, err := s.availablePreset(userID, presetID)
+	if err != nil {
+		return err
+	}
+	return s.applyPreset(chatID, userID, preset, subscribe)
+}
+
+func (s *ModerationService) UnsubscribePreset(ctx context.Context, chatID, userID int64) error {
+	_, span := s.tracer.Start(ctx, "UnsubscribePreset")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	if settings.PresetID == nil {
+		return nil
+	}
+	settings.PresetID = nil
+	settings.PresetBaseline = ""
+	if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+		return err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: userID,
+		Action:  repository.AuditActionSetting,
+		Reason:  "unsubscribe_preset",
+	})
+	return nil
+}
+
+func (s *ModerationService) GetPresetStatus(ctx context.Context, chatID int64) (*PresetStatus, error) {
+	_, span := s.tracer.Start(ctx, "GetPresetStatus")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.PresetID == nil {
+		return nil, nil
+	}
+	preset, err := s.presetRepo.Get(*settings.PresetID)
+	if err != nil {
+		return nil, err
+	}
+	if preset == nil {
+		return nil, nil
+	}
+	status := &PresetStatus{Preset: preset}
+	if settings.PresetBaseline == "" {
+		return status, nil
+	}
+	baseline, err := parsePresetData(settings.PresetBaseline)
+	if err != nil {
+		return nil, err
+	}
+	if status.Overrides, err = PresetOverrides(presetDataFrom(settings), baseline); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (s *ModerationService) DeletePreset(ctx context.Context, presetID uint, userID int64, operator bool) error {
+	_, span := s.tracer.Start(ctx, "DeletePreset")
+	defer span.End()
+
+	preset, err := s.ownedPreset(userID, presetID, operator)
+	if err != nil {
+		return err
+	}
+	subscribers, err := s.presetRepo.GetSubscribedChats(presetID)
+	if err != nil {
+		return err
+	}
+	for _, chatID := range subscribers {
+		if err := s.UnsubscribePreset(ctx, chatID, userID); err != nil {
+			return err
+		}
+	}
+	if err := s.presetRepo.Delete(presetID); err != nil {
+		return err
+	}
+	s.logger.Info("Preset deleted", "preset_id", presetID, "name", preset.Name, "user_id", userID)
+	return nil
+}
+
+func (s *ModerationService) GenerateLinkTokenWithPreset(ctx context.Context, userID int64, presetID uint) (string, error) {
+	_, span := s.tracer.Start(ctx, "GenerateLinkTokenWithPreset")
+	defer span.End()
+
+	if _, err := s.availablePreset(userID, presetID); err != nil {
+		return "", err
+	}
+	return s.linkTokenRepo.CreateWithPreset(userID, &presetID, 24*time.Hour)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMergePresetData(t *testing.T) {
+	baseline := &PresetData{
+		Settings:     SnapshotSettings{EnableWordFilter: true, SlowModeSeconds: 10, Timezone: "UTC"},
+		BlockedWords: []string{"spam"},
+	}
+	current := &PresetData{
+		Settings:     SnapshotSettings{EnableWordFilter: true, SlowModeSeconds: 60, Timezone: "UTC"},
+		BlockedWords: []string{"spam"},
+	}
+	next := &PresetData{
+		Settings:       SnapshotSettings{EnableWordFilter: false, EnableLinkFilter: true, SlowModeSeconds: 30, Timezone: "Europe/Moscow"},
+		BlockedWords:   []string{"spam", "casino"},
+		BlockedDomains: []string{"bad.com"},
+	}
+
+	overrides, err := PresetOverrides(current, baseline)
+	if err != nil {
+		t.Fatalf("PresetOverrides() error = %v", err)
+	}
+	if !reflect.DeepEqual(overrides, []string{"slow_mode_seconds"}) {
+		t.Errorf("overrides = %v, want [slow_mode_seconds]", overrides)
+	}
+
+	merged, err := MergePresetData(current, baseline, next)
+	if err != nil {
+		t.Fatalf("MergePresetData() error = %v", err)
+	}
+	if merged.Settings.SlowModeSeconds != 60 {
+		t.Errorf("slow mode = %d, want local override 60", merged.Settings.SlowModeSeconds)
+	}
+	if merged.Settings.EnableWordFilter || !merged.Settings.EnableLinkFilter || merged.Settings.Timezone != "Europe/Moscow" {
+		t.Errorf("settings = %+v, want preset values for untouched fields", merged.Settings)
+	}
+	if !reflect.DeepEqual(merged.BlockedWords, []string{"casino", "spam"}) || !reflect.DeepEqual(merged.BlockedDomains, []string{"bad.com"}) {
+		t.Errorf("lists = %v / %v, want preset lists", merged.BlockedWords, merged.BlockedDomains)
+	}
+}
+
+func TestModerationService_Presets(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	settings := map[int64]*repository.ChatSettings{
+		100: {ChatID: 100, BlockedWords: []string{"spam"}, EnableWordFilter: true, Timezone: "UTC", ArchiveDays: 7},
+		200: {ChatID: 200, EnableLinkFilter: true, Timezone: "UTC", ArchiveDays: 7},
+	}
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			copied := *settings[chatID]
+			return &copied, nil
+		},
+		UpdateSettingsFunc: func(s *repository.ChatSettings) error {
+			settings[s.ChatID] = s
+			return nil
+		},
+	}
+	presets := map[uint]*repository.SettingsPreset{}
+	presetRepo := &MockPresetRepository{
+		CreateFunc: func(p *repository.SettingsPreset) error {
+			p.ID = uint(len(presets) + 1)
+			presets[p.ID] = p
+			return nil
+		},
+		UpdateFunc: func(p *repository.SettingsPreset) error {
+			presets[p.ID] = p
+			return nil
+		},
+		GetFunc: func(id uint) (*repository.SettingsPreset, error) {
+			return presets[id], nil
+		},
+		GetSubscribedChatsFunc: func(id uint) ([]int64, error) {
+			var chats []int64
+			for chatID, s := range settings {
+				if s.PresetID != nil && *s.PresetID == id {
+					chats = append(chats, chatID)
+				}
+			}
+			return chats, nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &MockAuditRepository{}, nil, nil, nil, presetRepo, nil)
+	ctx := context.Background()
+
+	preset, err := svc.SavePresetFromChat(ctx, 100, 5, "  Work   chat ", false, false)
+	if err != nil {
+		t.Fatalf("SavePresetFromChat() error = %v", err)
+	}
+	if preset.Name != "Work chat" || preset.OwnerID != 5 {
+		t.Errorf("preset = %+v, want personal preset named %q", preset, "Work chat")
+	}
+	if _, err := svc.SavePresetFromChat(ctx, 100, 5, " ", true, true); err != ErrInvalidPresetName {
+		t.Errorf("SavePresetFromChat(blank) error = %v, want %v", err, ErrInvalidPresetName)
+	}
+	if _, err := svc.GetPreset(ctx, 6, preset.ID); err != ErrPresetNotFound {
+		t.Errorf("GetPreset(other admin) error = %v, want %v", err, ErrPresetNotFound)
+	}
+
+	if err := svc.ApplyPreset(ctx, 200, 5, preset.ID, true); err != nil {
+		t.Fatalf("ApplyPreset() error = %v", err)
+	}
+	got := settings[200]
+	if got.PresetID == nil || *got.PresetID != preset.ID || !got.EnableWordFilter || !reflect.DeepEqual([]string(got.BlockedWords), []string{"spam"}) {
+		t.Fatalf("settings after subscribe = %+v", got)
+	}
+
+	settings[200].SlowModeSeconds = 45
+	status, err := svc.GetPresetStatus(ctx, 200)
+	if err != nil || status == nil {
+		t.Fatalf("GetPresetStatus() = %v, %v", status, err)
+	}
+	if !reflect.DeepEqual(status.Overrides, []string{"slow_mode_seconds"}) {
+		t.Errorf("overrides = %v, want [slow_mode_seconds]", status.Overrides)
+	}
+
+	settings[100].BlockedWords = []string{"spam", "casino"}
+	settings[100].SlowModeSeconds = 10
+	updated, err := svc.UpdatePresetFromChat(ctx, preset.ID, 100, 5, false)
+	if err != nil {
+		t.Fatalf("UpdatePresetFromChat() error = %v", err)
+	}
+	if updated != 1 {
+		t.Errorf("updated = %d, want 1 subscriber", updated)
+	}
+	got = settings[200]
+	if got.SlowModeSeconds != 45 {
+		t.Errorf("slow mode = %d, want local override 45", got.SlowModeSeconds)
+	}
+	if !reflect.DeepEqual([]string(got.BlockedWords), []string{"casino", "spam"}) {
+		t.Errorf("blocked words = %v, want preset update", got.BlockedWords)
+	}
+	var baseline PresetData
+	if err := json.Unmarshal([]byte(got.PresetBaseline), &baseline); err != nil || baseline.Settings.SlowModeSeconds != 10 {
+		t.Errorf("baseline = %+v (%v), want updated preset values", baseline, err)
+	}
+
+	if err := svc.UnsubscribePreset(ctx, 200, 5); err != nil {
+		t.Fatalf("UnsubscribePreset() error = %v", err)
+	}
+	if settings[200].PresetID != nil || settings[200].PresetBaseline != "" {
+		t.Errorf("subscription not cleared: %+v", settings[200])
+	}
+}
+
+func TestModerationService_PresetAccess(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			return &repository.ChatSettings{ChatID: chatID, Timezone: "UTC"}, nil
+		},
+	}
+	presets := map[uint]*repository.SettingsPreset{
+		1: {ID: 1, OwnerID: 0, Name: "Shared", Data: "{}"},
+		2: {ID: 2, OwnerID: 5, Name: "Personal", Data: "{}"},
+	}
+	var changed []uint
+	presetRepo := &MockPresetRepository{
+		CreateFunc: func(p *repository.SettingsPreset) error {
+			changed = append(changed, 0)
+			return nil
+		},
+		UpdateFunc: func(p *repository.SettingsPreset) error {
+			changed = append(changed, p.ID)
+			return nil
+		},
+		DeleteFunc: func(id uint) error {
+			changed = append(changed, id)
+			return nil
+		},
+		GetFunc: func(id uint) (*repository.SettingsPreset, error) {
+			copied := *presets[id]
+			return &copied, nil
+		},
+		GetSubscribedChatsFunc: func(id uint) ([]int64, error) {
+			return nil, nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &MockAuditRepository{}, nil, nil, nil, presetRepo, nil)
+	ctx := context.Background()
+
+	if _, err := svc.SavePresetFromChat(ctx, 100, 6, "Shared", true, false); !errors.Is(err, ErrPresetReadOnly) {
+		t.Errorf("SavePresetFromChat(shared, not operator) error = %v, want %v", err, ErrPresetReadOnly)
+	}
+	if _, err := svc.UpdatePresetFromChat(ctx, 1, 100, 6, false); !errors.Is(err, ErrPresetReadOnly) {
+		t.Errorf("UpdatePresetFromChat(shared, not operator) error = %v, want %v", err, ErrPresetReadOnly)
+	}
+	if err := svc.DeletePreset(ctx, 1, 6, false); !errors.Is(err, ErrPresetReadOnly) {
+		t.Errorf("DeletePreset(shared, not operator) error = %v, want %v", err, ErrPresetReadOnly)
+	}
+	if err := svc.DeletePreset(ctx, 2, 6, true); !errors.Is(err, ErrPresetNotFound) {
+		t.Errorf("DeletePreset(foreign personal) error = %v, want %v", err, ErrPresetNotFound)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("presets changed without access: %v", changed)
+	}
+	if _, err := svc.GetPreset(ctx, 6, 1); err != nil {
+		t.Errorf("GetPreset(shared) error = %v, want read access", err)
+	}
+
+	if _, err := svc.SavePresetFromChat(ctx, 100, 7, "Shared", true, true); err != nil {
+		t.Errorf("SavePresetFromChat(shared, operator) error = %v", err)
+	}
+	if _, err := svc.UpdatePresetFromChat(ctx, 1, 100, 7, true); err != nil {
+		t.Errorf("UpdatePresetFromChat(shared, operator) error = %v", err)
+	}
+	if err := svc.DeletePreset(ctx, 2, 5, false); err != nil {
+		t.Errorf("DeletePreset(own personal) error = %v", err)
+	}
+	if !reflect.DeepEqual(changed, []uint{0, 1, 2}) {
+		t.Errorf("changed presets = %v, want [0 1 2]", changed)
+	}
+}
+
+func TestModerationService_LinkGroupWithPreset(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	presetID := uint(3)
+	data, _ := json.Marshal(PresetData{Settings: SnapshotSettings{RestrictVideo: true, Timezone: "UTC", ArchiveDays: 7}, BlockedDomains: []string{"bad.com"}})
+	var stored *repository.ChatSettings
+	settingsRepo := &MockSettingsRepository{
+		GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+			return &repository.ChatSettings{ChatID: chatID, EnableWordFilter: true}, nil
+		},
+		UpdateSettingsFunc: func(s *repository.ChatSettings) error {
+			stored = s
+			return nil
+		},
+	}
+	linkRepo := &MockLinkTokenRepository{
+		GetFunc: func(token string) (*repository.LinkToken, error) {
+			return &repository.LinkToken{Token: token, UserID: 1, PresetID: &presetID}, nil
+		},
+	}
+	presetRepo := &MockPresetRepository{
+		GetFunc: func(id uint) (*repository.SettingsPreset, error) {
+			return &repository.SettingsPreset{ID: id, Name: "Kids community", Data: string(data)}, nil
+		},
+	}
+	svc := NewModerationService(logger, settingsRepo, &MockChatAdminRepository{}, linkRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &MockAuditRepository{}, nil, nil, nil, presetRepo, nil)
+
+	if err := svc.LinkGroup(context.Background(), "token", 100, 1); err != nil {
+		t.Fatalf("LinkGroup() error = %v", err)
+	}
+	if stored == nil || !stored.RestrictVideo || stored.EnableWordFilter || stored.PresetID == nil || *stored.PresetID != presetID {
+		t.Errorf("settings = %+v, want preset applied and subscribed", stored)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"time"
+)
+
+const (
+	reportLimit  = 3
+	reportWindow = 10 * time.Minute
+)
+
+var (
+	ErrReportRateLimited = errors.New("too many reports")
+	ErrReportResolved    = errors.New("report is already resolved")
+)
+
+func (s *ModerationService) FileReport(ctx context.Context, report *repository.Report) ([]int64, error) {
+	_, span := s.tracer.Start(ctx, "FileReport")
+	defer span.End()
+
+	count, err := s.reportRepo.CountByReporterSince(report.ChatID, report.ReporterID, time.Now().Add(-reportWindow))
+	if err != nil {
+		return nil, err
+	}
+	if count >= reportLimit {
+		return nil, ErrReportRateLimited
+	}
+
+	runes := []rune(report.Text)
+	if len(runes) > 4000 {
+		report.Text = string(runes[:4000])
+	}
+	report.Status = repository.ReportStatusOpen
+	if err := s.reportRepo.Create(report); err != nil {
+		return nil, err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:       report.ChatID,
+		ActorID:      report.ReporterID,
+		TargetUserID: report.TargetUserID,
+		Action:       repository.AuditActionReport,
+		Reason:       report.Reason,
+		MessageID:    report.MessageID,
+	})
+
+	return s.adminsWithRole(report.ChatID, repository.RoleModerator)
+}
+
+func (s *ModerationService) SetReportNotices(ctx context.Context, reportID uint, noticeIDs []string) error {
+	_, span := s.tracer.Start(ctx, "SetReportNotices")
+	defer span.End()
+	return s.reportRepo.SetNoticeIDs(reportID, noticeIDs)
+}
+
+func (s *ModerationService) ResolveReport(ctx context.Context, reportID uint, adminID int64, status string) (*repository.Report, error) {
+	_, span := s.tracer.Start(ctx, "ResolveReport")
+	defer span.End()
+
+	switch status {
+	case repository.ReportStatusDeleted, repository.ReportStatusWarned, repository.ReportStatusMuted,
+		repository.ReportStatusBanned, repository.ReportStatusDismissed:
+	default:
+		return nil, fmt.Errorf("unknown report decision: %s", status)
+	}
+
+	report, err := s.reportRepo.Get(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, fmt.Errorf("report %d not found", reportID)
+	}
+
+	if err := s.requireRole(report.ChatID, adminID, repository.RoleModerator); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.reportRepo.Resolve(reportID, status, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return report, ErrReportResolved
+	}
+	report.Status = status
+	report.ResolvedBy = adminID
+	return report, nil
+}
+
+func (s *ModerationService) ReopenReport(ctx context.Context, reportID uint, status string) error {
+	_, span := s.tracer.Start(ctx, "ReopenReport")
+	defer span.End()
+	return s.reportRepo.Reopen(reportID, status)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModerationService_FileReport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	tests := []struct {
+		name       string
+		count      int64
+		wantErr    error
+		wantCreate bool
+	}{
+		{name: "First report", count: 0, wantCreate: true},
+		{name: "Below limit", count: reportLimit - 1, wantCreate: true},
+		{name: "Rate limited", count: reportLimit, wantErr: ErrReportRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *repository.Report
+			var audited *repository.AuditEntry
+			reportRepo := &MockReportRepository{
+				CountByReporterSinceFunc: func(chatID, reporterID int64, since time.Time) (int64, error) {
+					if time.Since(since) < reportWindow-time.Second {
+						t.Errorf("since = %v, want about %v ago", since, reportWindow)
+					}
+					return tt.count, nil
+				},
+				CreateFunc: func(report *repository.Report) error {
+					report.ID = 7
+					created = report
+					return nil
+				},
+			}
+			chatAdminRepo := &MockChatAdminRepository{
+				GetAdminsFunc: func(chatID int64) ([]int64, error) {
+					return []int64{1, 2}, nil
+				},
+			}
+			auditRepo := &MockAuditRepository{
+				AddFunc: func(entry *repository.AuditEntry) error {
+					audited = entry
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, nil, chatAdminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, auditRepo, nil, reportRepo, nil, nil, nil)
+
+			report := &repository.Report{ChatID: 100, ReporterID: 5, TargetUserID: 6, MessageID: "mid", Text: strings.Repeat("я", 5000), Reason: "spam"}
+			admins, err := svc.FileReport(context.Background(), report)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FileReport() error = %v, want %v", err, tt.wantErr)
+			}
+			if (created != nil) != tt.wantCreate {
+				t.Fatalf("created = %v, want %v", created != nil, tt.wantCreate)
+			}
+			if !tt.wantCreate {
+				return
+			}
+			if len(admins) != 2 {
+				t.Errorf("admins = %v, want 2 admins", admins)
+			}
+			if created.Status != repository.ReportStatusOpen {
+				t.Errorf("status = %q, want %q", created.Status, repository.ReportStatusOpen)
+			}
+			if n := len([]rune(created.Text)); n != 4000 {
+				t.Errorf("text length = %d, want 4000", n)
+			}
+			if audited == nil || audited.Action != repository.AuditActionReport || audited.ActorID != 5 || audited.TargetUserID != 6 {
+				t.Errorf("audit entry = %+v, want report by 5 against 6", audited)
+			}
+		})
+	}
+}
+
+func TestModerationService_ResolveReport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	tests := []struct {
+		name      string
+		status    string
+		isAdmin   bool
+		claimed   bool
+		wantErr   bool
+		wantErrIs error
+	}{
+		{name: "First decision wins", status: repository.ReportStatusBanned, isAdmin: true, claimed: true},
+		{name: "Already resolved", status: repository.ReportStatusWarned, isAdmin: true, claimed: false, wantErr: true, wantErrIs: ErrReportResolved},
+		{name: "Not an admin", status: repository.ReportStatusDeleted, isAdmin: false, wantErr: true},
+		{name: "Unknown decision", status: "nuke", isAdmin: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolveCalled := false
+			reportRepo := &MockReportRepository{
+				GetFunc: func(id uint) (*repository.Report, error) {
+					return &repository.Report{ID: id, ChatID: 100, Status: repository.ReportStatusOpen}, nil
+				},
+				ResolveFunc: func(id uint, status string, resolvedBy int64) (bool, error) {
+					resolveCalled = true
+					return tt.claimed, nil
+				},
+			}
+			chatAdminRepo := &MockChatAdminRepository{
+				IsAdminFunc: func(chatID, userID int64) (bool, error) {
+					return tt.isAdmin, nil
+				},
+			}
+			svc := NewModerationService(logger, nil, chatAdminRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, reportRepo, nil, nil, nil)
+
+			report, err := svc.ResolveReport(context.Background(), 7, 1, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("ResolveReport() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if !tt.isAdmin && resolveCalled {
+				t.Error("Resolve called for non-admin")
+			}
+			if tt.wantErr {
+				return
+			}
+			if report.Status != tt.status || report.ResolvedBy != 1 {
+				t.Errorf("report = %+v, want status %q resolved by 1", report, tt.status)
+			}
+		})
+	}
+}
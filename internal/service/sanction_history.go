@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/utils"
+	"time"
+)
+
+const (
+	SanctionSourceAdmin        = "admin"
+	SanctionSourceChatGroup    = "chat_group"
+	SanctionSourceStrikeLadder = "strike_ladder"
+	SanctionSourceGlobalBan    = "global_ban"
+	SanctionSourceAppeal       = "appeal"
+)
+
+func (s *ModerationService) KickUser(ctx context.Context, chatID, moderatorID, userID int64, userName, reason, source string) error {
+	ctx, span := s.tracer.Start(ctx, "KickUser")
+	defer span.End()
+
+	if source == SanctionSourceAdmin {
+		if err := s.requireRole(chatID, moderatorID, repository.RoleModerator); err != nil {
+			return err
+		}
+	}
+	if err := s.SystemKickUser(ctx, chatID, userID); err != nil {
+		return err
+	}
+	s.recordSanction(chatID, userID, userName, SanctionKick, reason, source, moderatorID, 0)
+	return nil
+}
+
+func (s *ModerationService) GetSanctionHistory(ctx context.Context, chatID, userID int64, page int) ([]repository.SanctionRecord, int64, error) {
+	_, span := s.tracer.Start(ctx, "GetSanctionHistory")
+	defer span.End()
+	pageSize := 10
+	offset := (page - 1) * pageSize
+	return s.historyRepo.GetUserHistory(chatID, userID, offset, pageSize)
+}
+
+func (s *ModerationService) recordSanction(chatID, userID int64, userName, sanctionType, reason, source string, issuedBy int64, duration time.Duration) {
+	s.audit(repository.AuditEntry{
+		ChatID:       chatID,
+		ActorID:      issuedBy,
+		TargetUserID: userID,
+		Action:       sanctionType,
+		Filter:       source,
+		Reason:       reason,
+	})
+	if s.historyRepo == nil {
+		return
+	}
+	record := &repository.SanctionRecord{
+		ChatID:   chatID,
+		UserID:   userID,
+		UserName: userName,
+		Type:     sanctionType,
+		Reason:   reason,
+		IssuedBy: issuedBy,
+		Source:   source,
+	}
+	if sanctionType != SanctionKick {
+		expiresAt := repository.PermanentExpiry
+		if duration > 0 && duration != utils.Forever {
+			expiresAt = repository.ExpiryAfter(duration)
+			record.DurationSeconds = int64(duration / time.Second)
+		}
+		record.ExpiresAt = &expiresAt
+		s.endSanction(chatID, userID, sanctionType, issuedBy, repository.SanctionEndReplaced)
+	}
+	if err := s.historyRepo.Add(record); err != nil {
+		s.logger.Error("Failed to record sanction", "chat_id", chatID, "user_id", userID, "type", sanctionType, "error", err)
+	}
+}
+
+func (s *ModerationService) endSanction(chatID, userID int64, sanctionType string, endedBy int64, reason string) {
+	if s.historyRepo == nil {
+		return
+	}
+	if err := s.historyRepo.End(chatID, userID, sanctionType, endedBy, reason); err != nil {
+		s.logger.Error("Failed to end sanction record", "chat_id", chatID, "user_id", userID, "type", sanctionType, "error", err)
+	}
+}
+
+func (s *ModerationService) endExpiredSanctions() {
+	now := time.Now()
+	if s.appealRepo != nil {
+		if _, err := s.appealRepo.CloseExpired(now); err != nil {
+			s.logger.Error("Failed to close appeals for expired sanctions", "error", err)
+		}
+	}
+	if s.historyRepo == nil {
+		return
+	}
+	if _, err := s.historyRepo.EndExpired(now); err != nil {
+		s.logger.Error("Failed to close expired sanction records", "error", err)
+	}
+}
+
+func (s *ModerationService) StartSanctionExpiryTask(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	go func() {
+		defer ticker.Stop()
+		s.endExpiredSanctions()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.endExpiredSanctions()
+			}
+		}
+	}()
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"testing"
+	"time"
+)
+
+type sanctionEnd struct {
+	sanctionType string
+	endedBy      int64
+	reason       string
+}
+
+func newHistoryRecorder() (*MockSanctionHistoryRepository, *[]repository.SanctionRecord, *[]sanctionEnd) {
+	var added []repository.SanctionRecord
+	var ended []sanctionEnd
+	repo := &MockSanctionHistoryRepository{
+		AddFunc: func(record *repository.SanctionRecord) error {
+			added = append(added, *record)
+			return nil
+		},
+		EndFunc: func(chatID, userID int64, sanctionType string, endedBy int64, reason string) error {
+			ended = append(ended, sanctionEnd{sanctionType: sanctionType, endedBy: endedBy, reason: reason})
+			return nil
+		},
+	}
+	return repo, &added, &ended
+}
+
+func TestModerationService_SanctionHistory_Mute(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	historyRepo, added, ended := newHistoryRecorder()
+	adminRepo := &MockChatAdminRepository{
+		IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+	}
+	svc := NewModerationService(logger, nil, adminRepo, nil, &MockMuteRepository{}, nil, &MockViolationRepository{}, nil, nil, nil, nil, nil, nil, historyRepo, nil, nil, nil, nil, nil, nil).(*ModerationService)
+
+	if err := svc.MuteUser(context.Background(), 100, 7, 456, "User", "флуд", time.Hour); err != nil {
+		t.Fatalf("MuteUser() error = %v", err)
+	}
+	if len(*added) != 1 {
+		t.Fatalf("records added = %d, want 1", len(*added))
+	}
+	record := (*added)[0]
+	if record.Type != SanctionMute || record.IssuedBy != 7 || record.Source != SanctionSourceAdmin || record.DurationSeconds != 3600 || record.Reason != "флуд" {
+		t.Errorf("unexpected mute record: %+v", record)
+	}
+	if record.ExpiresAt == nil || record.ExpiresAt.Before(time.Now()) {
+		t.Errorf("mute record expiry = %v, want future", record.ExpiresAt)
+	}
+	if len(*ended) != 1 || (*ended)[0].reason != repository.SanctionEndReplaced {
+		t.Errorf("previous mute not closed as replaced: %+v", *ended)
+	}
+
+	if err := svc.UnmuteUser(context.Background(), 100, 7, 456); err != nil {
+		t.Fatalf("UnmuteUser() error = %v", err)
+	}
+	last := (*ended)[len(*ended)-1]
+	if last.sanctionType != SanctionMute || last.endedBy != 7 || last.reason != repository.SanctionEndUnmuted {
+		t.Errorf("unmute end = %+v", last)
+	}
+}
+
+func TestModerationService_SanctionHistory_SystemMuteSource(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	historyRepo, added, _ := newHistoryRecorder()
+	svc := NewModerationService(logger, nil, nil, nil, &MockMuteRepository{}, nil, &MockViolationRepository{}, nil, nil, nil, nil, nil, nil, historyRepo, nil, nil, nil, nil, nil, nil).(*ModerationService)
+
+	if err := svc.SystemMuteUser(context.Background(), 100, 456, "User", "флуд", "rate_limit", time.Minute); err != nil {
+		t.Fatalf("SystemMuteUser() error = %v", err)
+	}
+	if len(*added) != 1 || (*added)[0].IssuedBy != 0 || (*added)[0].Source != "rate_limit" {
+		t.Errorf("unexpected system mute records: %+v", *added)
+	}
+}
+
+func TestModerationService_SanctionHistory_AppealReduce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	historyRepo, added, ended := newHistoryRecorder()
+	banRepo := &MockBanRepository{
+		IsBannedFunc: func(chatID, userID int64) (bool, time.Time, error) {
+			return true, repository.PermanentExpiry, nil
+		},
+	}
+	appealRepo := &MockAppealRepository{
+		GetFunc: func(id uint) (*repository.Appeal, error) {
+			return &repository.Appeal{ID: id, ChatID: 100, UserID: 456, SanctionType: SanctionBan}, nil
+		},
+	}
+	adminRepo := &MockChatAdminRepository{
+		IsAdminFunc: func(chatID, userID int64) (bool, error) { return true, nil },
+	}
+	svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, &MockViolationRepository{}, nil, nil, banRepo, appealRepo, nil, nil, historyRepo, nil, nil, nil, nil, nil, nil)
+
+	if _, _, err := svc.ResolveAppeal(context.Background(), 1, 7, repository.AppealStatusReduced); err != nil {
+		t.Fatalf("ResolveAppeal() error = %v", err)
+	}
+	if len(*ended) == 0 || (*ended)[0].reason != repository.SanctionEndReduced || (*ended)[0].endedBy != 7 {
+		t.Errorf("reduced ban not closed: %+v", *ended)
+	}
+	if len(*added) != 1 || (*added)[0].Source != SanctionSourceAppeal || (*added)[0].Type != SanctionBan {
+		t.Errorf("reduced ban not re-recorded: %+v", *added)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"max-moderation-bot/internal/messages"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/schedule"
+	"time"
+
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+)
+
+const scheduleNoticeLifetime = 10 * time.Minute
+
+func (s *ModerationService) GetScheduleRules(ctx context.Context, chatID int64) ([]repository.ScheduleRule, error) {
+	_, span := s.tracer.Start(ctx, "GetScheduleRules")
+	defer span.End()
+	return s.scheduleRepo.GetRules(chatID)
+}
+
+func (s *ModerationService) AddScheduleRule(ctx context.Context, chatID int64, text string) (*repository.ScheduleRule, error) {
+	_, span := s.tracer.Start(ctx, "AddScheduleRule")
+	defer span.End()
+
+	rule, err := schedule.ParseRule(text)
+	if err != nil {
+		return nil, err
+	}
+	rule.ChatID = chatID
+	if err := s.scheduleRepo.AddRule(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *ModerationService) DeleteScheduleRule(ctx context.Context, chatID int64, ruleID uint) error {
+	_, span := s.tracer.Start(ctx, "DeleteScheduleRule")
+	defer span.End()
+	return s.scheduleRepo.DeleteRule(chatID, ruleID)
+}
+
+func (s *ModerationService) SetTimezone(ctx context.Context, chatID int64, tz string) error {
+	_, span := s.tracer.Start(ctx, "SetTimezone")
+	defer span.End()
+
+	if _, err := schedule.LoadLocation(tz); err != nil {
+		return err
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	settings.Timezone = tz
+	return s.settingsRepo.UpdateSettings(settings)
+}
+
+func (s *ModerationService) StartScheduleNotifier(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute)
+
+	check := func(notify bool) {
+		chatIDs, err := s.scheduleRepo.GetScheduledChatIDs()
+		if err != nil {
+			s.logger.Error("Failed to get scheduled chats", "error", err)
+			return
+		}
+		for _, chatID := range chatIDs {
+			rules, err := s.scheduleRepo.GetRules(chatID)
+			if err != nil {
+				s.logger.Error("Failed to get schedule rules", "chat_id", chatID, "error", err)
+				continue
+			}
+			settings, err := s.settingsRepo.GetSettings(chatID)
+			if err != nil {
+				s.logger.Error("Failed to get settings for schedule", "chat_id", chatID, "error", err)
+				continue
+			}
+			active := schedule.Active(rules, settings.Timezone, time.Now()).Any()
+			prev, known := s.scheduleState.Swap(chatID, active)
+			if !notify || !known || prev.(bool) == active || !settings.ScheduleNotices {
+				continue
+			}
+			text := messages.MsgQuietHoursEnded
+			if active {
+				text = messages.MsgQuietHoursStarted
+			}
+			s.sendScheduleNotice(ctx, chatID, text)
+		}
+	}
+
+	go check(false)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				check(true)
+			}
+		}
+	}()
+}
+
+func (s *ModerationService) sendScheduleNotice(ctx context.Context, chatID int64, text string) {
+	if s.bot == nil {
+		return
+	}
+	msg := maxbot.NewMessage()
+	msg.SetChat(chatID)
+	msg.SetText(text)
+	msg.SetFormat("markdown")
+	respMsg, err := s.bot.Messages.SendWithResult(ctx, msg)
+	if err != nil {
+		s.logger.Error("Failed to send schedule notice", "chat_id", chatID, "error", err)
+		return
+	}
+	if respMsg != nil && respMsg.Body.Mid != "" {
+		if err := s.tempMessageRepo.Add(chatID, respMsg.Body.Mid, scheduleNoticeLifetime); err != nil {
+			s.logger.Error("Failed to schedule notice deletion", "chat_id", chatID, "error", err)
+		}
+	}
+}
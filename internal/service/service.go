@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"max-moderation-bot/internal/messages"
 	"max-moderation-bot/internal/metrics"
 	"max-moderation-bot/internal/pipeline"
 	"max-moderation-bot/internal/pipeline/filters"
 	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/schedule"
 	"max-moderation-bot/internal/utils"
 	"strings"
+	"sync"
 	"time"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
@@ -20,6 +23,7 @@ import (
 type Service interface {
 	ModerateMessage(ctx context.Context, payload pipeline.Payload) (*pipeline.Result, error)
 	GenerateLinkToken(ctx context.Context, userID int64) (string, error)
+	GenerateLinkTokenWithPreset(ctx context.Context, userID int64, presetID uint) (string, error)
 	GetManagedChats(ctx context.Context, userID int64) ([]int64, error)
 	GetManagedChatsPaginated(ctx context.Context, userID int64, page int) ([]int64, int64, error)
 	GetChatSettings(ctx context.Context, chatID int64) (*repository.ChatSettings, error)
@@ -30,31 +34,149 @@ type Service interface {
 	SetBlockedDomains(ctx context.Context, chatID int64, domains []string) error
 	InitializeChat(ctx context.Context, chatID int64) error
 	LinkGroup(ctx context.Context, token string, chatID, userID int64) error
-	MuteUser(ctx context.Context, chatID, adminID, userID int64, userName string, duration time.Duration) error
-	SystemMuteUser(ctx context.Context, chatID, userID int64, userName string, duration time.Duration) error
-	TrackViolation(ctx context.Context, chatID, userID int64, violationType string) (bool, time.Duration, error)
+	MuteUser(ctx context.Context, chatID, adminID, userID int64, userName, reason string, duration time.Duration) error
+	SystemMuteUser(ctx context.Context, chatID, userID int64, userName, reason, source string, duration time.Duration) error
+	TrackViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) (*StrikeOutcome, error)
+	GetStrikeLadder(ctx context.Context, chatID int64) ([]repository.StrikeStep, bool, error)
+	AddStrikeStep(ctx context.Context, chatID int64, text string) (*repository.StrikeStep, error)
+	DeleteStrikeStep(ctx context.Context, chatID int64, stepID uint) error
+	GetViolationWeights(ctx context.Context, chatID int64) (map[string]int, error)
+	SetViolationWeights(ctx context.Context, chatID int64, text string) error
+	SystemKickUser(ctx context.Context, chatID, userID int64) error
+	KickUser(ctx context.Context, chatID, moderatorID, userID int64, userName, reason, source string) error
+	WarnUser(ctx context.Context, chatID, moderatorID, userID int64, reason string) (*StrikeOutcome, error)
+	GetActiveWarnings(ctx context.Context, chatID, userID int64) ([]repository.UserViolation, error)
+	RemoveLatestWarning(ctx context.Context, chatID, moderatorID, userID int64) (*repository.UserViolation, error)
+	ForgiveUser(ctx context.Context, chatID, moderatorID, userID int64) (int, error)
+	SetStrikeDecay(ctx context.Context, chatID int64, days int) error
+	BanUser(ctx context.Context, chatID, moderatorID, userID int64, userName, reason string, duration time.Duration) error
+	UnbanUser(ctx context.Context, chatID, adminID, userID int64) error
+	GetActiveBansPaginated(ctx context.Context, chatID int64, page int) ([]repository.Ban, int64, error)
+	GetBan(ctx context.Context, chatID, userID int64) (*repository.Ban, error)
+	EnforceBan(ctx context.Context, chatID, userID int64) (bool, error)
+	AddGlobalBan(ctx context.Context, operatorID, userID int64, reason string) error
+	RemoveGlobalBan(ctx context.Context, userID int64) (bool, error)
+	CountGlobalBans(ctx context.Context) (int64, error)
+	ImportGlobalBans(ctx context.Context, operatorID int64, data []byte, format string) (int, error)
+	ExportGlobalBans(ctx context.Context, format string) ([]byte, error)
+	EnforceGlobalBan(ctx context.Context, chatID, userID int64, trigger string) (bool, error)
+	GetUserSanctions(ctx context.Context, userID int64) ([]Sanction, error)
+	CreateChatGroup(ctx context.Context, ownerID int64, name string) (*repository.ChatGroup, error)
+	GetChatGroups(ctx context.Context, ownerID int64) ([]repository.ChatGroup, error)
+	GetChatGroup(ctx context.Context, ownerID int64, groupID uint) (*repository.ChatGroup, []int64, error)
+	DeleteChatGroup(ctx context.Context, ownerID int64, groupID uint) error
+	ToggleGroupPropagation(ctx context.Context, ownerID int64, groupID uint) (bool, error)
+	AddChatToGroup(ctx context.Context, ownerID int64, groupID uint, chatID int64) error
+	RemoveChatFromGroup(ctx context.Context, ownerID int64, groupID uint, chatID int64) error
+	CheckAppeal(ctx context.Context, chatID, userID int64, sanctionType string, cooldown time.Duration) error
+	FileAppeal(ctx context.Context, chatID, userID int64, userName, sanctionType, text string, cooldown time.Duration) (*repository.Appeal, []int64, error)
+	GetAppeal(ctx context.Context, appealID uint) (*repository.Appeal, error)
+	ResolveAppeal(ctx context.Context, appealID uint, adminID int64, decision string) (*repository.Appeal, time.Time, error)
 	GetActiveMutesPaginated(ctx context.Context, chatID int64, page int) ([]repository.Mute, int64, error)
 	GetMute(ctx context.Context, chatID, userID int64) (*repository.Mute, error)
 	UnmuteUser(ctx context.Context, chatID, adminID, userID int64) error
+	GetSanctionHistory(ctx context.Context, chatID, userID int64, page int) ([]repository.SanctionRecord, int64, error)
+	LogAction(ctx context.Context, entry repository.AuditEntry) uint
+	GetAuditEntry(ctx context.Context, id uint) (*repository.AuditEntry, error)
+	NotifyDeletion(ctx context.Context, entry repository.AuditEntry, userName string)
+	RemoveMatchedRule(ctx context.Context, auditID uint, adminID int64) error
+	ExemptUser(ctx context.Context, auditID uint, adminID int64) error
+	UndoStrike(ctx context.Context, auditID uint, adminID int64) (bool, bool, error)
+	RemoveBlockedWord(ctx context.Context, chatID int64, word string) (bool, error)
+	RemoveBlockedDomain(ctx context.Context, chatID int64, domain string) (bool, error)
+	GetBlocklistPage(ctx context.Context, chatID int64, kind, query string, page int) ([]string, int, error)
+	ExportBlocklist(ctx context.Context, chatID int64, kind string) ([]byte, int, error)
+	PreviewBlocklistImport(ctx context.Context, chatID int64, imp *BlocklistImport, mode string) (ImportDiff, error)
+	ApplyBlocklistImport(ctx context.Context, chatID int64, imp *BlocklistImport, mode string) (ImportDiff, error)
+	ExportSettingsSnapshot(ctx context.Context, chatID int64, format string) ([]byte, error)
+	ImportSettingsSnapshot(ctx context.Context, chatID, adminID int64, data []byte, format string) (*SettingsSnapshot, error)
+	PreviewSettingsCopy(ctx context.Context, sourceID int64, targetIDs []int64, sections []string) ([]CopyPreview, error)
+	CopySettings(ctx context.Context, sourceID, adminID int64, targetIDs []int64, sections []string) ([]CopyPreview, error)
+	GetPresets(ctx context.Context, userID int64) ([]repository.SettingsPreset, error)
+	GetPreset(ctx context.Context, userID int64, presetID uint) (*repository.SettingsPreset, error)
+	GetPresetData(ctx context.Context, userID int64, presetID uint) (*PresetData, error)
+	SavePresetFromChat(ctx context.Context, chatID, userID int64, name string, shared, operator bool) (*repository.SettingsPreset, error)
+	UpdatePresetFromChat(ctx context.Context, presetID uint, chatID, userID int64, operator bool) (int, error)
+	ApplyPreset(ctx context.Context, chatID, userID int64, presetID uint, subscribe bool) error
+	UnsubscribePreset(ctx context.Context, chatID, userID int64) error
+	GetPresetStatus(ctx context.Context, chatID int64) (*PresetStatus, error)
+	DeletePreset(ctx context.Context, presetID uint, userID int64, operator bool) error
+	GetChatRole(ctx context.Context, chatID, userID int64) (string, error)
+	GetCoAdmins(ctx context.Context, chatID int64) ([]repository.ChatAdmin, error)
+	CreateCoAdminInvite(ctx context.Context, chatID, ownerID int64, role string) (string, error)
+	AcceptCoAdminInvite(ctx context.Context, token string, userID int64) (*repository.LinkToken, error)
+	RemoveCoAdmin(ctx context.Context, chatID, ownerID, userID int64) error
+	GetAuditLog(ctx context.Context, chatID int64, filter repository.AuditFilter, page int) ([]repository.AuditEntry, int64, error)
+	ArchiveMessage(ctx context.Context, message repository.ArchivedMessage) error
+	GetArchivedMessages(ctx context.Context, chatID int64, page int) ([]repository.ArchivedMessage, int64, error)
+	GetArchivedMessage(ctx context.Context, id uint) (*repository.ArchivedMessage, error)
+	RestoreArchivedMessage(ctx context.Context, id uint, moderatorID int64) error
+	MarkFalsePositive(ctx context.Context, id uint, moderatorID int64) error
+	SetArchiveRetention(ctx context.Context, chatID int64, days int) error
+	TrustUser(ctx context.Context, chatID, moderatorID, userID int64) error
+	UntrustUser(ctx context.Context, chatID, moderatorID, userID int64) error
+	IsTrustedUser(ctx context.Context, chatID, userID int64) (bool, error)
+	StartArchiveCleanupTask(ctx context.Context)
+	FileReport(ctx context.Context, report *repository.Report) ([]int64, error)
+	SetReportNotices(ctx context.Context, reportID uint, noticeIDs []string) error
+	ResolveReport(ctx context.Context, reportID uint, adminID int64, status string) (*repository.Report, error)
+	ReopenReport(ctx context.Context, reportID uint, status string) error
 	GetChatStats(ctx context.Context, chatID int64) (*repository.ChatStats, error)
 	StartMetricsUpdater(ctx context.Context)
 	StartCleanupTask(ctx context.Context, bot *maxbot.Api)
+	StartSanctionExpiryTask(ctx context.Context)
+	StartStrikeDecayTask(ctx context.Context)
+	StartAdminSyncTask(ctx context.Context, interval, grace time.Duration)
+	SyncChatAdmins(ctx context.Context, chatID int64, grace time.Duration) (*AdminSyncResult, error)
+	SyncChatAdminsOnChange(ctx context.Context, chatID int64, grace time.Duration) error
 	ScheduleDeletion(ctx context.Context, chatID int64, messageID string, duration time.Duration) error
 	IsChatAdmin(ctx context.Context, chatID, userID int64) (bool, error)
 	IsChatOwner(ctx context.Context, chatID, userID int64) (bool, error)
+	GetScheduleRules(ctx context.Context, chatID int64) ([]repository.ScheduleRule, error)
+	AddScheduleRule(ctx context.Context, chatID int64, text string) (*repository.ScheduleRule, error)
+	DeleteScheduleRule(ctx context.Context, chatID int64, ruleID uint) error
+	SetTimezone(ctx context.Context, chatID int64, tz string) error
+	SetSlowMode(ctx context.Context, chatID int64, seconds int) error
+	StartScheduleNotifier(ctx context.Context)
+	GetNotificationPrefs(ctx context.Context, chatID, userID int64) (*repository.NotificationPref, error)
+	ToggleNotification(ctx context.Context, chatID, userID int64, option string) (bool, error)
+	CycleDigest(ctx context.Context, chatID, userID int64) (string, error)
+	TrackJoin(ctx context.Context, chatID int64) bool
+	NotifyBotRemoved(ctx context.Context, chatID int64)
+	StartNotificationTasks(ctx context.Context)
 }
 
 type ModerationService struct {
-	logger          *slog.Logger
-	settingsRepo    repository.SettingsRepository
-	chatAdminRepo   repository.ChatAdminRepository
-	linkTokenRepo   repository.LinkTokenRepository
-	muteRepo        repository.MuteRepository
-	tempMessageRepo repository.TemporaryMessageRepository
-	violationRepo   repository.ViolationRepository
-	pipeline        *pipeline.Manager
-	tracer          trace.Tracer
-	bot             *maxbot.Api
+	logger           *slog.Logger
+	settingsRepo     repository.SettingsRepository
+	chatAdminRepo    repository.ChatAdminRepository
+	linkTokenRepo    repository.LinkTokenRepository
+	muteRepo         repository.MuteRepository
+	tempMessageRepo  repository.TemporaryMessageRepository
+	violationRepo    repository.ViolationRepository
+	scheduleRepo     repository.ScheduleRepository
+	strikeLadderRepo repository.StrikeLadderRepository
+	banRepo          repository.BanRepository
+	appealRepo       repository.AppealRepository
+	federationRepo   repository.FederationRepository
+	globalBanRepo    repository.GlobalBanRepository
+	historyRepo      repository.SanctionHistoryRepository
+	auditRepo        repository.AuditRepository
+	archiveRepo      repository.ArchiveRepository
+	reportRepo       repository.ReportRepository
+	notificationRepo repository.NotificationRepository
+	presetRepo       repository.PresetRepository
+	remover          MemberRemover
+	notifier         AdminNotifier
+	pipeline         *pipeline.Manager
+	trustedPipeline  *pipeline.Manager
+	tracer           trace.Tracer
+	bot              *maxbot.Api
+	adminCache       sync.Map
+	scheduleState    sync.Map
+	joinState        sync.Map
+	botRightsState   sync.Map
+	adminSyncState   sync.Map
 }
 
 func NewModerationService(
@@ -65,29 +187,61 @@ func NewModerationService(
 	muteRepo repository.MuteRepository,
 	tempMessageRepo repository.TemporaryMessageRepository,
 	violationRepo repository.ViolationRepository,
+	scheduleRepo repository.ScheduleRepository,
+	strikeLadderRepo repository.StrikeLadderRepository,
+	banRepo repository.BanRepository,
+	appealRepo repository.AppealRepository,
+	federationRepo repository.FederationRepository,
+	globalBanRepo repository.GlobalBanRepository,
+	historyRepo repository.SanctionHistoryRepository,
+	auditRepo repository.AuditRepository,
+	archiveRepo repository.ArchiveRepository,
+	reportRepo repository.ReportRepository,
+	notificationRepo repository.NotificationRepository,
+	presetRepo repository.PresetRepository,
 	bot *maxbot.Api,
 ) Service {
-
-	linkFilter := filters.NewLinkFilter(settingsRepo, violationRepo)
-	wordFilter := filters.NewWordFilter(settingsRepo, violationRepo)
+	s := &ModerationService{
+		logger:           logger,
+		settingsRepo:     settingsRepo,
+		chatAdminRepo:    chatAdminRepo,
+		linkTokenRepo:    linkTokenRepo,
+		muteRepo:         muteRepo,
+		tempMessageRepo:  tempMessageRepo,
+		violationRepo:    violationRepo,
+		scheduleRepo:     scheduleRepo,
+		strikeLadderRepo: strikeLadderRepo,
+		banRepo:          banRepo,
+		appealRepo:       appealRepo,
+		federationRepo:   federationRepo,
+		globalBanRepo:    globalBanRepo,
+		historyRepo:      historyRepo,
+		auditRepo:        auditRepo,
+		archiveRepo:      archiveRepo,
+		reportRepo:       reportRepo,
+		notificationRepo: notificationRepo,
+		presetRepo:       presetRepo,
+		remover:          NewMaxMemberRemover(bot),
+		notifier:         NewMaxNotifier(bot),
+		tracer:           otel.Tracer("service"),
+		bot:              bot,
+	}
+
+	scheduledSettings := schedule.NewSettingsOverlay(settingsRepo, scheduleRepo)
+
+	linkFilter := filters.NewLinkFilter(scheduledSettings, violationRepo)
+	wordFilter := filters.NewWordFilter(scheduledSettings, violationRepo)
 	muteFilter := filters.NewMuteFilter(muteRepo, settingsRepo)
-	attachmentFilter := filters.NewAttachmentFilter(settingsRepo, violationRepo)
+	scheduleFilter := filters.NewScheduleFilter(settingsRepo, scheduleRepo, s.isCachedChatAdmin)
+	slowModeFilter := filters.NewSlowModeFilter(settingsRepo, s.isCachedChatAdmin)
+	suspectFilter := filters.NewSuspectFilter(s.isSuspect)
+	attachmentFilter := filters.NewAttachmentFilter(scheduledSettings, violationRepo)
 	rateLimitFilter := filters.NewRateLimitFilter(5, 1*time.Second)
 
-	pm := pipeline.NewManager(rateLimitFilter, muteFilter, linkFilter, wordFilter, attachmentFilter)
+	s.pipeline = pipeline.NewManager(rateLimitFilter, muteFilter, scheduleFilter, slowModeFilter, suspectFilter, linkFilter, wordFilter, attachmentFilter)
+	s.trustedPipeline = pipeline.NewManager(rateLimitFilter, muteFilter, scheduleFilter)
 
-	return &ModerationService{
-		logger:          logger,
-		settingsRepo:    settingsRepo,
-		chatAdminRepo:   chatAdminRepo,
-		linkTokenRepo:   linkTokenRepo,
-		muteRepo:        muteRepo,
-		tempMessageRepo: tempMessageRepo,
-		violationRepo:   violationRepo,
-		pipeline:        pm,
-		tracer:          otel.Tracer("service"),
-		bot:             bot,
-	}
+	return s
 }
 
 func (s *ModerationService) StartMetricsUpdater(ctx context.Context) {
@@ -122,6 +276,9 @@ func (s *ModerationService) ModerateMessage(ctx context.Context, payload pipelin
 	defer span.End()
 
 	s.logger.Debug("Moderating message", "chat_id", payload.ChatID, "user_id", payload.SenderID)
+	if s.isTrusted(payload.ChatID, payload.SenderID) {
+		return s.trustedPipeline.Process(ctx, payload)
+	}
 	return s.pipeline.Process(ctx, payload)
 }
 
@@ -185,6 +342,15 @@ func (s *ModerationService) ToggleSetting(ctx context.Context, chatID int64, set
 	case "file", "document":
 		settings.RestrictFile = !settings.RestrictFile
 		newValue = settings.RestrictFile
+	case "schedulenotices", "schedule_notices":
+		settings.ScheduleNotices = !settings.ScheduleNotices
+		newValue = settings.ScheduleNotices
+	case "globalbans", "global_bans":
+		settings.UseGlobalBans = !settings.UseGlobalBans
+		newValue = settings.UseGlobalBans
+	case "archive":
+		settings.EnableArchive = !settings.EnableArchive
+		newValue = settings.EnableArchive
 	default:
 		return false, fmt.Errorf("unknown setting: %s", setting)
 	}
@@ -228,6 +394,27 @@ func (s *ModerationService) SetBlockedWords(ctx context.Context, chatID int64, w
 		return err
 	}
 
+	settings.BlockedWords = normalizeWords(words)
+	pruneWordRules(settings)
+	return s.settingsRepo.UpdateSettings(settings)
+}
+
+func pruneWordRules(settings *repository.ChatSettings) {
+	if len(settings.WordRules) == 0 {
+		return
+	}
+	kept := make(map[string]bool, len(settings.BlockedWords))
+	for _, word := range settings.BlockedWords {
+		kept[word] = true
+	}
+	for word := range settings.WordRules {
+		if !kept[word] {
+			delete(settings.WordRules, word)
+		}
+	}
+}
+
+func normalizeWords(words []string) []string {
 	unique := make(map[string]struct{})
 	var normalized []string
 	for _, w := range words {
@@ -240,8 +427,7 @@ func (s *ModerationService) SetBlockedWords(ctx context.Context, chatID int64, w
 			normalized = append(normalized, norm)
 		}
 	}
-	settings.BlockedWords = normalized
-	return s.settingsRepo.UpdateSettings(settings)
+	return normalized
 }
 
 func (s *ModerationService) AddBlockedDomains(ctx context.Context, chatID int64, domains []string) error {
@@ -278,6 +464,11 @@ func (s *ModerationService) SetBlockedDomains(ctx context.Context, chatID int64,
 		return err
 	}
 
+	settings.BlockedDomains = normalizeDomains(domains)
+	return s.settingsRepo.UpdateSettings(settings)
+}
+
+func normalizeDomains(domains []string) []string {
 	unique := make(map[string]struct{})
 	var normalized []string
 	for _, d := range domains {
@@ -290,7 +481,70 @@ func (s *ModerationService) SetBlockedDomains(ctx context.Context, chatID int64,
 			normalized = append(normalized, norm)
 		}
 	}
-	settings.BlockedDomains = normalized
+	return normalized
+}
+
+func (s *ModerationService) RemoveBlockedWord(ctx context.Context, chatID int64, word string) (bool, error) {
+	_, span := s.tracer.Start(ctx, "RemoveBlockedWord")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	remaining, removed := removeListEntry(settings.BlockedWords, strings.ToLower(strings.TrimSpace(word)))
+	if !removed {
+		return false, nil
+	}
+	settings.BlockedWords = remaining
+	pruneWordRules(settings)
+	return true, s.settingsRepo.UpdateSettings(settings)
+}
+
+func (s *ModerationService) RemoveBlockedDomain(ctx context.Context, chatID int64, domain string) (bool, error) {
+	_, span := s.tracer.Start(ctx, "RemoveBlockedDomain")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return false, err
+	}
+	remaining, removed := removeListEntry(settings.BlockedDomains, domain)
+	if !removed {
+		remaining, removed = removeListEntry(settings.BlockedDomains, utils.NormalizeDomain(domain))
+	}
+	if !removed {
+		return false, nil
+	}
+	settings.BlockedDomains = remaining
+	return true, s.settingsRepo.UpdateSettings(settings)
+}
+
+func removeListEntry(list []string, entry string) ([]string, bool) {
+	remaining := make([]string, 0, len(list))
+	removed := false
+	for _, item := range list {
+		if item == entry {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	return remaining, removed
+}
+
+func (s *ModerationService) SetSlowMode(ctx context.Context, chatID int64, seconds int) error {
+	_, span := s.tracer.Start(ctx, "SetSlowMode")
+	defer span.End()
+
+	if seconds < 0 {
+		return fmt.Errorf("invalid slow mode interval: %d", seconds)
+	}
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	settings.SlowModeSeconds = seconds
 	return s.settingsRepo.UpdateSettings(settings)
 }
 
@@ -311,60 +565,58 @@ func (s *ModerationService) LinkGroup(ctx context.Context, token string, chatID,
 	if linkToken.UserID != userID {
 		return fmt.Errorf("token does not belong to user")
 	}
+	if linkToken.Role != "" {
+		return fmt.Errorf("token is a co-admin invite")
+	}
 	if err := s.chatAdminRepo.AddAdmin(chatID, userID); err != nil {
 		return fmt.Errorf("failed to add admin: %w", err)
 	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: userID, Action: repository.AuditActionLink})
+	if linkToken.PresetID != nil {
+		if preset, err := s.availablePreset(userID, *linkToken.PresetID); err != nil {
+			s.logger.Warn("Preset from link token is unavailable", "preset_id", *linkToken.PresetID, "chat_id", chatID, "error", err)
+		} else if err := s.applyPreset(chatID, userID, preset, true); err != nil {
+			s.logger.Error("Failed to apply preset on link", "preset_id", preset.ID, "chat_id", chatID, "error", err)
+		}
+	}
 	return s.linkTokenRepo.Delete(token)
 }
 
-func (s *ModerationService) MuteUser(ctx context.Context, chatID, adminID, userID int64, userName string, duration time.Duration) error {
+func (s *ModerationService) MuteUser(ctx context.Context, chatID, adminID, userID int64, userName, reason string, duration time.Duration) error {
 	_, span := s.tracer.Start(ctx, "MuteUser")
 	defer span.End()
-	isAdmin, err := s.chatAdminRepo.IsAdmin(chatID, adminID)
-	if err != nil {
-		return fmt.Errorf("failed to check admin status: %w", err)
-	}
-	if !isAdmin {
-		return fmt.Errorf("user %d is not a bot admin in chat %d", adminID, chatID)
+	if err := s.requireRole(chatID, adminID, repository.RoleModerator); err != nil {
+		return err
 	}
-	if err := s.muteRepo.MuteUser(chatID, userID, userName, duration); err != nil {
+	reason = truncateReason(reason)
+	if err := s.muteRepo.MuteUser(chatID, userID, userName, reason, duration); err != nil {
 		return err
 	}
+	s.recordSanction(chatID, userID, userName, SanctionMute, reason, SanctionSourceAdmin, adminID, duration)
+	s.propagateSanction(ctx, chatID, adminID, func(siblingID int64) error {
+		if err := s.muteRepo.MuteUser(siblingID, userID, userName, reason, duration); err != nil {
+			return err
+		}
+		s.recordSanction(siblingID, userID, userName, SanctionMute, reason, SanctionSourceChatGroup, adminID, duration)
+		return nil
+	})
 	return nil
 }
-func (s *ModerationService) SystemMuteUser(ctx context.Context, chatID, userID int64, userName string, duration time.Duration) error {
+func (s *ModerationService) SystemMuteUser(ctx context.Context, chatID, userID int64, userName, reason, source string, duration time.Duration) error {
 	_, span := s.tracer.Start(ctx, "SystemMuteUser")
 	defer span.End()
-	if err := s.muteRepo.MuteUser(chatID, userID, userName, duration); err != nil {
+	reason = truncateReason(reason)
+	if err := s.muteRepo.MuteUser(chatID, userID, userName, reason, duration); err != nil {
 		return err
 	}
+	s.recordSanction(chatID, userID, userName, SanctionMute, reason, source, 0, duration)
 	go func() {
 		_ = s.violationRepo.IncrementChatStat(context.Background(), chatID, "mute_count")
 	}()
+	go s.notifyAdmins(context.Background(), chatID, NotifyEventAutoMute, fmt.Sprintf(messages.MsgNotifyAutoMute, userName, userID, utils.FormatDuration(duration), reason))
 	return nil
 }
 
-func (s *ModerationService) TrackViolation(ctx context.Context, chatID, userID int64, violationType string) (bool, time.Duration, error) {
-	_, span := s.tracer.Start(ctx, "TrackViolation")
-	defer span.End()
-
-	if err := s.violationRepo.AddViolation(ctx, chatID, userID, violationType); err != nil {
-		return false, 0, err
-	}
-
-	since := time.Now().Add(-24 * time.Hour)
-	count, err := s.violationRepo.CountViolationsSince(ctx, chatID, userID, since)
-	if err != nil {
-		return false, 0, err
-	}
-
-	if count >= 5 {
-		muteDuration := 24 * time.Hour
-		return true, muteDuration, nil
-	}
-
-	return false, 0, nil
-}
 func (s *ModerationService) GetActiveMutesPaginated(ctx context.Context, chatID int64, page int) ([]repository.Mute, int64, error) {
 	_, span := s.tracer.Start(ctx, "GetActiveMutesPaginated")
 	defer span.End()
@@ -379,16 +631,22 @@ func (s *ModerationService) GetMute(ctx context.Context, chatID, userID int64) (
 	return s.muteRepo.GetMute(chatID, userID)
 }
 func (s *ModerationService) UnmuteUser(ctx context.Context, chatID, adminID, userID int64) error {
-	_, span := s.tracer.Start(ctx, "UnmuteUser")
+	ctx, span := s.tracer.Start(ctx, "UnmuteUser")
 	defer span.End()
-	isAdmin, err := s.chatAdminRepo.IsAdmin(chatID, adminID)
-	if err != nil {
-		return fmt.Errorf("failed to check admin status: %w", err)
+	if err := s.requireRole(chatID, adminID, repository.RoleModerator); err != nil {
+		return err
 	}
-	if !isAdmin {
-		return fmt.Errorf("user %d is not a bot admin in chat %d", adminID, chatID)
+	return s.liftMute(ctx, chatID, userID, adminID, repository.SanctionEndUnmuted)
+}
+
+func (s *ModerationService) liftMute(ctx context.Context, chatID, userID, endedBy int64, endReason string) error {
+	if err := s.muteRepo.UnmuteUser(chatID, userID); err != nil {
+		return err
 	}
-	return s.muteRepo.UnmuteUser(chatID, userID)
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: endedBy, TargetUserID: userID, Action: repository.AuditActionUnmute, Reason: endReason})
+	s.endSanction(chatID, userID, SanctionMute, endedBy, endReason)
+	s.closeAppeals(chatID, userID, SanctionMute)
+	return nil
 }
 
 func (s *ModerationService) GetChatStats(ctx context.Context, chatID int64) (*repository.ChatStats, error) {
@@ -419,6 +677,45 @@ func (s *ModerationService) IsChatAdmin(ctx context.Context, chatID, userID int6
 	return false, nil
 }
 
+type cachedAdmins struct {
+	userIDs   map[int64]struct{}
+	expiresAt time.Time
+}
+
+const adminCacheTTL = 1 * time.Minute
+
+func (s *ModerationService) isCachedChatAdmin(ctx context.Context, chatID, userID int64) (bool, error) {
+	if val, ok := s.adminCache.Load(chatID); ok {
+		entry := val.(*cachedAdmins)
+		if time.Now().Before(entry.expiresAt) {
+			_, isAdmin := entry.userIDs[userID]
+			return isAdmin, nil
+		}
+		s.adminCache.Delete(chatID)
+	}
+
+	if s.bot == nil {
+		return false, fmt.Errorf("bot client not initialized in service")
+	}
+
+	adminList, err := s.bot.Chats.GetChatAdmins(ctx, chatID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get chat admins: %w", err)
+	}
+
+	entry := &cachedAdmins{
+		userIDs:   make(map[int64]struct{}, len(adminList.Members)),
+		expiresAt: time.Now().Add(adminCacheTTL),
+	}
+	for _, member := range adminList.Members {
+		entry.userIDs[member.UserId] = struct{}{}
+	}
+	s.adminCache.Store(chatID, entry)
+
+	_, isAdmin := entry.userIDs[userID]
+	return isAdmin, nil
+}
+
 func (s *ModerationService) IsChatOwner(ctx context.Context, chatID, userID int64) (bool, error) {
 	_, span := s.tracer.Start(ctx, "IsChatOwner")
 	defer span.End()
@@ -0,0 +1,492 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/schedule"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SnapshotVersion    = 2
+	SnapshotFormatJSON = "json"
+	SnapshotFormatYAML = "yaml"
+)
+
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+type SettingsSnapshot struct {
+	Version          int                    `json:"version" yaml:"version"`
+	ChatID           int64                  `json:"chat_id" yaml:"chat_id"`
+	ExportedAt       time.Time              `json:"exported_at" yaml:"exported_at"`
+	Settings         SnapshotSettings       `json:"settings" yaml:"settings"`
+	BlockedWords     []string               `json:"blocked_words" yaml:"blocked_words"`
+	BlockedDomains   []string               `json:"blocked_domains" yaml:"blocked_domains"`
+	WordRules        repository.WordRules   `json:"word_rules,omitempty" yaml:"word_rules,omitempty"`
+	Schedule         []SnapshotScheduleRule `json:"schedule" yaml:"schedule"`
+	StrikeLadder     []SnapshotStrikeStep   `json:"strike_ladder" yaml:"strike_ladder"`
+	ViolationWeights map[string]int         `json:"violation_weights" yaml:"violation_weights"`
+	TrustedUsers     []int64                `json:"trusted_users" yaml:"trusted_users"`
+}
+
+type SnapshotSettings struct {
+	EnableAutoDelete bool   `json:"enable_auto_delete" yaml:"enable_auto_delete"`
+	EnableWordFilter bool   `json:"enable_word_filter" yaml:"enable_word_filter"`
+	EnableLinkFilter bool   `json:"enable_link_filter" yaml:"enable_link_filter"`
+	EnableMute       bool   `json:"enable_mute" yaml:"enable_mute"`
+	RestrictImage    bool   `json:"restrict_image" yaml:"restrict_image"`
+	RestrictVideo    bool   `json:"restrict_video" yaml:"restrict_video"`
+	RestrictAudio    bool   `json:"restrict_audio" yaml:"restrict_audio"`
+	RestrictFile     bool   `json:"restrict_file" yaml:"restrict_file"`
+	Timezone         string `json:"timezone" yaml:"timezone"`
+	ScheduleNotices  bool   `json:"schedule_notices" yaml:"schedule_notices"`
+	SlowModeSeconds  int    `json:"slow_mode_seconds" yaml:"slow_mode_seconds"`
+	StrikeDecayDays  int    `json:"strike_decay_days" yaml:"strike_decay_days"`
+	UseGlobalBans    bool   `json:"use_global_bans" yaml:"use_global_bans"`
+	EnableArchive    bool   `json:"enable_archive" yaml:"enable_archive"`
+	ArchiveDays      int    `json:"archive_days" yaml:"archive_days"`
+}
+
+type SnapshotScheduleRule struct {
+	Days         string   `json:"days" yaml:"days"`
+	Window       string   `json:"window" yaml:"window"`
+	Restrictions []string `json:"restrictions" yaml:"restrictions"`
+}
+
+type SnapshotStrikeStep struct {
+	Strikes         int    `json:"strikes" yaml:"strikes"`
+	Action          string `json:"action" yaml:"action"`
+	DurationSeconds int64  `json:"duration_seconds,omitempty" yaml:"duration_seconds,omitempty"`
+	WindowSeconds   int64  `json:"window_seconds" yaml:"window_seconds"`
+}
+
+func snapshotSettingsFrom(settings *repository.ChatSettings) SnapshotSettings {
+	return SnapshotSettings{
+		EnableAutoDelete: settings.EnableAutoDelete,
+		EnableWordFilter: settings.EnableWordFilter,
+		EnableLinkFilter: settings.EnableLinkFilter,
+		EnableMute:       settings.EnableMute,
+		RestrictImage:    settings.RestrictImage,
+		RestrictVideo:    settings.RestrictVideo,
+		RestrictAudio:    settings.RestrictAudio,
+		RestrictFile:     settings.RestrictFile,
+		Timezone:         settings.Timezone,
+		ScheduleNotices:  settings.ScheduleNotices,
+		SlowModeSeconds:  settings.SlowModeSeconds,
+		StrikeDecayDays:  settings.StrikeDecayDays,
+		UseGlobalBans:    settings.UseGlobalBans,
+		EnableArchive:    settings.EnableArchive,
+		ArchiveDays:      settings.ArchiveDays,
+	}
+}
+
+func (s SnapshotSettings) applyTo(settings *repository.ChatSettings) {
+	settings.EnableAutoDelete = s.EnableAutoDelete
+	settings.EnableWordFilter = s.EnableWordFilter
+	settings.EnableLinkFilter = s.EnableLinkFilter
+	settings.EnableMute = s.EnableMute
+	settings.RestrictImage = s.RestrictImage
+	settings.RestrictVideo = s.RestrictVideo
+	settings.RestrictAudio = s.RestrictAudio
+	settings.RestrictFile = s.RestrictFile
+	settings.Timezone = s.Timezone
+	settings.ScheduleNotices = s.ScheduleNotices
+	settings.SlowModeSeconds = s.SlowModeSeconds
+	settings.StrikeDecayDays = s.StrikeDecayDays
+	settings.UseGlobalBans = s.UseGlobalBans
+	settings.EnableArchive = s.EnableArchive
+	settings.ArchiveDays = s.ArchiveDays
+}
+
+func (s SnapshotSettings) validate() error {
+	if _, err := schedule.LoadLocation(s.Timezone); err != nil {
+		return err
+	}
+	if s.SlowModeSeconds < 0 {
+		return fmt.Errorf("invalid slow mode interval: %d", s.SlowModeSeconds)
+	}
+	if s.StrikeDecayDays < 0 {
+		return fmt.Errorf("invalid strike decay period: %d", s.StrikeDecayDays)
+	}
+	if s.ArchiveDays < 0 || s.ArchiveDays > 365 {
+		return fmt.Errorf("invalid archive retention: %d", s.ArchiveDays)
+	}
+	return nil
+}
+
+func snapshotScheduleRule(rule repository.ScheduleRule) SnapshotScheduleRule {
+	var restrictions []string
+	for _, item := range []struct {
+		enabled bool
+		token   string
+	}{
+		{rule.ReadOnly, "readonly"},
+		{rule.WordFilter, "words"},
+		{rule.LinkFilter, "links"},
+		{rule.RestrictImage, "image"},
+		{rule.RestrictVideo, "video"},
+		{rule.RestrictAudio, "audio"},
+		{rule.RestrictFile, "file"},
+	} {
+		if item.enabled {
+			restrictions = append(restrictions, item.token)
+		}
+	}
+	return SnapshotScheduleRule{
+		Days:         schedule.FormatWeekdays(rule.Weekdays),
+		Window:       fmt.Sprintf("%02d:%02d-%02d:%02d", rule.StartMinute/60, rule.StartMinute%60, rule.EndMinute/60, rule.EndMinute%60),
+		Restrictions: restrictions,
+	}
+}
+
+func (r SnapshotScheduleRule) rule() (repository.ScheduleRule, error) {
+	return schedule.ParseRule(fmt.Sprintf("%s %s %s", r.Days, r.Window, strings.Join(r.Restrictions, ",")))
+}
+
+func (st SnapshotStrikeStep) step() (repository.StrikeStep, error) {
+	step := repository.StrikeStep{
+		Strikes:         st.Strikes,
+		Action:          strings.ToLower(st.Action),
+		DurationSeconds: st.DurationSeconds,
+		WindowSeconds:   st.WindowSeconds,
+	}
+	if step.Strikes < 1 {
+		return step, fmt.Errorf("invalid strike number: %d", st.Strikes)
+	}
+	if step.WindowSeconds <= 0 {
+		return step, fmt.Errorf("invalid counting window: %d", st.WindowSeconds)
+	}
+	switch step.Action {
+	case StrikeActionMute:
+		if step.DurationSeconds <= 0 {
+			return step, fmt.Errorf("mute step requires a duration")
+		}
+	case StrikeActionWarn, StrikeActionKick:
+		step.DurationSeconds = 0
+	default:
+		return step, fmt.Errorf("unknown action: %s", st.Action)
+	}
+	return step, nil
+}
+
+func (snap *SettingsSnapshot) validate() error {
+	if snap.Version < 1 || snap.Version > SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snap.Version)
+	}
+	if err := snap.Settings.validate(); err != nil {
+		return err
+	}
+	for word, rule := range snap.WordRules {
+		switch rule.Mode {
+		case "", repository.MatchContains, repository.MatchWord, repository.MatchExact:
+		default:
+			return fmt.Errorf("unknown match mode for %q: %s", word, rule.Mode)
+		}
+		if rule.Severity < 0 || rule.Severity > 10 {
+			return fmt.Errorf("invalid severity for %q: %d", word, rule.Severity)
+		}
+	}
+	for i, r := range snap.Schedule {
+		if _, err := r.rule(); err != nil {
+			return fmt.Errorf("schedule rule %d: %w", i+1, err)
+		}
+	}
+	for i, st := range snap.StrikeLadder {
+		if _, err := st.step(); err != nil {
+			return fmt.Errorf("strike step %d: %w", i+1, err)
+		}
+	}
+	for violationType, weight := range snap.ViolationWeights {
+		if _, ok := ViolationTypeLabels[violationType]; !ok {
+			return fmt.Errorf("unknown violation type: %s", violationType)
+		}
+		if weight < 0 {
+			return fmt.Errorf("invalid weight: %d", weight)
+		}
+	}
+	for _, userID := range snap.TrustedUsers {
+		if userID <= 0 {
+			return fmt.Errorf("invalid trusted user id: %d", userID)
+		}
+	}
+	return nil
+}
+
+func DetectSnapshotFormat(data []byte) string {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return SnapshotFormatJSON
+	}
+	return SnapshotFormatYAML
+}
+
+func EncodeSettingsSnapshot(snap *SettingsSnapshot, format string) ([]byte, error) {
+	switch format {
+	case SnapshotFormatJSON:
+		return json.MarshalIndent(snap, "", "  ")
+	case SnapshotFormatYAML:
+		return yaml.Marshal(snap)
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+func ParseSettingsSnapshot(data []byte, format string) (*SettingsSnapshot, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if format == "" {
+		format = DetectSnapshotFormat(data)
+	}
+	var snap SettingsSnapshot
+	switch format {
+	case SnapshotFormatJSON:
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	case SnapshotFormatYAML:
+		if err := yaml.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+	if err := snap.validate(); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+func (s *ModerationService) settingsSnapshot(chatID int64) (*SettingsSnapshot, error) {
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	snap := &SettingsSnapshot{
+		Version:          SnapshotVersion,
+		ChatID:           chatID,
+		ExportedAt:       time.Now().UTC().Truncate(time.Second),
+		Settings:         snapshotSettingsFrom(settings),
+		BlockedWords:     FilterBlocklist(settings.BlockedWords, ""),
+		BlockedDomains:   FilterBlocklist(settings.BlockedDomains, ""),
+		WordRules:        settings.WordRules,
+		ViolationWeights: map[string]int{},
+	}
+
+	rules, err := s.scheduleRepo.GetRules(chatID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		snap.Schedule = append(snap.Schedule, snapshotScheduleRule(rule))
+	}
+
+	steps, err := s.strikeLadderRepo.GetSteps(chatID)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		snap.StrikeLadder = append(snap.StrikeLadder, SnapshotStrikeStep{
+			Strikes:         step.Strikes,
+			Action:          step.Action,
+			DurationSeconds: step.DurationSeconds,
+			WindowSeconds:   step.WindowSeconds,
+		})
+	}
+	weights, err := s.strikeLadderRepo.GetWeights(chatID)
+	if err != nil {
+		return nil, err
+	}
+	for violationType, weight := range weights {
+		snap.ViolationWeights[violationType] = weight
+	}
+
+	trusted, err := s.archiveRepo.GetTrusted(chatID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range trusted {
+		snap.TrustedUsers = append(snap.TrustedUsers, t.UserID)
+	}
+	return snap, nil
+}
+
+func (s *ModerationService) ExportSettingsSnapshot(ctx context.Context, chatID int64, format string) ([]byte, error) {
+	_, span := s.tracer.Start(ctx, "ExportSettingsSnapshot")
+	defer span.End()
+
+	snap, err := s.settingsSnapshot(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeSettingsSnapshot(snap, format)
+}
+
+func (s *ModerationService) ExportBlocklist(ctx context.Context, chatID int64, kind string) ([]byte, int, error) {
+	_, span := s.tracer.Start(ctx, "ExportBlocklist")
+	defer span.End()
+
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var list []string
+	switch kind {
+	case BlocklistWords:
+		list = settings.BlockedWords
+	case BlocklistDomains:
+		list = settings.BlockedDomains
+	default:
+		return nil, 0, fmt.Errorf("unknown blocklist: %s", kind)
+	}
+	entries := FilterBlocklist(list, "")
+	if len(entries) == 0 {
+		return nil, 0, nil
+	}
+	return []byte(strings.Join(entries, "\n") + "\n"), len(entries), nil
+}
+
+func (s *ModerationService) ImportSettingsSnapshot(ctx context.Context, chatID, adminID int64, data []byte, format string) (*SettingsSnapshot, error) {
+	_, span := s.tracer.Start(ctx, "ImportSettingsSnapshot")
+	defer span.End()
+
+	snap, err := ParseSettingsSnapshot(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applySettingsSnapshot(chatID, adminID, snap); err != nil {
+		return nil, err
+	}
+	s.audit(repository.AuditEntry{
+		ChatID:  chatID,
+		ActorID: adminID,
+		Action:  repository.AuditActionSetting,
+		Reason:  fmt.Sprintf("import_snapshot: v%d", snap.Version),
+	})
+	return snap, nil
+}
+
+func (snap *SettingsSnapshot) applyTo(settings *repository.ChatSettings) {
+	snap.Settings.applyTo(settings)
+	settings.BlockedWords = normalizeWords(snap.BlockedWords)
+	settings.BlockedDomains = normalizeDomains(snap.BlockedDomains)
+	settings.WordRules = repository.WordRules{}
+	for word, rule := range snap.WordRules {
+		settings.WordRules[strings.ToLower(strings.TrimSpace(word))] = rule
+	}
+	pruneWordRules(settings)
+}
+
+func (s *ModerationService) applySettingsSnapshot(chatID, adminID int64, snap *SettingsSnapshot) error {
+	settings, err := s.settingsRepo.GetSettings(chatID)
+	if err != nil {
+		return err
+	}
+	snap.applyTo(settings)
+	if err := s.settingsRepo.UpdateSettings(settings); err != nil {
+		return err
+	}
+	if err := s.replaceScheduleRules(chatID, snap.Schedule); err != nil {
+		return err
+	}
+	if err := s.replaceStrikeLadder(chatID, snap.StrikeLadder); err != nil {
+		return err
+	}
+	if err := s.replaceViolationWeights(chatID, snap.ViolationWeights); err != nil {
+		return err
+	}
+	return s.replaceTrustedUsers(chatID, adminID, snap.TrustedUsers)
+}
+
+func (s *ModerationService) replaceScheduleRules(chatID int64, snapRules []SnapshotScheduleRule) error {
+	rules, err := s.scheduleRepo.GetRules(chatID)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := s.scheduleRepo.DeleteRule(chatID, rule.ID); err != nil {
+			return err
+		}
+	}
+	for _, r := range snapRules {
+		rule, err := r.rule()
+		if err != nil {
+			return err
+		}
+		rule.ChatID = chatID
+		if err := s.scheduleRepo.AddRule(&rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ModerationService) replaceStrikeLadder(chatID int64, snapSteps []SnapshotStrikeStep) error {
+	steps, err := s.strikeLadderRepo.GetSteps(chatID)
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		if err := s.strikeLadderRepo.DeleteStep(chatID, step.ID); err != nil {
+			return err
+		}
+	}
+	for _, st := range snapSteps {
+		step, err := st.step()
+		if err != nil {
+			return err
+		}
+		step.ChatID = chatID
+		if err := s.strikeLadderRepo.AddStep(&step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ModerationService) replaceViolationWeights(chatID int64, snapWeights map[string]int) error {
+	weights, err := s.strikeLadderRepo.GetWeights(chatID)
+	if err != nil {
+		return err
+	}
+	for violationType := range weights {
+		if _, ok := snapWeights[violationType]; !ok {
+			if err := s.strikeLadderRepo.SetWeight(chatID, violationType, 1); err != nil {
+				return err
+			}
+		}
+	}
+	for violationType, weight := range snapWeights {
+		if err := s.strikeLadderRepo.SetWeight(chatID, violationType, weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ModerationService) replaceTrustedUsers(chatID, adminID int64, userIDs []int64) error {
+	trusted, err := s.archiveRepo.GetTrusted(chatID)
+	if err != nil {
+		return err
+	}
+	keep := make(map[int64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		keep[userID] = true
+	}
+	for _, t := range trusted {
+		if !keep[t.UserID] {
+			if err := s.archiveRepo.RemoveTrusted(chatID, t.UserID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, userID := range userIDs {
+		if err := s.archiveRepo.AddTrusted(chatID, userID, adminID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
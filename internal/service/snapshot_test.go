@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestModerationService_SettingsSnapshotRoundTrip(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	for _, format := range []string{SnapshotFormatJSON, SnapshotFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			settings := map[int64]*repository.ChatSettings{
+				100: {ChatID: 100, BlockedWords: []string{"spam", "free money"}, WordRules: repository.WordRules{"spam": {Mode: repository.MatchWord, Severity: 3}}, BlockedDomains: []string{"bad.com"}, EnableWordFilter: true, RestrictVideo: true, Timezone: "Europe/Moscow", SlowModeSeconds: 30, ArchiveDays: 14},
+				200: {ChatID: 200, BlockedWords: []string{"old"}, EnableLinkFilter: true, Timezone: "UTC", ArchiveDays: 7},
+			}
+			rules := map[int64][]repository.ScheduleRule{
+				100: {{ID: 1, ChatID: 100, Weekdays: 0b0111110, StartMinute: 22 * 60, EndMinute: 7 * 60, ReadOnly: true, RestrictImage: true}},
+				200: {{ID: 9, ChatID: 200, Weekdays: 1, StartMinute: 0, EndMinute: 60, WordFilter: true}},
+			}
+			steps := map[int64][]repository.StrikeStep{
+				100: {{ID: 1, ChatID: 100, Strikes: 3, Action: StrikeActionMute, DurationSeconds: 3600, WindowSeconds: 86400}},
+				200: {{ID: 4, ChatID: 200, Strikes: 2, Action: StrikeActionKick, WindowSeconds: 3600}},
+			}
+			weights := map[int64]map[string]int{
+				100: {"word_filter": 2},
+				200: {"link_filter": 3},
+			}
+			trusted := map[int64][]repository.TrustedUser{
+				100: {{ChatID: 100, UserID: 42}},
+				200: {{ChatID: 200, UserID: 55}},
+			}
+
+			settingsRepo := &MockSettingsRepository{
+				GetSettingsFunc: func(chatID int64) (*repository.ChatSettings, error) {
+					copied := *settings[chatID]
+					return &copied, nil
+				},
+				UpdateSettingsFunc: func(s *repository.ChatSettings) error {
+					settings[s.ChatID] = s
+					return nil
+				},
+			}
+			scheduleRepo := &MockScheduleRepository{
+				GetRulesFunc: func(chatID int64) ([]repository.ScheduleRule, error) {
+					return rules[chatID], nil
+				},
+				AddRuleFunc: func(rule *repository.ScheduleRule) error {
+					rules[rule.ChatID] = append(rules[rule.ChatID], *rule)
+					return nil
+				},
+				DeleteRuleFunc: func(chatID int64, ruleID uint) error {
+					var kept []repository.ScheduleRule
+					for _, r := range rules[chatID] {
+						if r.ID != ruleID {
+							kept = append(kept, r)
+						}
+					}
+					rules[chatID] = kept
+					return nil
+				},
+			}
+			strikeLadderRepo := &MockStrikeLadderRepository{
+				GetStepsFunc: func(chatID int64) ([]repository.StrikeStep, error) {
+					return steps[chatID], nil
+				},
+				AddStepFunc: func(step *repository.StrikeStep) error {
+					steps[step.ChatID] = append(steps[step.ChatID], *step)
+					return nil
+				},
+				DeleteStepFunc: func(chatID int64, stepID uint) error {
+					var kept []repository.StrikeStep
+					for _, s := range steps[chatID] {
+						if s.ID != stepID {
+							kept = append(kept, s)
+						}
+					}
+					steps[chatID] = kept
+					return nil
+				},
+				GetWeightsFunc: func(chatID int64) (map[string]int, error) {
+					return weights[chatID], nil
+				},
+				SetWeightFunc: func(chatID int64, violationType string, weight int) error {
+					weights[chatID][violationType] = weight
+					return nil
+				},
+			}
+			archiveRepo := &MockArchiveRepository{
+				GetTrustedFunc: func(chatID int64) ([]repository.TrustedUser, error) {
+					return trusted[chatID], nil
+				},
+				AddTrustedFunc: func(chatID, userID, addedBy int64) error {
+					trusted[chatID] = append(trusted[chatID], repository.TrustedUser{ChatID: chatID, UserID: userID, AddedBy: addedBy})
+					return nil
+				},
+				RemoveTrustedFunc: func(chatID, userID int64) error {
+					var kept []repository.TrustedUser
+					for _, u := range trusted[chatID] {
+						if u.UserID != userID {
+							kept = append(kept, u)
+						}
+					}
+					trusted[chatID] = kept
+					return nil
+				},
+			}
+			var audited *repository.AuditEntry
+			auditRepo := &MockAuditRepository{
+				AddFunc: func(entry *repository.AuditEntry) error {
+					audited = entry
+					return nil
+				},
+			}
+			svc := NewModerationService(logger, settingsRepo, nil, nil, nil, nil, nil, scheduleRepo, strikeLadderRepo, nil, nil, nil, nil, nil, auditRepo, archiveRepo, nil, nil, nil, nil)
+
+			data, err := svc.ExportSettingsSnapshot(context.Background(), 100, format)
+			if err != nil {
+				t.Fatalf("ExportSettingsSnapshot() error = %v", err)
+			}
+			snap, err := svc.ImportSettingsSnapshot(context.Background(), 200, 5, data, "")
+			if err != nil {
+				t.Fatalf("ImportSettingsSnapshot() error = %v\n%s", err, data)
+			}
+			if snap.Version != SnapshotVersion || snap.ChatID != 100 {
+				t.Errorf("snapshot version/chat = %d/%d, want %d/100", snap.Version, snap.ChatID, SnapshotVersion)
+			}
+
+			got := settings[200]
+			if !reflect.DeepEqual([]string(got.BlockedWords), []string{"free money", "spam"}) || !reflect.DeepEqual([]string(got.BlockedDomains), []string{"bad.com"}) {
+				t.Errorf("lists = %v / %v, want source lists", got.BlockedWords, got.BlockedDomains)
+			}
+			if !reflect.DeepEqual(got.WordRules, repository.WordRules{"spam": {Mode: repository.MatchWord, Severity: 3}}) {
+				t.Errorf("word rules = %v, want source rules", got.WordRules)
+			}
+			if got.EnableLinkFilter || !got.EnableWordFilter || !got.RestrictVideo || got.Timezone != "Europe/Moscow" || got.SlowModeSeconds != 30 || got.ArchiveDays != 14 {
+				t.Errorf("settings = %+v, want source toggles", got)
+			}
+			if len(rules[200]) != 1 {
+				t.Fatalf("rules = %+v, want one imported rule", rules[200])
+			}
+			rule := rules[200][0]
+			if rule.Weekdays != 0b0111110 || rule.StartMinute != 22*60 || rule.EndMinute != 7*60 || !rule.ReadOnly || !rule.RestrictImage || rule.WordFilter {
+				t.Errorf("rule = %+v, want source rule", rule)
+			}
+			if len(steps[200]) != 1 || steps[200][0].Action != StrikeActionMute || steps[200][0].DurationSeconds != 3600 || steps[200][0].ChatID != 200 {
+				t.Errorf("steps = %+v, want source step", steps[200])
+			}
+			if !reflect.DeepEqual(weights[200], map[string]int{"word_filter": 2, "link_filter": 1}) {
+				t.Errorf("weights = %v, want word_filter=2 and link_filter reset", weights[200])
+			}
+			var trustedIDs []int64
+			for _, u := range trusted[200] {
+				trustedIDs = append(trustedIDs, u.UserID)
+			}
+			sort.Slice(trustedIDs, func(i, j int) bool { return trustedIDs[i] < trustedIDs[j] })
+			if !reflect.DeepEqual(trustedIDs, []int64{42}) {
+				t.Errorf("trusted = %v, want [42]", trustedIDs)
+			}
+			if audited == nil || audited.ChatID != 200 || audited.ActorID != 5 || audited.Action != repository.AuditActionSetting {
+				t.Errorf("audit entry = %+v, want settings import by 5", audited)
+			}
+		})
+	}
+}
+
+func TestParseSettingsSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{name: "Valid yaml", data: "version: 1\nsettings:\n  timezone: UTC\n  archive_days: 7\nblocked_words: [spam]\n"},
+		{name: "Valid json", data: `{"version": 2, "settings": {"timezone": "UTC", "archive_days": 7}}`},
+		{name: "Previous version", data: `{"version": 1, "settings": {"timezone": "UTC", "archive_days": 7}}`},
+		{name: "Future version", data: `{"version": 3, "settings": {"archive_days": 7}}`, wantErr: ErrUnsupportedSnapshotVersion},
+		{name: "Missing version", data: "settings:\n  archive_days: 7\n", wantErr: ErrUnsupportedSnapshotVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSettingsSnapshot([]byte(tt.data), "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSettingsSnapshot() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	invalid := []string{
+		`{"version": 1, "settings": {"timezone": "Mars/Base"}}`,
+		`{"version": 1, "schedule": [{"days": "пн", "window": "25:00-26:00", "restrictions": ["words"]}]}`,
+		`{"version": 1, "strike_ladder": [{"strikes": 2, "action": "mute", "window_seconds": 60}]}`,
+		`{"version": 1, "violation_weights": {"unknown": 1}}`,
+		`{"version": 2, "word_rules": {"spam": {"mode": "regex"}}}`,
+		"version: 1\ntrusted_users: [-1]\n",
+	}
+	for _, data := range invalid {
+		if _, err := ParseSettingsSnapshot([]byte(data), ""); err == nil {
+			t.Errorf("ParseSettingsSnapshot(%s) expected error", data)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"max-moderation-bot/internal/repository"
+	"max-moderation-bot/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StrikeActionWarn = "warn"
+	StrikeActionMute = "mute"
+	StrikeActionKick = "kick"
+)
+
+var defaultStrikeLadder = []repository.StrikeStep{
+	{Strikes: 5, Action: StrikeActionMute, DurationSeconds: int64((24 * time.Hour).Seconds()), WindowSeconds: int64((24 * time.Hour).Seconds())},
+}
+
+var violationTypeAliases = map[string]string{
+	"слова":          "word_filter",
+	"words":          "word_filter",
+	"ссылки":         "link_filter",
+	"links":          "link_filter",
+	"вложения":       "attachment_filter",
+	"медиа":          "attachment_filter",
+	"attachments":    "attachment_filter",
+	"media":          "attachment_filter",
+	"предупреждения": repository.ManualWarningType,
+	"warnings":       repository.ManualWarningType,
+}
+
+var ViolationTypeLabels = map[string]string{
+	"word_filter":                "слова",
+	"link_filter":                "ссылки",
+	"attachment_filter":          "вложения",
+	repository.ManualWarningType: "предупреждения",
+}
+
+type StrikeOutcome struct {
+	Strikes  int
+	Action   string
+	Duration time.Duration
+	Next     *repository.StrikeStep
+}
+
+func EvaluateStrikeLadder(steps []repository.StrikeStep, added int, strikesInWindow func(window time.Duration) (int, error)) (*StrikeOutcome, error) {
+	sorted := make([]repository.StrikeStep, len(steps))
+	copy(sorted, steps)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Strikes < sorted[j].Strikes })
+
+	counts := make(map[int64]int)
+	outcome := &StrikeOutcome{Action: StrikeActionWarn}
+	var longestWindow int64 = -1
+	for i, step := range sorted {
+		count, ok := counts[step.WindowSeconds]
+		if !ok {
+			var err error
+			count, err = strikesInWindow(time.Duration(step.WindowSeconds) * time.Second)
+			if err != nil {
+				return nil, err
+			}
+			counts[step.WindowSeconds] = count
+		}
+		if step.WindowSeconds > longestWindow {
+			longestWindow = step.WindowSeconds
+			outcome.Strikes = count
+		}
+		if count >= step.Strikes {
+			if count-added < step.Strikes {
+				outcome.Action = step.Action
+				outcome.Duration = time.Duration(step.DurationSeconds) * time.Second
+			}
+			outcome.Next = nil
+			continue
+		}
+		if outcome.Next == nil {
+			outcome.Next = &sorted[i]
+		}
+	}
+	return outcome, nil
+}
+
+func ParseStrikeStep(text string) (*repository.StrikeStep, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) < 2 || len(fields) > 4 {
+		return nil, fmt.Errorf("expected <strikes> <warn|mute|kick> [duration] [window]")
+	}
+	strikes, err := strconv.Atoi(fields[0])
+	if err != nil || strikes < 1 {
+		return nil, fmt.Errorf("invalid strike number: %s", fields[0])
+	}
+	step := &repository.StrikeStep{
+		Strikes:       strikes,
+		Action:        fields[1],
+		WindowSeconds: int64((24 * time.Hour).Seconds()),
+	}
+	rest := fields[2:]
+	switch step.Action {
+	case StrikeActionMute:
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("mute step requires a duration")
+		}
+		duration, err := utils.ParseDuration(rest[0])
+		if err != nil || duration == utils.Forever {
+			return nil, fmt.Errorf("invalid mute duration: %s", rest[0])
+		}
+		step.DurationSeconds = int64(duration.Seconds())
+		rest = rest[1:]
+	case StrikeActionWarn, StrikeActionKick:
+	default:
+		return nil, fmt.Errorf("unknown action: %s", fields[1])
+	}
+	if len(rest) > 1 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(rest[1:], " "))
+	}
+	if len(rest) == 1 {
+		window, err := utils.ParseDuration(rest[0])
+		if err != nil || window == utils.Forever {
+			return nil, fmt.Errorf("invalid counting window: %s", rest[0])
+		}
+		step.WindowSeconds = int64(window.Seconds())
+	}
+	return step, nil
+}
+
+func ParseViolationWeights(text string) (map[string]int, error) {
+	weights := make(map[string]int)
+	for _, item := range strings.Split(text, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight entry: %s", item)
+		}
+		violationType, ok := violationTypeAliases[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown violation type: %s", strings.TrimSpace(name))
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight: %s", strings.TrimSpace(value))
+		}
+		weights[violationType] = weight
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("no weights given")
+	}
+	return weights, nil
+}
+
+func (s *ModerationService) TrackViolation(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) (*StrikeOutcome, error) {
+	_, span := s.tracer.Start(ctx, "TrackViolation")
+	defer span.End()
+
+	if weight <= 0 {
+		var err error
+		weight, err = s.violationWeight(chatID, violationType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.violationRepo.AddViolation(ctx, chatID, userID, violationType, weight, messageID); err != nil {
+		return nil, err
+	}
+	if suspectViolationTypes[violationType] {
+		s.flagSuspectInSiblings(chatID, userID, violationType)
+	}
+
+	return s.evaluateStrikes(ctx, chatID, userID, weight)
+}
+
+func (s *ModerationService) violationWeight(chatID int64, violationType string) (int, error) {
+	if s.strikeLadderRepo == nil {
+		return 1, nil
+	}
+	weights, err := s.strikeLadderRepo.GetWeights(chatID)
+	if err != nil {
+		return 0, err
+	}
+	if w, ok := weights[violationType]; ok {
+		return w, nil
+	}
+	return 1, nil
+}
+
+func (s *ModerationService) evaluateStrikes(ctx context.Context, chatID, userID int64, added int) (*StrikeOutcome, error) {
+	steps, err := s.getStrikeLadder(chatID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return EvaluateStrikeLadder(steps, added, func(window time.Duration) (int, error) {
+		return s.violationRepo.SumViolationWeightsSince(ctx, chatID, userID, now.Add(-window))
+	})
+}
+
+func (s *ModerationService) getStrikeLadder(chatID int64) ([]repository.StrikeStep, error) {
+	if s.strikeLadderRepo == nil {
+		return defaultStrikeLadder, nil
+	}
+	steps, err := s.strikeLadderRepo.GetSteps(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return defaultStrikeLadder, nil
+	}
+	return steps, nil
+}
+
+func (s *ModerationService) GetStrikeLadder(ctx context.Context, chatID int64) ([]repository.StrikeStep, bool, error) {
+	_, span := s.tracer.Start(ctx, "GetStrikeLadder")
+	defer span.End()
+
+	steps, err := s.strikeLadderRepo.GetSteps(chatID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(steps) == 0 {
+		return defaultStrikeLadder, true, nil
+	}
+	return steps, false, nil
+}
+
+func (s *ModerationService) AddStrikeStep(ctx context.Context, chatID int64, text string) (*repository.StrikeStep, error) {
+	_, span := s.tracer.Start(ctx, "AddStrikeStep")
+	defer span.End()
+
+	step, err := ParseStrikeStep(text)
+	if err != nil {
+		return nil, err
+	}
+	step.ChatID = chatID
+	if err := s.strikeLadderRepo.AddStep(step); err != nil {
+		return nil, err
+	}
+	return step, nil
+}
+
+func (s *ModerationService) DeleteStrikeStep(ctx context.Context, chatID int64, stepID uint) error {
+	_, span := s.tracer.Start(ctx, "DeleteStrikeStep")
+	defer span.End()
+	return s.strikeLadderRepo.DeleteStep(chatID, stepID)
+}
+
+func (s *ModerationService) GetViolationWeights(ctx context.Context, chatID int64) (map[string]int, error) {
+	_, span := s.tracer.Start(ctx, "GetViolationWeights")
+	defer span.End()
+	return s.strikeLadderRepo.GetWeights(chatID)
+}
+
+func (s *ModerationService) SetViolationWeights(ctx context.Context, chatID int64, text string) error {
+	_, span := s.tracer.Start(ctx, "SetViolationWeights")
+	defer span.End()
+
+	weights, err := ParseViolationWeights(text)
+	if err != nil {
+		return err
+	}
+	for violationType, weight := range weights {
+		if err := s.strikeLadderRepo.SetWeight(chatID, violationType, weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"log/slog"
+	"max-moderation-bot/internal/repository"
 	"os"
 	"testing"
 	"time"
@@ -17,7 +18,8 @@ func TestModerationService_TrackViolation(t *testing.T) {
 		userID        int64
 		violationType string
 		setupMocks    func() *MockViolationRepository
-		wantMute      bool
+		wantAction    string
+		wantDuration  time.Duration
 		wantErr       bool
 	}{
 		{
@@ -27,16 +29,16 @@ func TestModerationService_TrackViolation(t *testing.T) {
 			violationType: "link_filter",
 			setupMocks: func() *MockViolationRepository {
 				return &MockViolationRepository{
-					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string) error {
+					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
 						return nil
 					},
-					CountViolationsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+					SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
 						return 4, nil
 					},
 				}
 			},
-			wantMute: false,
-			wantErr:  false,
+			wantAction: StrikeActionWarn,
+			wantErr:    false,
 		},
 		{
 			name:          "At Limit - violation added, trigger mute",
@@ -45,54 +47,217 @@ func TestModerationService_TrackViolation(t *testing.T) {
 			violationType: "word_filter",
 			setupMocks: func() *MockViolationRepository {
 				return &MockViolationRepository{
-					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string) error {
+					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
+						if weight != 1 {
+							t.Errorf("AddViolation called with weight %d, want 1", weight)
+						}
 						return nil
 					},
-					CountViolationsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+					SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
 						expectedSince := time.Now().Add(-24 * time.Hour)
 						diff := expectedSince.Sub(since)
 						if diff < -1*time.Second || diff > 1*time.Second {
-							t.Errorf("CountViolationsSince called with wrong time. Got %v, want ~ %v", since, expectedSince)
+							t.Errorf("SumViolationWeightsSince called with wrong time. Got %v, want ~ %v", since, expectedSince)
 						}
 						return 5, nil
 					},
 				}
 			},
-			wantMute: true,
-			wantErr:  false,
+			wantAction:   StrikeActionMute,
+			wantDuration: 24 * time.Hour,
+			wantErr:      false,
 		},
 		{
-			name:          "Above Limit - violation added, trigger mute",
+			name:          "Above Limit - step already applied, no repeat mute",
 			chatID:        123,
 			userID:        456,
 			violationType: "word_filter",
 			setupMocks: func() *MockViolationRepository {
 				return &MockViolationRepository{
-					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string) error {
+					AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
 						return nil
 					},
-					CountViolationsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
-						return 10, nil
+					SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+						return 6, nil
 					},
 				}
 			},
-			wantMute: true,
-			wantErr:  false,
+			wantAction: StrikeActionWarn,
+			wantErr:    false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			violationRepo := tt.setupMocks()
-			svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil)
+			svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
-			mute, _, err := svc.TrackViolation(context.Background(), tt.chatID, tt.userID, tt.violationType)
+			outcome, err := svc.TrackViolation(context.Background(), tt.chatID, tt.userID, tt.violationType, 0, "")
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TrackViolation() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if mute != tt.wantMute {
-				t.Errorf("TrackViolation() mute = %v, want %v", mute, tt.wantMute)
+			if outcome.Action != tt.wantAction {
+				t.Errorf("TrackViolation() action = %v, want %v", outcome.Action, tt.wantAction)
+			}
+			if outcome.Duration != tt.wantDuration {
+				t.Errorf("TrackViolation() duration = %v, want %v", outcome.Duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestModerationService_TrackViolation_CustomLadder(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	day := int64((24 * time.Hour).Seconds())
+	week := int64((7 * 24 * time.Hour).Seconds())
+	steps := []repository.StrikeStep{
+		{ID: 4, Strikes: 7, Action: StrikeActionKick, WindowSeconds: week},
+		{ID: 1, Strikes: 1, Action: StrikeActionWarn, WindowSeconds: day},
+		{ID: 2, Strikes: 3, Action: StrikeActionMute, DurationSeconds: 3600, WindowSeconds: day},
+		{ID: 3, Strikes: 5, Action: StrikeActionMute, DurationSeconds: day, WindowSeconds: day},
+	}
+
+	tests := []struct {
+		name         string
+		dayCount     int
+		weekCount    int
+		wantAction   string
+		wantDuration time.Duration
+		wantStrikes  int
+		wantNextID   uint
+	}{
+		{name: "first strike warns", dayCount: 1, weekCount: 1, wantAction: StrikeActionWarn, wantStrikes: 1, wantNextID: 2},
+		{name: "third strike mutes for an hour", dayCount: 3, weekCount: 3, wantAction: StrikeActionMute, wantDuration: time.Hour, wantStrikes: 3, wantNextID: 3},
+		{name: "fifth strike mutes for a day", dayCount: 5, weekCount: 6, wantAction: StrikeActionMute, wantDuration: 24 * time.Hour, wantStrikes: 6, wantNextID: 4},
+		{name: "seventh strike in a week kicks", dayCount: 2, weekCount: 7, wantAction: StrikeActionKick, wantStrikes: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotWeight int
+			violationRepo := &MockViolationRepository{
+				AddViolationFunc: func(ctx context.Context, chatID, userID int64, violationType string, weight int, messageID string) error {
+					gotWeight = weight
+					return nil
+				},
+				SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+					if time.Since(since) > 48*time.Hour {
+						return tt.weekCount, nil
+					}
+					return tt.dayCount, nil
+				},
+			}
+			ladderRepo := &MockStrikeLadderRepository{
+				GetStepsFunc: func(chatID int64) ([]repository.StrikeStep, error) {
+					return steps, nil
+				},
+				GetWeightsFunc: func(chatID int64) (map[string]int, error) {
+					return map[string]int{"link_filter": 2}, nil
+				},
+			}
+			svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil, ladderRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			outcome, err := svc.TrackViolation(context.Background(), 1, 2, "link_filter", 0, "")
+			if err != nil {
+				t.Fatalf("TrackViolation() error = %v", err)
+			}
+			if gotWeight != 2 {
+				t.Errorf("AddViolation weight = %d, want 2", gotWeight)
+			}
+			if outcome.Action != tt.wantAction || outcome.Duration != tt.wantDuration {
+				t.Errorf("outcome = %s/%v, want %s/%v", outcome.Action, outcome.Duration, tt.wantAction, tt.wantDuration)
+			}
+			if outcome.Strikes != tt.wantStrikes {
+				t.Errorf("strikes = %d, want %d", outcome.Strikes, tt.wantStrikes)
+			}
+			var nextID uint
+			if outcome.Next != nil {
+				nextID = outcome.Next.ID
+			}
+			if nextID != tt.wantNextID {
+				t.Errorf("next step = %d, want %d", nextID, tt.wantNextID)
+			}
+		})
+	}
+}
+
+func TestParseStrikeStep(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    repository.StrikeStep
+		wantErr bool
+	}{
+		{input: "1 warn", want: repository.StrikeStep{Strikes: 1, Action: "warn", WindowSeconds: 86400}},
+		{input: "3 mute 1h", want: repository.StrikeStep{Strikes: 3, Action: "mute", DurationSeconds: 3600, WindowSeconds: 86400}},
+		{input: "7 kick 168h", want: repository.StrikeStep{Strikes: 7, Action: "kick", WindowSeconds: 604800}},
+		{input: "5 MUTE 24h 48h", want: repository.StrikeStep{Strikes: 5, Action: "mute", DurationSeconds: 86400, WindowSeconds: 172800}},
+		{input: "3 mute", wantErr: true},
+		{input: "0 warn", wantErr: true},
+		{input: "2 ban", wantErr: true},
+		{input: "2 warn 1h 2h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseStrikeStep(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStrikeStep() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("ParseStrikeStep() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseViolationWeights(t *testing.T) {
+	got, err := ParseViolationWeights("ссылки=2, слова = 1, медиа=0")
+	if err != nil {
+		t.Fatalf("ParseViolationWeights() error = %v", err)
+	}
+	want := map[string]int{"link_filter": 2, "word_filter": 1, "attachment_filter": 0}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("weight[%s] = %d, want %d", k, got[k], v)
+		}
+	}
+	for _, input := range []string{"", "ссылки", "спам=2", "ссылки=-1"} {
+		if _, err := ParseViolationWeights(input); err == nil {
+			t.Errorf("ParseViolationWeights(%q) expected error", input)
+		}
+	}
+}
+
+func TestEvaluateStrikeLadder_AppliesStepOnce(t *testing.T) {
+	day := int64((24 * time.Hour).Seconds())
+	steps := []repository.StrikeStep{
+		{Strikes: 3, Action: StrikeActionMute, DurationSeconds: 3600, WindowSeconds: day},
+		{Strikes: 5, Action: StrikeActionKick, WindowSeconds: day},
+	}
+
+	tests := []struct {
+		name         string
+		count        int
+		added        int
+		wantAction   string
+		wantDuration time.Duration
+	}{
+		{name: "Strike reaching the threshold mutes", count: 3, added: 1, wantAction: StrikeActionMute, wantDuration: time.Hour},
+		{name: "Strike after the threshold does not mute again", count: 4, added: 1, wantAction: StrikeActionWarn},
+		{name: "Heavy strike crossing two steps applies the higher one", count: 6, added: 4, wantAction: StrikeActionKick},
+		{name: "Strike after the last threshold does nothing", count: 6, added: 1, wantAction: StrikeActionWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, err := EvaluateStrikeLadder(steps, tt.added, func(window time.Duration) (int, error) {
+				return tt.count, nil
+			})
+			if err != nil {
+				t.Fatalf("EvaluateStrikeLadder() error = %v", err)
+			}
+			if outcome.Action != tt.wantAction || outcome.Duration != tt.wantDuration {
+				t.Errorf("outcome = %s/%v, want %s/%v", outcome.Action, outcome.Duration, tt.wantAction, tt.wantDuration)
 			}
 		})
 	}
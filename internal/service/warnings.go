@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"max-moderation-bot/internal/repository"
+	"time"
+)
+
+func (s *ModerationService) WarnUser(ctx context.Context, chatID, moderatorID, userID int64, reason string) (*StrikeOutcome, error) {
+	ctx, span := s.tracer.Start(ctx, "WarnUser")
+	defer span.End()
+
+	if err := s.requireRole(chatID, moderatorID, repository.RoleModerator); err != nil {
+		return nil, err
+	}
+	reason = truncateReason(reason)
+	weight, err := s.violationWeight(chatID, repository.ManualWarningType)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.violationRepo.AddWarning(ctx, chatID, userID, moderatorID, reason, weight); err != nil {
+		return nil, err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: moderatorID, TargetUserID: userID, Action: repository.AuditActionWarn, Reason: reason})
+	return s.evaluateStrikes(ctx, chatID, userID, weight)
+}
+
+func (s *ModerationService) GetActiveWarnings(ctx context.Context, chatID, userID int64) ([]repository.UserViolation, error) {
+	ctx, span := s.tracer.Start(ctx, "GetActiveWarnings")
+	defer span.End()
+
+	steps, err := s.getStrikeLadder(chatID)
+	if err != nil {
+		return nil, err
+	}
+	var window int64
+	for _, step := range steps {
+		if step.WindowSeconds > window {
+			window = step.WindowSeconds
+		}
+	}
+	since := time.Now().Add(-time.Duration(window) * time.Second)
+	return s.violationRepo.GetViolationsSince(ctx, chatID, userID, repository.ManualWarningType, since)
+}
+
+func (s *ModerationService) RemoveLatestWarning(ctx context.Context, chatID, moderatorID, userID int64) (*repository.UserViolation, error) {
+	ctx, span := s.tracer.Start(ctx, "RemoveLatestWarning")
+	defer span.End()
+	if err := s.requireRole(chatID, moderatorID, repository.RoleModerator); err != nil {
+		return nil, err
+	}
+	removed, err := s.violationRepo.DeleteLatestViolation(ctx, chatID, userID, repository.ManualWarningType)
+	if err != nil || removed == nil {
+		return removed, err
+	}
+	s.audit(repository.AuditEntry{ChatID: chatID, ActorID: moderatorID, TargetUserID: userID, Action: repository.AuditActionUnwarn, Reason: removed.Reason})
+	return removed, nil
+}
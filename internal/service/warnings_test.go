@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"max-moderation-bot/internal/repository"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModerationService_WarnUser(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	var gotModerator int64
+	var gotReason string
+	var gotWeight int
+	violationRepo := &MockViolationRepository{
+		AddWarningFunc: func(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+			gotModerator, gotReason, gotWeight = moderatorID, reason, weight
+			return nil
+		},
+		SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+			return 3, nil
+		},
+	}
+	ladderRepo := &MockStrikeLadderRepository{
+		GetStepsFunc: func(chatID int64) ([]repository.StrikeStep, error) {
+			return []repository.StrikeStep{
+				{ID: 1, Strikes: 3, Action: StrikeActionMute, DurationSeconds: 3600, WindowSeconds: 86400},
+				{ID: 2, Strikes: 6, Action: StrikeActionKick, WindowSeconds: 86400},
+			}, nil
+		},
+		GetWeightsFunc: func(chatID int64) (map[string]int, error) {
+			return map[string]int{repository.ManualWarningType: 2}, nil
+		},
+	}
+	adminRepo := rolesRepo(map[int64]string{1: repository.RoleModerator})
+	svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, violationRepo, nil, ladderRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	outcome, err := svc.WarnUser(context.Background(), 100, 1, 456, "флуд")
+	if err != nil {
+		t.Fatalf("WarnUser() error = %v", err)
+	}
+	if gotModerator != 1 || gotReason != "флуд" || gotWeight != 2 {
+		t.Errorf("AddWarning got moderator=%d reason=%q weight=%d", gotModerator, gotReason, gotWeight)
+	}
+	if outcome.Action != StrikeActionMute || outcome.Duration != time.Hour {
+		t.Errorf("outcome = %s/%v, want mute/1h", outcome.Action, outcome.Duration)
+	}
+	if outcome.Next == nil || outcome.Next.ID != 2 {
+		t.Errorf("next step = %+v, want step 2", outcome.Next)
+	}
+}
+
+func TestModerationService_GetActiveWarnings(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	violationRepo := &MockViolationRepository{
+		GetViolationsSinceFunc: func(ctx context.Context, chatID, userID int64, violationType string, since time.Time) ([]repository.UserViolation, error) {
+			if violationType != repository.ManualWarningType {
+				t.Errorf("violationType = %s, want %s", violationType, repository.ManualWarningType)
+			}
+			expectedSince := time.Now().Add(-7 * 24 * time.Hour)
+			if diff := expectedSince.Sub(since); diff < -time.Second || diff > time.Second {
+				t.Errorf("since = %v, want ~ %v", since, expectedSince)
+			}
+			return []repository.UserViolation{{ID: 1}}, nil
+		},
+	}
+	ladderRepo := &MockStrikeLadderRepository{
+		GetStepsFunc: func(chatID int64) ([]repository.StrikeStep, error) {
+			return []repository.StrikeStep{
+				{Strikes: 1, Action: StrikeActionWarn, WindowSeconds: 86400},
+				{Strikes: 5, Action: StrikeActionKick, WindowSeconds: 7 * 86400},
+			}, nil
+		},
+	}
+	svc := NewModerationService(logger, nil, nil, nil, nil, nil, violationRepo, nil, ladderRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	warnings, err := svc.GetActiveWarnings(context.Background(), 100, 456)
+	if err != nil {
+		t.Fatalf("GetActiveWarnings() error = %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("got %d warnings, want 1", len(warnings))
+	}
+}
+
+func TestModerationService_WarnUser_TruncatesReason(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	var gotReason string
+	violationRepo := &MockViolationRepository{
+		AddWarningFunc: func(ctx context.Context, chatID, userID, moderatorID int64, reason string, weight int) error {
+			gotReason = reason
+			return nil
+		},
+		SumViolationWeightsSinceFunc: func(ctx context.Context, chatID, userID int64, since time.Time) (int, error) {
+			return 1, nil
+		},
+	}
+	ladderRepo := &MockStrikeLadderRepository{
+		GetStepsFunc: func(chatID int64) ([]repository.StrikeStep, error) {
+			return nil, nil
+		},
+		GetWeightsFunc: func(chatID int64) (map[string]int, error) {
+			return nil, nil
+		},
+	}
+	adminRepo := rolesRepo(map[int64]string{1: repository.RoleModerator})
+	svc := NewModerationService(logger, nil, adminRepo, nil, nil, nil, violationRepo, nil, ladderRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	if _, err := svc.WarnUser(context.Background(), 100, 1, 456, strings.Repeat("ф", 600)); err != nil {
+		t.Fatalf("WarnUser() error = %v", err)
+	}
+	if n := len([]rune(gotReason)); n != 500 {
+		t.Errorf("stored reason length = %d, want 500", n)
+	}
+}
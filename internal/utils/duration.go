@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const Forever = time.Duration(math.MaxInt64)
+
+const MaxDuration = 10 * 365 * 24 * time.Hour
+
+var foreverWords = map[string]struct{}{
+	"forever":   {},
+	"permanent": {},
+	"perm":      {},
+	"навсегда":  {},
+	"бессрочно": {},
+}
+
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"с": time.Second, "сек": time.Second, "секунда": time.Second, "секунды": time.Second, "секунд": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"м": time.Minute, "мин": time.Minute, "минута": time.Minute, "минуты": time.Minute, "минут": time.Minute, "минуту": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"ч": time.Hour, "час": time.Hour, "часа": time.Hour, "часов": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"д": 24 * time.Hour, "дн": 24 * time.Hour, "день": 24 * time.Hour, "дня": 24 * time.Hour, "дней": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"н": 7 * 24 * time.Hour, "нед": 7 * 24 * time.Hour, "неделя": 7 * 24 * time.Hour, "недели": 7 * 24 * time.Hour, "недель": 7 * 24 * time.Hour, "неделю": 7 * 24 * time.Hour,
+}
+
+func ParseDuration(s string) (time.Duration, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if _, ok := foreverWords[input]; ok {
+		return Forever, nil
+	}
+	runes := []rune(input)
+	var total time.Duration
+	for i := 0; i < len(runes); {
+		start := i
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+		}
+		if start == i {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		value, err := strconv.Atoi(string(runes[start:i]))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		unitStart := i
+		for i < len(runes) && !unicode.IsDigit(runes[i]) {
+			i++
+		}
+		unit, ok := durationUnits[strings.TrimRight(string(runes[unitStart:i]), ".")]
+		if !ok {
+			return 0, fmt.Errorf("unknown duration unit in %s", s)
+		}
+		if time.Duration(value) > (MaxDuration-total)/unit {
+			return 0, fmt.Errorf("duration too long: %s", s)
+		}
+		total += time.Duration(value) * unit
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	return total, nil
+}
+
+func FormatDuration(d time.Duration) string {
+	if d == Forever {
+		return "навсегда"
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%d сек", int(d.Seconds()))
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%d д", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d ч", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%d мин", minutes))
+	}
+	return strings.Join(parts, " ")
+}
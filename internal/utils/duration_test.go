@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30m", want: 30 * time.Minute},
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "1d", want: 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "30мин", want: 30 * time.Minute},
+		{input: "1ч30м", want: 90 * time.Minute},
+		{input: "2дня", want: 48 * time.Hour},
+		{input: "1нед", want: 7 * 24 * time.Hour},
+		{input: "520w", want: 520 * 7 * 24 * time.Hour},
+		{input: "10s", want: 10 * time.Second},
+		{input: "Навсегда", want: Forever},
+		{input: "forever", want: Forever},
+		{input: "", wantErr: true},
+		{input: "1x", wantErr: true},
+		{input: "ч", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "45", wantErr: true},
+		{input: "3651d", wantErr: true},
+		{input: "9999999999999h", wantErr: true},
+		{input: "99999999999999999999m", wantErr: true},
+		{input: "0m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{input: 30 * time.Second, want: "30 сек"},
+		{input: 90 * time.Minute, want: "1 ч 30 мин"},
+		{input: 26 * time.Hour, want: "1 д 2 ч"},
+		{input: Forever, want: "навсегда"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.input); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT 'UTC';
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS schedule_notices BOOLEAN DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS schedule_rules (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    weekdays INTEGER NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    word_filter BOOLEAN DEFAULT FALSE,
+    link_filter BOOLEAN DEFAULT FALSE,
+    restrict_image BOOLEAN DEFAULT FALSE,
+    restrict_video BOOLEAN DEFAULT FALSE,
+    restrict_audio BOOLEAN DEFAULT FALSE,
+    restrict_file BOOLEAN DEFAULT FALSE,
+    read_only BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_rules_chat_id ON schedule_rules(chat_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS schedule_rules;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS schedule_notices;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS slow_mode_seconds INTEGER DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS slow_mode_seconds;
+-- +goose StatementEnd
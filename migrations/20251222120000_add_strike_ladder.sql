@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS weight INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS strike_steps (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    strikes INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    duration_seconds BIGINT DEFAULT 0,
+    window_seconds BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_strike_steps_chat_id ON strike_steps(chat_id);
+
+CREATE TABLE IF NOT EXISTS violation_weights (
+    chat_id BIGINT NOT NULL,
+    violation_type VARCHAR(50) NOT NULL,
+    weight INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (chat_id, violation_type)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS violation_weights;
+DROP TABLE IF EXISTS strike_steps;
+ALTER TABLE user_violations DROP COLUMN IF EXISTS weight;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bans (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    user_id BIGINT,
+    user_name VARCHAR(255),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bans_chat_id ON bans(chat_id);
+CREATE INDEX IF NOT EXISTS idx_bans_user_id ON bans(user_id);
+CREATE INDEX IF NOT EXISTS idx_bans_expires_at ON bans(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bans;
+-- +goose StatementEnd
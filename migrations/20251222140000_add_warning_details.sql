@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS reason VARCHAR(500);
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS moderator_id BIGINT DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_violations DROP COLUMN IF EXISTS moderator_id;
+ALTER TABLE user_violations DROP COLUMN IF EXISTS reason;
+-- +goose StatementEnd
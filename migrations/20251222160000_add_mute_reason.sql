@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mutes ADD COLUMN IF NOT EXISTS reason VARCHAR(500);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mutes DROP COLUMN IF EXISTS reason;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS appeals (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    user_name VARCHAR(255),
+    sanction_type VARCHAR(20) NOT NULL,
+    text VARCHAR(2000),
+    status VARCHAR(20) NOT NULL,
+    resolved_by BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_appeals_chat_id ON appeals(chat_id);
+CREATE INDEX IF NOT EXISTS idx_appeals_user_id ON appeals(user_id);
+CREATE INDEX IF NOT EXISTS idx_appeals_status ON appeals(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS appeals;
+-- +goose StatementEnd
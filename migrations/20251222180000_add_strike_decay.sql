@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS strike_decay_days INTEGER DEFAULT 0;
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS forgiven_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS forgiven_by BIGINT DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_violations DROP COLUMN IF EXISTS forgiven_by;
+ALTER TABLE user_violations DROP COLUMN IF EXISTS forgiven_at;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS strike_decay_days;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS chat_groups (
+    id SERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    propagate BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_groups_owner_id ON chat_groups(owner_id);
+
+CREATE TABLE IF NOT EXISTS chat_group_members (
+    chat_id BIGINT PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_group_members_group_id ON chat_group_members(group_id);
+
+CREATE TABLE IF NOT EXISTS suspects (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    user_id BIGINT,
+    source_chat_id BIGINT NOT NULL,
+    reason VARCHAR(500),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suspect_chat_user ON suspects(chat_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_suspects_expires_at ON suspects(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS suspects;
+DROP TABLE IF EXISTS chat_group_members;
+DROP TABLE IF EXISTS chat_groups;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS global_bans (
+    user_id BIGINT PRIMARY KEY,
+    reason VARCHAR(500),
+    added_by BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS global_ban_enforcements (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    user_id BIGINT,
+    trigger VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_global_ban_enforcements_chat_id ON global_ban_enforcements(chat_id);
+CREATE INDEX IF NOT EXISTS idx_global_ban_enforcements_user_id ON global_ban_enforcements(user_id);
+
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS use_global_bans BOOLEAN DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS use_global_bans;
+DROP TABLE IF EXISTS global_ban_enforcements;
+DROP TABLE IF EXISTS global_bans;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sanction_records (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    user_id BIGINT,
+    user_name VARCHAR(255),
+    type VARCHAR(20) NOT NULL,
+    duration_seconds BIGINT,
+    reason VARCHAR(500),
+    issued_by BIGINT,
+    source VARCHAR(50),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    ended_by BIGINT,
+    end_reason VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanction_chat_user ON sanction_records(chat_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_sanction_records_ended_at ON sanction_records(ended_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sanction_records;
+-- +goose StatementEnd
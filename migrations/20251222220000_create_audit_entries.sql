@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    actor_id BIGINT,
+    target_user_id BIGINT,
+    action VARCHAR(30) NOT NULL,
+    filter VARCHAR(50),
+    reason VARCHAR(500),
+    message_id VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_chat_created ON audit_entries(chat_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor_id ON audit_entries(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_target_user_id ON audit_entries(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_action ON audit_entries(action);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_entries;
+-- +goose StatementEnd
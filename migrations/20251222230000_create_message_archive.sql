@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS enable_archive BOOLEAN DEFAULT FALSE;
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS archive_days INTEGER DEFAULT 7;
+
+CREATE TABLE IF NOT EXISTS archived_messages (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT,
+    user_id BIGINT,
+    user_name VARCHAR(255),
+    message_id VARCHAR(100),
+    text TEXT,
+    attachments TEXT[],
+    filter VARCHAR(50),
+    reason VARCHAR(500),
+    status VARCHAR(20) DEFAULT 'pending',
+    reviewed_by BIGINT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_archive_chat_created ON archived_messages(chat_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_archived_messages_user_id ON archived_messages(user_id);
+CREATE INDEX IF NOT EXISTS idx_archived_messages_expires_at ON archived_messages(expires_at);
+
+CREATE TABLE IF NOT EXISTS trusted_users (
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    added_by BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (chat_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS trusted_users;
+DROP TABLE IF EXISTS archived_messages;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS archive_days;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS enable_archive;
+-- +goose StatementEnd
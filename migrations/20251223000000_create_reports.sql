@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reports (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    reporter_id BIGINT NOT NULL,
+    reporter_name VARCHAR(255),
+    target_user_id BIGINT,
+    target_name VARCHAR(255),
+    message_id VARCHAR(100),
+    text VARCHAR(4000),
+    reason VARCHAR(500),
+    status VARCHAR(20) NOT NULL,
+    resolved_by BIGINT,
+    notice_ids TEXT[],
+    created_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_chat_id ON reports(chat_id);
+CREATE INDEX IF NOT EXISTS idx_reports_target_user_id ON reports(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports(status);
+CREATE INDEX IF NOT EXISTS idx_report_reporter_created ON reports(reporter_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reports;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notification_prefs (
+    chat_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    notify_auto_mute BOOLEAN DEFAULT TRUE,
+    notify_raid BOOLEAN DEFAULT TRUE,
+    notify_rights BOOLEAN DEFAULT TRUE,
+    digest VARCHAR(10) DEFAULT 'off',
+    silenced BOOLEAN DEFAULT FALSE,
+    last_digest_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_prefs_user_id ON notification_prefs(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_prefs;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE audit_entries ADD COLUMN IF NOT EXISTS rule VARCHAR(255);
+ALTER TABLE archived_messages ADD COLUMN IF NOT EXISTS rule VARCHAR(255);
+ALTER TABLE archived_messages ADD COLUMN IF NOT EXISTS audit_id BIGINT;
+ALTER TABLE notification_prefs ADD COLUMN IF NOT EXISTS notify_deletes BOOLEAN DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notification_prefs DROP COLUMN IF EXISTS notify_deletes;
+ALTER TABLE archived_messages DROP COLUMN IF EXISTS audit_id;
+ALTER TABLE archived_messages DROP COLUMN IF EXISTS rule;
+ALTER TABLE audit_entries DROP COLUMN IF EXISTS rule;
+-- +goose StatementEnd
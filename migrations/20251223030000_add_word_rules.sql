@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS word_rules JSONB;
+ALTER TABLE user_states ADD COLUMN IF NOT EXISTS payload TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_states DROP COLUMN IF EXISTS payload;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS word_rules;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS settings_presets (
+    id SERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL DEFAULT 0,
+    created_by BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_settings_presets_owner_id ON settings_presets(owner_id);
+
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS preset_id BIGINT;
+ALTER TABLE chat_settings ADD COLUMN IF NOT EXISTS preset_baseline TEXT;
+CREATE INDEX IF NOT EXISTS idx_chat_settings_preset_id ON chat_settings(preset_id);
+
+ALTER TABLE link_tokens ADD COLUMN IF NOT EXISTS preset_id BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE link_tokens DROP COLUMN IF EXISTS preset_id;
+DROP INDEX IF EXISTS idx_chat_settings_preset_id;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS preset_baseline;
+ALTER TABLE chat_settings DROP COLUMN IF EXISTS preset_id;
+DROP TABLE IF EXISTS settings_presets;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_admins ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'owner';
+ALTER TABLE chat_admins ADD COLUMN IF NOT EXISTS invited_by BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE link_tokens ADD COLUMN IF NOT EXISTS chat_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE link_tokens ADD COLUMN IF NOT EXISTS role VARCHAR(20);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE link_tokens DROP COLUMN IF EXISTS role;
+ALTER TABLE link_tokens DROP COLUMN IF EXISTS chat_id;
+ALTER TABLE chat_admins DROP COLUMN IF EXISTS invited_by;
+ALTER TABLE chat_admins DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
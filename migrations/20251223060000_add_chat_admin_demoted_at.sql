@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_admins ADD COLUMN IF NOT EXISTS demoted_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_admins DROP COLUMN IF EXISTS demoted_at;
+-- +goose StatementEnd
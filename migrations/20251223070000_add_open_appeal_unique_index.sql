@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE appeals SET status = 'closed', resolved_at = NOW()
+WHERE status = 'open' AND id NOT IN (
+    SELECT MAX(id) FROM appeals WHERE status = 'open' GROUP BY chat_id, user_id, sanction_type
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_appeals_open_sanction ON appeals(chat_id, user_id, sanction_type) WHERE status = 'open';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_appeals_open_sanction;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_violations ADD COLUMN IF NOT EXISTS message_id VARCHAR(100) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_user_violations_message_id ON user_violations(message_id);
+ALTER TABLE audit_entries ADD COLUMN IF NOT EXISTS undone_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE audit_entries DROP COLUMN IF EXISTS undone_at;
+DROP INDEX IF EXISTS idx_user_violations_message_id;
+ALTER TABLE user_violations DROP COLUMN IF EXISTS message_id;
+-- +goose StatementEnd
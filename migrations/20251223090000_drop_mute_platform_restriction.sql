@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mutes DROP COLUMN IF EXISTS platform_restricted;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mutes ADD COLUMN IF NOT EXISTS platform_restricted BOOLEAN DEFAULT FALSE;
+-- +goose StatementEnd